@@ -10,9 +10,12 @@ import (
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
+	status "google.golang.org/genproto/googleapis/rpc/status"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
+	status1 "google.golang.org/grpc/status"
 	math "math"
 )
 
@@ -27,14 +30,90 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type Todo_Status int32
+
+const (
+	Todo_OPEN        Todo_Status = 0
+	Todo_IN_PROGRESS Todo_Status = 1
+	Todo_DONE        Todo_Status = 2
+)
+
+var Todo_Status_name = map[int32]string{
+	0: "OPEN",
+	1: "IN_PROGRESS",
+	2: "DONE",
+}
+
+var Todo_Status_value = map[string]int32{
+	"OPEN":        0,
+	"IN_PROGRESS": 1,
+	"DONE":        2,
+}
+
+func (x Todo_Status) String() string {
+	return proto.EnumName(Todo_Status_name, int32(x))
+}
+
+func (Todo_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{0, 0}
+}
+
+type TodoEvent_Type int32
+
+const (
+	TodoEvent_CREATED TodoEvent_Type = 0
+	TodoEvent_UPDATED TodoEvent_Type = 1
+	TodoEvent_DELETED TodoEvent_Type = 2
+)
+
+var TodoEvent_Type_name = map[int32]string{
+	0: "CREATED",
+	1: "UPDATED",
+	2: "DELETED",
+}
+
+var TodoEvent_Type_value = map[string]int32{
+	"CREATED": 0,
+	"UPDATED": 1,
+	"DELETED": 2,
+}
+
+func (x TodoEvent_Type) String() string {
+	return proto.EnumName(TodoEvent_Type_name, int32(x))
+}
+
+func (TodoEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{61, 0}
+}
+
 type Todo struct {
-	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title                string               `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description          string               `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Reminder             *timestamp.Timestamp `protobuf:"bytes,4,opt,name=reminder,proto3" json:"reminder,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Id          int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string               `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string               `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Reminder    *timestamp.Timestamp `protobuf:"bytes,4,opt,name=reminder,proto3" json:"reminder,omitempty"`
+	Status      Todo_Status          `protobuf:"varint,5,opt,name=status,proto3,enum=v1.Todo_Status" json:"status,omitempty"`
+	// time the todo was marked as done, set by the server
+	CompletedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Labels      []string             `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty"`
+	// time the todo was moved to trash, set by the server
+	DeletedAt *timestamp.Timestamp `protobuf:"bytes,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// version of the todo set by the server, send it back on Update to detect concurrent changes
+	Etag string `protobuf:"bytes,9,opt,name=etag,proto3" json:"etag,omitempty"`
+	// id of the todo in the system it was imported from, todos with the same external id are created only once
+	ExternalId string `protobuf:"bytes,10,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	// RFC 5545 recurrence rule such as FREQ=WEEKLY;BYDAY=MO, reminder is the first occurrence,
+	// completing the todo moves reminder to the next occurrence
+	Recurrence string `protobuf:"bytes,11,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// id of the list the todo belongs to, zero if it is not in any list
+	ListId int64 `protobuf:"varint,12,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	// IANA time zone of the reminder such as Asia/Colombo, empty means UTC. Recurring todo repeats
+	// at the same local time in the zone across daylight saving time changes
+	TimeZone string `protobuf:"bytes,13,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// reminder as RFC 3339 local time in time_zone such as 2026-10-20T09:00:00+05:30, set by the server
+	LocalReminder        string   `protobuf:"bytes,14,opt,name=local_reminder,json=localReminder,proto3" json:"local_reminder,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Todo) Reset()         { *m = Todo{} }
@@ -90,289 +169,486 @@ func (m *Todo) GetReminder() *timestamp.Timestamp {
 	return nil
 }
 
-type CreateRequest struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Todo                 *Todo    `protobuf:"bytes,2,opt,name=todo,proto3" json:"todo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Todo) GetStatus() Todo_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Todo_OPEN
 }
 
-func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
-func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateRequest) ProtoMessage()    {}
-func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80b701c7b1c502fe, []int{1}
+func (m *Todo) GetCompletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CompletedAt
+	}
+	return nil
 }
 
-func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+func (m *Todo) GetLabels() []string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
 }
-func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+
+func (m *Todo) GetDeletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
 }
-func (m *CreateRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateRequest.Merge(m, src)
+
+func (m *Todo) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
 }
-func (m *CreateRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateRequest.Size(m)
+
+func (m *Todo) GetExternalId() string {
+	if m != nil {
+		return m.ExternalId
+	}
+	return ""
 }
-func (m *CreateRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+
+func (m *Todo) GetRecurrence() string {
+	if m != nil {
+		return m.Recurrence
+	}
+	return ""
 }
 
-var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+func (m *Todo) GetListId() int64 {
+	if m != nil {
+		return m.ListId
+	}
+	return 0
+}
 
-func (m *CreateRequest) GetApi() string {
+func (m *Todo) GetTimeZone() string {
 	if m != nil {
-		return m.Api
+		return m.TimeZone
 	}
 	return ""
 }
 
-func (m *CreateRequest) GetTodo() *Todo {
+func (m *Todo) GetLocalReminder() string {
 	if m != nil {
-		return m.Todo
+		return m.LocalReminder
 	}
-	return nil
+	return ""
 }
 
-type CreateResponse struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+// TodoList groups todos of a project
+type TodoList struct {
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// name of the list, unique within the tenant
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// time the list was created, set by the server
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// time the list was last updated, set by the server
+	UpdateTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// number of todos in the list, todos in trash are not counted
+	TodoCount            int64    `protobuf:"varint,6,opt,name=todo_count,json=todoCount,proto3" json:"todo_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
-func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
-func (*CreateResponse) ProtoMessage()    {}
-func (*CreateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80b701c7b1c502fe, []int{2}
+func (m *TodoList) Reset()         { *m = TodoList{} }
+func (m *TodoList) String() string { return proto.CompactTextString(m) }
+func (*TodoList) ProtoMessage()    {}
+func (*TodoList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{1}
 }
 
-func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
+func (m *TodoList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TodoList.Unmarshal(m, b)
 }
-func (m *CreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateResponse.Marshal(b, m, deterministic)
+func (m *TodoList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TodoList.Marshal(b, m, deterministic)
 }
-func (m *CreateResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateResponse.Merge(m, src)
+func (m *TodoList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TodoList.Merge(m, src)
 }
-func (m *CreateResponse) XXX_Size() int {
-	return xxx_messageInfo_CreateResponse.Size(m)
+func (m *TodoList) XXX_Size() int {
+	return xxx_messageInfo_TodoList.Size(m)
 }
-func (m *CreateResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateResponse.DiscardUnknown(m)
+func (m *TodoList) XXX_DiscardUnknown() {
+	xxx_messageInfo_TodoList.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
+var xxx_messageInfo_TodoList proto.InternalMessageInfo
 
-func (m *CreateResponse) GetApi() string {
+func (m *TodoList) GetId() int64 {
 	if m != nil {
-		return m.Api
+		return m.Id
+	}
+	return 0
+}
+
+func (m *TodoList) GetName() string {
+	if m != nil {
+		return m.Name
 	}
 	return ""
 }
 
-func (m *CreateResponse) GetId() int64 {
+func (m *TodoList) GetDescription() string {
 	if m != nil {
-		return m.Id
+		return m.Description
+	}
+	return ""
+}
+
+func (m *TodoList) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *TodoList) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+func (m *TodoList) GetTodoCount() int64 {
+	if m != nil {
+		return m.TodoCount
 	}
 	return 0
 }
 
-type ReadRequest struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// Comment is a message in the discussion of the todo
+type Comment struct {
+	Id     int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TodoId int64 `protobuf:"varint,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	// who wrote the comment, the authenticated user, set by the server
+	Author string `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Body   string `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	// time the comment was added, set by the server
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// time the comment was last edited, not set if it was never edited
+	EditTime             *timestamp.Timestamp `protobuf:"bytes,6,opt,name=edit_time,json=editTime,proto3" json:"edit_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
-func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
-func (*ReadRequest) ProtoMessage()    {}
-func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80b701c7b1c502fe, []int{3}
+func (m *Comment) Reset()         { *m = Comment{} }
+func (m *Comment) String() string { return proto.CompactTextString(m) }
+func (*Comment) ProtoMessage()    {}
+func (*Comment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{2}
 }
 
-func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadRequest.Unmarshal(m, b)
+func (m *Comment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Comment.Unmarshal(m, b)
 }
-func (m *ReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadRequest.Marshal(b, m, deterministic)
+func (m *Comment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Comment.Marshal(b, m, deterministic)
 }
-func (m *ReadRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadRequest.Merge(m, src)
+func (m *Comment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Comment.Merge(m, src)
 }
-func (m *ReadRequest) XXX_Size() int {
-	return xxx_messageInfo_ReadRequest.Size(m)
+func (m *Comment) XXX_Size() int {
+	return xxx_messageInfo_Comment.Size(m)
 }
-func (m *ReadRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadRequest.DiscardUnknown(m)
+func (m *Comment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Comment.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
+var xxx_messageInfo_Comment proto.InternalMessageInfo
 
-func (m *ReadRequest) GetApi() string {
+func (m *Comment) GetId() int64 {
 	if m != nil {
-		return m.Api
+		return m.Id
 	}
-	return ""
+	return 0
 }
 
-func (m *ReadRequest) GetId() int64 {
+func (m *Comment) GetTodoId() int64 {
 	if m != nil {
-		return m.Id
+		return m.TodoId
 	}
 	return 0
 }
 
-type ReadResponse struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Todo                 *Todo    `protobuf:"bytes,2,opt,name=todo,proto3" json:"todo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Comment) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
 }
 
-func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
-func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
-func (*ReadResponse) ProtoMessage()    {}
-func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80b701c7b1c502fe, []int{4}
+func (m *Comment) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
 }
 
-func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadResponse.Unmarshal(m, b)
+func (m *Comment) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
 }
-func (m *ReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadResponse.Marshal(b, m, deterministic)
+
+func (m *Comment) GetEditTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EditTime
+	}
+	return nil
 }
-func (m *ReadResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadResponse.Merge(m, src)
+
+// Attachment is a file attached to the todo
+type Attachment struct {
+	Id          int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TodoId      int64  `protobuf:"varint,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	FileName    string `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType string `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// size of the file in bytes
+	Size int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// time the file was uploaded, set by the server
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
-func (m *ReadResponse) XXX_Size() int {
-	return xxx_messageInfo_ReadResponse.Size(m)
+
+func (m *Attachment) Reset()         { *m = Attachment{} }
+func (m *Attachment) String() string { return proto.CompactTextString(m) }
+func (*Attachment) ProtoMessage()    {}
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{3}
 }
-func (m *ReadResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadResponse.DiscardUnknown(m)
+
+func (m *Attachment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Attachment.Unmarshal(m, b)
+}
+func (m *Attachment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Attachment.Marshal(b, m, deterministic)
+}
+func (m *Attachment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attachment.Merge(m, src)
+}
+func (m *Attachment) XXX_Size() int {
+	return xxx_messageInfo_Attachment.Size(m)
+}
+func (m *Attachment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attachment.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadResponse proto.InternalMessageInfo
+var xxx_messageInfo_Attachment proto.InternalMessageInfo
 
-func (m *ReadResponse) GetApi() string {
+func (m *Attachment) GetId() int64 {
 	if m != nil {
-		return m.Api
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Attachment) GetTodoId() int64 {
+	if m != nil {
+		return m.TodoId
+	}
+	return 0
+}
+
+func (m *Attachment) GetFileName() string {
+	if m != nil {
+		return m.FileName
 	}
 	return ""
 }
 
-func (m *ReadResponse) GetTodo() *Todo {
+func (m *Attachment) GetContentType() string {
 	if m != nil {
-		return m.Todo
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *Attachment) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Attachment) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
 	}
 	return nil
 }
 
-type UpdateRequest struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Todo                 *Todo    `protobuf:"bytes,2,opt,name=todo,proto3" json:"todo,omitempty"`
+type Label struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// number of todos having the label
+	TodoCount            int64    `protobuf:"varint,2,opt,name=todo_count,json=todoCount,proto3" json:"todo_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
-func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
-func (*UpdateRequest) ProtoMessage()    {}
-func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80b701c7b1c502fe, []int{5}
+func (m *Label) Reset()         { *m = Label{} }
+func (m *Label) String() string { return proto.CompactTextString(m) }
+func (*Label) ProtoMessage()    {}
+func (*Label) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{4}
 }
 
-func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
+func (m *Label) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Label.Unmarshal(m, b)
 }
-func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
+func (m *Label) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Label.Marshal(b, m, deterministic)
 }
-func (m *UpdateRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateRequest.Merge(m, src)
+func (m *Label) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Label.Merge(m, src)
 }
-func (m *UpdateRequest) XXX_Size() int {
-	return xxx_messageInfo_UpdateRequest.Size(m)
+func (m *Label) XXX_Size() int {
+	return xxx_messageInfo_Label.Size(m)
 }
-func (m *UpdateRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
+func (m *Label) XXX_DiscardUnknown() {
+	xxx_messageInfo_Label.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
+var xxx_messageInfo_Label proto.InternalMessageInfo
 
-func (m *UpdateRequest) GetApi() string {
+func (m *Label) GetName() string {
 	if m != nil {
-		return m.Api
+		return m.Name
 	}
 	return ""
 }
 
-func (m *UpdateRequest) GetTodo() *Todo {
+func (m *Label) GetTodoCount() int64 {
+	if m != nil {
+		return m.TodoCount
+	}
+	return 0
+}
+
+type CreateRequest struct {
+	Api  string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Todo *Todo  `protobuf:"bytes,2,opt,name=todo,proto3" json:"todo,omitempty"`
+	// retries with the same key return the original response instead of creating another todo,
+	// the Idempotency-Key header can be sent instead
+	IdempotencyKey       string   `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
+func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()    {}
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{5}
+}
+
+func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+}
+func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRequest.Merge(m, src)
+}
+func (m *CreateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRequest.Size(m)
+}
+func (m *CreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+
+func (m *CreateRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetTodo() *Todo {
 	if m != nil {
 		return m.Todo
 	}
 	return nil
 }
 
-type UpdateResponse struct {
+func (m *CreateRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type CreateResponse struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
-func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
-func (*UpdateResponse) ProtoMessage()    {}
-func (*UpdateResponse) Descriptor() ([]byte, []int) {
+func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
+func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateResponse) ProtoMessage()    {}
+func (*CreateResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_80b701c7b1c502fe, []int{6}
 }
 
-func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
+func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
 }
-func (m *UpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateResponse.Marshal(b, m, deterministic)
+func (m *CreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateResponse.Marshal(b, m, deterministic)
 }
-func (m *UpdateResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateResponse.Merge(m, src)
+func (m *CreateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateResponse.Merge(m, src)
 }
-func (m *UpdateResponse) XXX_Size() int {
-	return xxx_messageInfo_UpdateResponse.Size(m)
+func (m *CreateResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateResponse.Size(m)
 }
-func (m *UpdateResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateResponse.DiscardUnknown(m)
+func (m *CreateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateResponse proto.InternalMessageInfo
+var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
 
-func (m *UpdateResponse) GetApi() string {
+func (m *CreateResponse) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *UpdateResponse) GetId() int64 {
+func (m *CreateResponse) GetId() int64 {
 	if m != nil {
 		return m.Id
 	}
 	return 0
 }
 
-type DeleteRequest struct {
+func (m *CreateResponse) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type ReadRequest struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -380,459 +656,6724 @@ type DeleteRequest struct {
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
-func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteRequest) ProtoMessage()    {}
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
+func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
+func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadRequest) ProtoMessage()    {}
+func (*ReadRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_80b701c7b1c502fe, []int{7}
 }
 
-func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadRequest.Unmarshal(m, b)
 }
-func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+func (m *ReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadRequest.Marshal(b, m, deterministic)
 }
-func (m *DeleteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteRequest.Merge(m, src)
+func (m *ReadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadRequest.Merge(m, src)
 }
-func (m *DeleteRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteRequest.Size(m)
+func (m *ReadRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadRequest.Size(m)
 }
-func (m *DeleteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+func (m *ReadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
 
-func (m *DeleteRequest) GetApi() string {
+func (m *ReadRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *DeleteRequest) GetId() int64 {
+func (m *ReadRequest) GetId() int64 {
 	if m != nil {
 		return m.Id
 	}
 	return 0
 }
 
-type DeleteResponse struct {
+type ReadResponse struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Deleted              int64    `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Todo                 *Todo    `protobuf:"bytes,2,opt,name=todo,proto3" json:"todo,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
-func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteResponse) ProtoMessage()    {}
-func (*DeleteResponse) Descriptor() ([]byte, []int) {
+func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
+func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadResponse) ProtoMessage()    {}
+func (*ReadResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_80b701c7b1c502fe, []int{8}
 }
 
-func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
+func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadResponse.Unmarshal(m, b)
 }
-func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteResponse.Marshal(b, m, deterministic)
+func (m *ReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadResponse.Marshal(b, m, deterministic)
 }
-func (m *DeleteResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteResponse.Merge(m, src)
+func (m *ReadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadResponse.Merge(m, src)
 }
-func (m *DeleteResponse) XXX_Size() int {
-	return xxx_messageInfo_DeleteResponse.Size(m)
+func (m *ReadResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadResponse.Size(m)
 }
-func (m *DeleteResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteResponse.DiscardUnknown(m)
+func (m *ReadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
+var xxx_messageInfo_ReadResponse proto.InternalMessageInfo
 
-func (m *DeleteResponse) GetApi() string {
+func (m *ReadResponse) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *DeleteResponse) GetDeleted() int64 {
+func (m *ReadResponse) GetTodo() *Todo {
 	if m != nil {
-		return m.Deleted
+		return m.Todo
 	}
-	return 0
+	return nil
 }
 
-type ReadAllRequest struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type UpdateRequest struct {
+	Api  string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Todo *Todo  `protobuf:"bytes,2,opt,name=todo,proto3" json:"todo,omitempty"`
+	// fields of the todo to update, title, description and reminder are replaced when empty
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *ReadAllRequest) Reset()         { *m = ReadAllRequest{} }
-func (m *ReadAllRequest) String() string { return proto.CompactTextString(m) }
-func (*ReadAllRequest) ProtoMessage()    {}
-func (*ReadAllRequest) Descriptor() ([]byte, []int) {
+func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
+func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRequest) ProtoMessage()    {}
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_80b701c7b1c502fe, []int{9}
 }
 
-func (m *ReadAllRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadAllRequest.Unmarshal(m, b)
+func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
 }
-func (m *ReadAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadAllRequest.Marshal(b, m, deterministic)
+func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
 }
-func (m *ReadAllRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadAllRequest.Merge(m, src)
+func (m *UpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRequest.Merge(m, src)
 }
-func (m *ReadAllRequest) XXX_Size() int {
-	return xxx_messageInfo_ReadAllRequest.Size(m)
+func (m *UpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRequest.Size(m)
 }
-func (m *ReadAllRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadAllRequest.DiscardUnknown(m)
+func (m *UpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadAllRequest proto.InternalMessageInfo
+var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
 
-func (m *ReadAllRequest) GetApi() string {
+func (m *UpdateRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-type ReadAllResponse struct {
+func (m *UpdateRequest) GetTodo() *Todo {
+	if m != nil {
+		return m.Todo
+	}
+	return nil
+}
+
+func (m *UpdateRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type UpdateResponse struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Todos                []*Todo  `protobuf:"bytes,2,rep,name=todos,proto3" json:"todos,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReadAllResponse) Reset()         { *m = ReadAllResponse{} }
-func (m *ReadAllResponse) String() string { return proto.CompactTextString(m) }
-func (*ReadAllResponse) ProtoMessage()    {}
-func (*ReadAllResponse) Descriptor() ([]byte, []int) {
+func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
+func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateResponse) ProtoMessage()    {}
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_80b701c7b1c502fe, []int{10}
 }
 
-func (m *ReadAllResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadAllResponse.Unmarshal(m, b)
+func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
 }
-func (m *ReadAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadAllResponse.Marshal(b, m, deterministic)
+func (m *UpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateResponse.Merge(m, src)
+}
+func (m *UpdateResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateResponse.Size(m)
+}
+func (m *UpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateResponse proto.InternalMessageInfo
+
+func (m *UpdateResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UpdateResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *UpdateResponse) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type DeleteRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id  int64  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// todo is deleted only if it still has this etag
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{11}
+}
+
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DeleteRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type DeleteResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Deleted              int64    `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
+func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteResponse) ProtoMessage()    {}
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{12}
+}
+
+func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
+}
+func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteResponse.Merge(m, src)
+}
+func (m *DeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteResponse.Size(m)
+}
+func (m *DeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
+
+func (m *DeleteResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+type UndeleteRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UndeleteRequest) Reset()         { *m = UndeleteRequest{} }
+func (m *UndeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*UndeleteRequest) ProtoMessage()    {}
+func (*UndeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{13}
+}
+
+func (m *UndeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndeleteRequest.Unmarshal(m, b)
+}
+func (m *UndeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *UndeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndeleteRequest.Merge(m, src)
+}
+func (m *UndeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_UndeleteRequest.Size(m)
+}
+func (m *UndeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndeleteRequest proto.InternalMessageInfo
+
+func (m *UndeleteRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UndeleteRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type UndeleteResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Todo                 *Todo    `protobuf:"bytes,2,opt,name=todo,proto3" json:"todo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UndeleteResponse) Reset()         { *m = UndeleteResponse{} }
+func (m *UndeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*UndeleteResponse) ProtoMessage()    {}
+func (*UndeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{14}
+}
+
+func (m *UndeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndeleteResponse.Unmarshal(m, b)
+}
+func (m *UndeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *UndeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndeleteResponse.Merge(m, src)
+}
+func (m *UndeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_UndeleteResponse.Size(m)
+}
+func (m *UndeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndeleteResponse proto.InternalMessageInfo
+
+func (m *UndeleteResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UndeleteResponse) GetTodo() *Todo {
+	if m != nil {
+		return m.Todo
+	}
+	return nil
+}
+
+type CompleteRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompleteRequest) Reset()         { *m = CompleteRequest{} }
+func (m *CompleteRequest) String() string { return proto.CompactTextString(m) }
+func (*CompleteRequest) ProtoMessage()    {}
+func (*CompleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{15}
+}
+
+func (m *CompleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompleteRequest.Unmarshal(m, b)
+}
+func (m *CompleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompleteRequest.Marshal(b, m, deterministic)
+}
+func (m *CompleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompleteRequest.Merge(m, src)
+}
+func (m *CompleteRequest) XXX_Size() int {
+	return xxx_messageInfo_CompleteRequest.Size(m)
+}
+func (m *CompleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompleteRequest proto.InternalMessageInfo
+
+func (m *CompleteRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CompleteRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type CompleteResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Todo                 *Todo    `protobuf:"bytes,2,opt,name=todo,proto3" json:"todo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompleteResponse) Reset()         { *m = CompleteResponse{} }
+func (m *CompleteResponse) String() string { return proto.CompactTextString(m) }
+func (*CompleteResponse) ProtoMessage()    {}
+func (*CompleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{16}
+}
+
+func (m *CompleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompleteResponse.Unmarshal(m, b)
+}
+func (m *CompleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompleteResponse.Marshal(b, m, deterministic)
+}
+func (m *CompleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompleteResponse.Merge(m, src)
+}
+func (m *CompleteResponse) XXX_Size() int {
+	return xxx_messageInfo_CompleteResponse.Size(m)
+}
+func (m *CompleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompleteResponse proto.InternalMessageInfo
+
+func (m *CompleteResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CompleteResponse) GetTodo() *Todo {
+	if m != nil {
+		return m.Todo
+	}
+	return nil
+}
+
+type ReopenRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReopenRequest) Reset()         { *m = ReopenRequest{} }
+func (m *ReopenRequest) String() string { return proto.CompactTextString(m) }
+func (*ReopenRequest) ProtoMessage()    {}
+func (*ReopenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{17}
+}
+
+func (m *ReopenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReopenRequest.Unmarshal(m, b)
+}
+func (m *ReopenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReopenRequest.Marshal(b, m, deterministic)
+}
+func (m *ReopenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReopenRequest.Merge(m, src)
+}
+func (m *ReopenRequest) XXX_Size() int {
+	return xxx_messageInfo_ReopenRequest.Size(m)
+}
+func (m *ReopenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReopenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReopenRequest proto.InternalMessageInfo
+
+func (m *ReopenRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReopenRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type ReopenResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Todo                 *Todo    `protobuf:"bytes,2,opt,name=todo,proto3" json:"todo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReopenResponse) Reset()         { *m = ReopenResponse{} }
+func (m *ReopenResponse) String() string { return proto.CompactTextString(m) }
+func (*ReopenResponse) ProtoMessage()    {}
+func (*ReopenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{18}
+}
+
+func (m *ReopenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReopenResponse.Unmarshal(m, b)
+}
+func (m *ReopenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReopenResponse.Marshal(b, m, deterministic)
+}
+func (m *ReopenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReopenResponse.Merge(m, src)
+}
+func (m *ReopenResponse) XXX_Size() int {
+	return xxx_messageInfo_ReopenResponse.Size(m)
+}
+func (m *ReopenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReopenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReopenResponse proto.InternalMessageInfo
+
+func (m *ReopenResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReopenResponse) GetTodo() *Todo {
+	if m != nil {
+		return m.Todo
+	}
+	return nil
+}
+
+type ListLabelsRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListLabelsRequest) Reset()         { *m = ListLabelsRequest{} }
+func (m *ListLabelsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListLabelsRequest) ProtoMessage()    {}
+func (*ListLabelsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{19}
+}
+
+func (m *ListLabelsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLabelsRequest.Unmarshal(m, b)
+}
+func (m *ListLabelsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLabelsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListLabelsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLabelsRequest.Merge(m, src)
+}
+func (m *ListLabelsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListLabelsRequest.Size(m)
+}
+func (m *ListLabelsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLabelsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLabelsRequest proto.InternalMessageInfo
+
+func (m *ListLabelsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+type ListLabelsResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Labels               []*Label `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListLabelsResponse) Reset()         { *m = ListLabelsResponse{} }
+func (m *ListLabelsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListLabelsResponse) ProtoMessage()    {}
+func (*ListLabelsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{20}
+}
+
+func (m *ListLabelsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLabelsResponse.Unmarshal(m, b)
+}
+func (m *ListLabelsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLabelsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListLabelsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLabelsResponse.Merge(m, src)
+}
+func (m *ListLabelsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListLabelsResponse.Size(m)
+}
+func (m *ListLabelsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLabelsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLabelsResponse proto.InternalMessageInfo
+
+func (m *ListLabelsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListLabelsResponse) GetLabels() []*Label {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type RenameLabelRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	NewName              string   `protobuf:"bytes,3,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameLabelRequest) Reset()         { *m = RenameLabelRequest{} }
+func (m *RenameLabelRequest) String() string { return proto.CompactTextString(m) }
+func (*RenameLabelRequest) ProtoMessage()    {}
+func (*RenameLabelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{21}
+}
+
+func (m *RenameLabelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameLabelRequest.Unmarshal(m, b)
+}
+func (m *RenameLabelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameLabelRequest.Marshal(b, m, deterministic)
+}
+func (m *RenameLabelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameLabelRequest.Merge(m, src)
+}
+func (m *RenameLabelRequest) XXX_Size() int {
+	return xxx_messageInfo_RenameLabelRequest.Size(m)
+}
+func (m *RenameLabelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameLabelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameLabelRequest proto.InternalMessageInfo
+
+func (m *RenameLabelRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RenameLabelRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RenameLabelRequest) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+type RenameLabelResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Label                *Label   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameLabelResponse) Reset()         { *m = RenameLabelResponse{} }
+func (m *RenameLabelResponse) String() string { return proto.CompactTextString(m) }
+func (*RenameLabelResponse) ProtoMessage()    {}
+func (*RenameLabelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{22}
+}
+
+func (m *RenameLabelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameLabelResponse.Unmarshal(m, b)
+}
+func (m *RenameLabelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameLabelResponse.Marshal(b, m, deterministic)
+}
+func (m *RenameLabelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameLabelResponse.Merge(m, src)
+}
+func (m *RenameLabelResponse) XXX_Size() int {
+	return xxx_messageInfo_RenameLabelResponse.Size(m)
+}
+func (m *RenameLabelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameLabelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameLabelResponse proto.InternalMessageInfo
+
+func (m *RenameLabelResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RenameLabelResponse) GetLabel() *Label {
+	if m != nil {
+		return m.Label
+	}
+	return nil
+}
+
+type ReadAllRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// maximum number of todos to return, server default is used when zero
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// opaque token returned as next_page_token by a previous ReadAll call
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// AIP-160 filter expression, e.g. title:"invoice" AND reminder < "2026-11-01T00:00:00Z"
+	Filter string `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	// comma separated list of fields with optional desc suffix, e.g. "reminder desc, id"
+	OrderBy string `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// include todos moved to trash
+	ShowDeleted bool `protobuf:"varint,6,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	// return todos of the list only, all todos are returned when zero
+	ListId               int64    `protobuf:"varint,7,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadAllRequest) Reset()         { *m = ReadAllRequest{} }
+func (m *ReadAllRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadAllRequest) ProtoMessage()    {}
+func (*ReadAllRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{23}
+}
+
+func (m *ReadAllRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadAllRequest.Unmarshal(m, b)
+}
+func (m *ReadAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadAllRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadAllRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadAllRequest.Merge(m, src)
+}
+func (m *ReadAllRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadAllRequest.Size(m)
+}
+func (m *ReadAllRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadAllRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadAllRequest proto.InternalMessageInfo
+
+func (m *ReadAllRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReadAllRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ReadAllRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ReadAllRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *ReadAllRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *ReadAllRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
+func (m *ReadAllRequest) GetListId() int64 {
+	if m != nil {
+		return m.ListId
+	}
+	return 0
+}
+
+type ReadAllResponse struct {
+	Api   string  `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Todos []*Todo `protobuf:"bytes,2,rep,name=todos,proto3" json:"todos,omitempty"`
+	// token to retrieve the next page, empty when there are no more pages
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadAllResponse) Reset()         { *m = ReadAllResponse{} }
+func (m *ReadAllResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadAllResponse) ProtoMessage()    {}
+func (*ReadAllResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{24}
+}
+
+func (m *ReadAllResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadAllResponse.Unmarshal(m, b)
+}
+func (m *ReadAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadAllResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadAllResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadAllResponse.Merge(m, src)
+}
+func (m *ReadAllResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadAllResponse.Size(m)
+}
+func (m *ReadAllResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadAllResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadAllResponse proto.InternalMessageInfo
+
+func (m *ReadAllResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReadAllResponse) GetTodos() []*Todo {
+	if m != nil {
+		return m.Todos
+	}
+	return nil
+}
+
+func (m *ReadAllResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type CreateListRequest struct {
+	Api                  string    `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	List                 *TodoList `protobuf:"bytes,2,opt,name=list,proto3" json:"list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CreateListRequest) Reset()         { *m = CreateListRequest{} }
+func (m *CreateListRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateListRequest) ProtoMessage()    {}
+func (*CreateListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{25}
+}
+
+func (m *CreateListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateListRequest.Unmarshal(m, b)
+}
+func (m *CreateListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateListRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateListRequest.Merge(m, src)
+}
+func (m *CreateListRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateListRequest.Size(m)
+}
+func (m *CreateListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateListRequest proto.InternalMessageInfo
+
+func (m *CreateListRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateListRequest) GetList() *TodoList {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+type CreateListResponse struct {
+	Api                  string    `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	List                 *TodoList `protobuf:"bytes,2,opt,name=list,proto3" json:"list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CreateListResponse) Reset()         { *m = CreateListResponse{} }
+func (m *CreateListResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateListResponse) ProtoMessage()    {}
+func (*CreateListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{26}
+}
+
+func (m *CreateListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateListResponse.Unmarshal(m, b)
+}
+func (m *CreateListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateListResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateListResponse.Merge(m, src)
+}
+func (m *CreateListResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateListResponse.Size(m)
+}
+func (m *CreateListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateListResponse proto.InternalMessageInfo
+
+func (m *CreateListResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateListResponse) GetList() *TodoList {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+type ReadListRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadListRequest) Reset()         { *m = ReadListRequest{} }
+func (m *ReadListRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadListRequest) ProtoMessage()    {}
+func (*ReadListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{27}
+}
+
+func (m *ReadListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadListRequest.Unmarshal(m, b)
+}
+func (m *ReadListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadListRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadListRequest.Merge(m, src)
+}
+func (m *ReadListRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadListRequest.Size(m)
+}
+func (m *ReadListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadListRequest proto.InternalMessageInfo
+
+func (m *ReadListRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReadListRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type ReadListResponse struct {
+	Api                  string    `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	List                 *TodoList `protobuf:"bytes,2,opt,name=list,proto3" json:"list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ReadListResponse) Reset()         { *m = ReadListResponse{} }
+func (m *ReadListResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadListResponse) ProtoMessage()    {}
+func (*ReadListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{28}
+}
+
+func (m *ReadListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadListResponse.Unmarshal(m, b)
+}
+func (m *ReadListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadListResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadListResponse.Merge(m, src)
+}
+func (m *ReadListResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadListResponse.Size(m)
+}
+func (m *ReadListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadListResponse proto.InternalMessageInfo
+
+func (m *ReadListResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReadListResponse) GetList() *TodoList {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+type UpdateListRequest struct {
+	Api  string    `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	List *TodoList `protobuf:"bytes,2,opt,name=list,proto3" json:"list,omitempty"`
+	// fields to update, name and description are updated when empty
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateListRequest) Reset()         { *m = UpdateListRequest{} }
+func (m *UpdateListRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateListRequest) ProtoMessage()    {}
+func (*UpdateListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{29}
+}
+
+func (m *UpdateListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateListRequest.Unmarshal(m, b)
+}
+func (m *UpdateListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateListRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateListRequest.Merge(m, src)
+}
+func (m *UpdateListRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateListRequest.Size(m)
+}
+func (m *UpdateListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateListRequest proto.InternalMessageInfo
+
+func (m *UpdateListRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UpdateListRequest) GetList() *TodoList {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+func (m *UpdateListRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type UpdateListResponse struct {
+	Api                  string    `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	List                 *TodoList `protobuf:"bytes,2,opt,name=list,proto3" json:"list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *UpdateListResponse) Reset()         { *m = UpdateListResponse{} }
+func (m *UpdateListResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateListResponse) ProtoMessage()    {}
+func (*UpdateListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{30}
+}
+
+func (m *UpdateListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateListResponse.Unmarshal(m, b)
+}
+func (m *UpdateListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateListResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateListResponse.Merge(m, src)
+}
+func (m *UpdateListResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateListResponse.Size(m)
+}
+func (m *UpdateListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateListResponse proto.InternalMessageInfo
+
+func (m *UpdateListResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UpdateListResponse) GetList() *TodoList {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+type DeleteListRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id  int64  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// move todos of the list to trash together with it, otherwise list having todos is not deleted
+	Cascade              bool     `protobuf:"varint,3,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteListRequest) Reset()         { *m = DeleteListRequest{} }
+func (m *DeleteListRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteListRequest) ProtoMessage()    {}
+func (*DeleteListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{31}
+}
+
+func (m *DeleteListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteListRequest.Unmarshal(m, b)
+}
+func (m *DeleteListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteListRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteListRequest.Merge(m, src)
+}
+func (m *DeleteListRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteListRequest.Size(m)
+}
+func (m *DeleteListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteListRequest proto.InternalMessageInfo
+
+func (m *DeleteListRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteListRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DeleteListRequest) GetCascade() bool {
+	if m != nil {
+		return m.Cascade
+	}
+	return false
+}
+
+type DeleteListResponse struct {
+	Api     string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Deleted int64  `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// number of todos moved to trash
+	DeletedTodos         int64    `protobuf:"varint,3,opt,name=deleted_todos,json=deletedTodos,proto3" json:"deleted_todos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteListResponse) Reset()         { *m = DeleteListResponse{} }
+func (m *DeleteListResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteListResponse) ProtoMessage()    {}
+func (*DeleteListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{32}
+}
+
+func (m *DeleteListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteListResponse.Unmarshal(m, b)
+}
+func (m *DeleteListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteListResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteListResponse.Merge(m, src)
+}
+func (m *DeleteListResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteListResponse.Size(m)
+}
+func (m *DeleteListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteListResponse proto.InternalMessageInfo
+
+func (m *DeleteListResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteListResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+func (m *DeleteListResponse) GetDeletedTodos() int64 {
+	if m != nil {
+		return m.DeletedTodos
+	}
+	return 0
+}
+
+type ListListsRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListListsRequest) Reset()         { *m = ListListsRequest{} }
+func (m *ListListsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListListsRequest) ProtoMessage()    {}
+func (*ListListsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{33}
+}
+
+func (m *ListListsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListListsRequest.Unmarshal(m, b)
+}
+func (m *ListListsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListListsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListListsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListListsRequest.Merge(m, src)
+}
+func (m *ListListsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListListsRequest.Size(m)
+}
+func (m *ListListsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListListsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListListsRequest proto.InternalMessageInfo
+
+func (m *ListListsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+type ListListsResponse struct {
+	Api                  string      `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Lists                []*TodoList `protobuf:"bytes,2,rep,name=lists,proto3" json:"lists,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListListsResponse) Reset()         { *m = ListListsResponse{} }
+func (m *ListListsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListListsResponse) ProtoMessage()    {}
+func (*ListListsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{34}
+}
+
+func (m *ListListsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListListsResponse.Unmarshal(m, b)
+}
+func (m *ListListsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListListsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListListsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListListsResponse.Merge(m, src)
+}
+func (m *ListListsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListListsResponse.Size(m)
+}
+func (m *ListListsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListListsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListListsResponse proto.InternalMessageInfo
+
+func (m *ListListsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListListsResponse) GetLists() []*TodoList {
+	if m != nil {
+		return m.Lists
+	}
+	return nil
+}
+
+type AddCommentRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TodoId               int64    `protobuf:"varint,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	Comment              *Comment `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddCommentRequest) Reset()         { *m = AddCommentRequest{} }
+func (m *AddCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*AddCommentRequest) ProtoMessage()    {}
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{35}
+}
+
+func (m *AddCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddCommentRequest.Unmarshal(m, b)
+}
+func (m *AddCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddCommentRequest.Marshal(b, m, deterministic)
+}
+func (m *AddCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddCommentRequest.Merge(m, src)
+}
+func (m *AddCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_AddCommentRequest.Size(m)
+}
+func (m *AddCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddCommentRequest proto.InternalMessageInfo
+
+func (m *AddCommentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *AddCommentRequest) GetTodoId() int64 {
+	if m != nil {
+		return m.TodoId
+	}
+	return 0
+}
+
+func (m *AddCommentRequest) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+type AddCommentResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Comment              *Comment `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddCommentResponse) Reset()         { *m = AddCommentResponse{} }
+func (m *AddCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*AddCommentResponse) ProtoMessage()    {}
+func (*AddCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{36}
+}
+
+func (m *AddCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddCommentResponse.Unmarshal(m, b)
+}
+func (m *AddCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddCommentResponse.Marshal(b, m, deterministic)
+}
+func (m *AddCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddCommentResponse.Merge(m, src)
+}
+func (m *AddCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_AddCommentResponse.Size(m)
+}
+func (m *AddCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddCommentResponse proto.InternalMessageInfo
+
+func (m *AddCommentResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *AddCommentResponse) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+type ListCommentsRequest struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TodoId int64  `protobuf:"varint,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	// maximum number of comments returned, 50 by default and at most 1000
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCommentsRequest) Reset()         { *m = ListCommentsRequest{} }
+func (m *ListCommentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCommentsRequest) ProtoMessage()    {}
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{37}
+}
+
+func (m *ListCommentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCommentsRequest.Unmarshal(m, b)
+}
+func (m *ListCommentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCommentsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListCommentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommentsRequest.Merge(m, src)
+}
+func (m *ListCommentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCommentsRequest.Size(m)
+}
+func (m *ListCommentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCommentsRequest proto.InternalMessageInfo
+
+func (m *ListCommentsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListCommentsRequest) GetTodoId() int64 {
+	if m != nil {
+		return m.TodoId
+	}
+	return 0
+}
+
+func (m *ListCommentsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListCommentsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListCommentsResponse struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// comments from the oldest one
+	Comments []*Comment `protobuf:"bytes,2,rep,name=comments,proto3" json:"comments,omitempty"`
+	// token of the next page, empty on the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCommentsResponse) Reset()         { *m = ListCommentsResponse{} }
+func (m *ListCommentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCommentsResponse) ProtoMessage()    {}
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{38}
+}
+
+func (m *ListCommentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCommentsResponse.Unmarshal(m, b)
+}
+func (m *ListCommentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCommentsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListCommentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommentsResponse.Merge(m, src)
+}
+func (m *ListCommentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCommentsResponse.Size(m)
+}
+func (m *ListCommentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCommentsResponse proto.InternalMessageInfo
+
+func (m *ListCommentsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListCommentsResponse) GetComments() []*Comment {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+func (m *ListCommentsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type EditCommentRequest struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TodoId int64  `protobuf:"varint,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	// comment with id and the new body
+	Comment              *Comment `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EditCommentRequest) Reset()         { *m = EditCommentRequest{} }
+func (m *EditCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*EditCommentRequest) ProtoMessage()    {}
+func (*EditCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{39}
+}
+
+func (m *EditCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EditCommentRequest.Unmarshal(m, b)
+}
+func (m *EditCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EditCommentRequest.Marshal(b, m, deterministic)
+}
+func (m *EditCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EditCommentRequest.Merge(m, src)
+}
+func (m *EditCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_EditCommentRequest.Size(m)
+}
+func (m *EditCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EditCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EditCommentRequest proto.InternalMessageInfo
+
+func (m *EditCommentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *EditCommentRequest) GetTodoId() int64 {
+	if m != nil {
+		return m.TodoId
+	}
+	return 0
+}
+
+func (m *EditCommentRequest) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+type EditCommentResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Comment              *Comment `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EditCommentResponse) Reset()         { *m = EditCommentResponse{} }
+func (m *EditCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*EditCommentResponse) ProtoMessage()    {}
+func (*EditCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{40}
+}
+
+func (m *EditCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EditCommentResponse.Unmarshal(m, b)
+}
+func (m *EditCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EditCommentResponse.Marshal(b, m, deterministic)
+}
+func (m *EditCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EditCommentResponse.Merge(m, src)
+}
+func (m *EditCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_EditCommentResponse.Size(m)
+}
+func (m *EditCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EditCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EditCommentResponse proto.InternalMessageInfo
+
+func (m *EditCommentResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *EditCommentResponse) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+type DeleteCommentRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TodoId               int64    `protobuf:"varint,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	Id                   int64    `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCommentRequest) Reset()         { *m = DeleteCommentRequest{} }
+func (m *DeleteCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCommentRequest) ProtoMessage()    {}
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{41}
+}
+
+func (m *DeleteCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCommentRequest.Unmarshal(m, b)
+}
+func (m *DeleteCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCommentRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCommentRequest.Merge(m, src)
+}
+func (m *DeleteCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteCommentRequest.Size(m)
+}
+func (m *DeleteCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCommentRequest proto.InternalMessageInfo
+
+func (m *DeleteCommentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteCommentRequest) GetTodoId() int64 {
+	if m != nil {
+		return m.TodoId
+	}
+	return 0
+}
+
+func (m *DeleteCommentRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type DeleteCommentResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Deleted              int64    `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCommentResponse) Reset()         { *m = DeleteCommentResponse{} }
+func (m *DeleteCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteCommentResponse) ProtoMessage()    {}
+func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{42}
+}
+
+func (m *DeleteCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCommentResponse.Unmarshal(m, b)
+}
+func (m *DeleteCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCommentResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCommentResponse.Merge(m, src)
+}
+func (m *DeleteCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteCommentResponse.Size(m)
+}
+func (m *DeleteCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCommentResponse proto.InternalMessageInfo
+
+func (m *DeleteCommentResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteCommentResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+// UploadAttachmentRequest is a chunk of the uploaded file, api, todo_id, file_name and content
+// type of the chunk are taken from the first chunk only
+type UploadAttachmentRequest struct {
+	Api                  string             `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TodoId               int64              `protobuf:"varint,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	FileName             string             `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Chunk                *httpbody.HttpBody `protobuf:"bytes,4,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *UploadAttachmentRequest) Reset()         { *m = UploadAttachmentRequest{} }
+func (m *UploadAttachmentRequest) String() string { return proto.CompactTextString(m) }
+func (*UploadAttachmentRequest) ProtoMessage()    {}
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{43}
+}
+
+func (m *UploadAttachmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadAttachmentRequest.Unmarshal(m, b)
+}
+func (m *UploadAttachmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadAttachmentRequest.Marshal(b, m, deterministic)
+}
+func (m *UploadAttachmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadAttachmentRequest.Merge(m, src)
+}
+func (m *UploadAttachmentRequest) XXX_Size() int {
+	return xxx_messageInfo_UploadAttachmentRequest.Size(m)
+}
+func (m *UploadAttachmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadAttachmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadAttachmentRequest proto.InternalMessageInfo
+
+func (m *UploadAttachmentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UploadAttachmentRequest) GetTodoId() int64 {
+	if m != nil {
+		return m.TodoId
+	}
+	return 0
+}
+
+func (m *UploadAttachmentRequest) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *UploadAttachmentRequest) GetChunk() *httpbody.HttpBody {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+type UploadAttachmentResponse struct {
+	Api                  string      `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Attachment           *Attachment `protobuf:"bytes,2,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *UploadAttachmentResponse) Reset()         { *m = UploadAttachmentResponse{} }
+func (m *UploadAttachmentResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadAttachmentResponse) ProtoMessage()    {}
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{44}
+}
+
+func (m *UploadAttachmentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadAttachmentResponse.Unmarshal(m, b)
+}
+func (m *UploadAttachmentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadAttachmentResponse.Marshal(b, m, deterministic)
+}
+func (m *UploadAttachmentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadAttachmentResponse.Merge(m, src)
+}
+func (m *UploadAttachmentResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadAttachmentResponse.Size(m)
+}
+func (m *UploadAttachmentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadAttachmentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadAttachmentResponse proto.InternalMessageInfo
+
+func (m *UploadAttachmentResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UploadAttachmentResponse) GetAttachment() *Attachment {
+	if m != nil {
+		return m.Attachment
+	}
+	return nil
+}
+
+type DownloadAttachmentRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TodoId               int64    `protobuf:"varint,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	Id                   int64    `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DownloadAttachmentRequest) Reset()         { *m = DownloadAttachmentRequest{} }
+func (m *DownloadAttachmentRequest) String() string { return proto.CompactTextString(m) }
+func (*DownloadAttachmentRequest) ProtoMessage()    {}
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{45}
+}
+
+func (m *DownloadAttachmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownloadAttachmentRequest.Unmarshal(m, b)
+}
+func (m *DownloadAttachmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownloadAttachmentRequest.Marshal(b, m, deterministic)
+}
+func (m *DownloadAttachmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownloadAttachmentRequest.Merge(m, src)
+}
+func (m *DownloadAttachmentRequest) XXX_Size() int {
+	return xxx_messageInfo_DownloadAttachmentRequest.Size(m)
+}
+func (m *DownloadAttachmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownloadAttachmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownloadAttachmentRequest proto.InternalMessageInfo
+
+func (m *DownloadAttachmentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DownloadAttachmentRequest) GetTodoId() int64 {
+	if m != nil {
+		return m.TodoId
+	}
+	return 0
+}
+
+func (m *DownloadAttachmentRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type ListAttachmentsRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TodoId               int64    `protobuf:"varint,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAttachmentsRequest) Reset()         { *m = ListAttachmentsRequest{} }
+func (m *ListAttachmentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAttachmentsRequest) ProtoMessage()    {}
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{46}
+}
+
+func (m *ListAttachmentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAttachmentsRequest.Unmarshal(m, b)
+}
+func (m *ListAttachmentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAttachmentsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAttachmentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAttachmentsRequest.Merge(m, src)
+}
+func (m *ListAttachmentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAttachmentsRequest.Size(m)
+}
+func (m *ListAttachmentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAttachmentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAttachmentsRequest proto.InternalMessageInfo
+
+func (m *ListAttachmentsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListAttachmentsRequest) GetTodoId() int64 {
+	if m != nil {
+		return m.TodoId
+	}
+	return 0
+}
+
+type ListAttachmentsResponse struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// attachments from the oldest one
+	Attachments          []*Attachment `protobuf:"bytes,2,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListAttachmentsResponse) Reset()         { *m = ListAttachmentsResponse{} }
+func (m *ListAttachmentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAttachmentsResponse) ProtoMessage()    {}
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{47}
+}
+
+func (m *ListAttachmentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAttachmentsResponse.Unmarshal(m, b)
+}
+func (m *ListAttachmentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAttachmentsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAttachmentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAttachmentsResponse.Merge(m, src)
+}
+func (m *ListAttachmentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAttachmentsResponse.Size(m)
+}
+func (m *ListAttachmentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAttachmentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAttachmentsResponse proto.InternalMessageInfo
+
+func (m *ListAttachmentsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if m != nil {
+		return m.Attachments
+	}
+	return nil
+}
+
+type DeleteAttachmentRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TodoId               int64    `protobuf:"varint,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	Id                   int64    `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteAttachmentRequest) Reset()         { *m = DeleteAttachmentRequest{} }
+func (m *DeleteAttachmentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteAttachmentRequest) ProtoMessage()    {}
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{48}
+}
+
+func (m *DeleteAttachmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAttachmentRequest.Unmarshal(m, b)
+}
+func (m *DeleteAttachmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAttachmentRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteAttachmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAttachmentRequest.Merge(m, src)
+}
+func (m *DeleteAttachmentRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteAttachmentRequest.Size(m)
+}
+func (m *DeleteAttachmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAttachmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAttachmentRequest proto.InternalMessageInfo
+
+func (m *DeleteAttachmentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteAttachmentRequest) GetTodoId() int64 {
+	if m != nil {
+		return m.TodoId
+	}
+	return 0
+}
+
+func (m *DeleteAttachmentRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type DeleteAttachmentResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Deleted              int64    `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteAttachmentResponse) Reset()         { *m = DeleteAttachmentResponse{} }
+func (m *DeleteAttachmentResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteAttachmentResponse) ProtoMessage()    {}
+func (*DeleteAttachmentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{49}
+}
+
+func (m *DeleteAttachmentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAttachmentResponse.Unmarshal(m, b)
+}
+func (m *DeleteAttachmentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAttachmentResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteAttachmentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAttachmentResponse.Merge(m, src)
+}
+func (m *DeleteAttachmentResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteAttachmentResponse.Size(m)
+}
+func (m *DeleteAttachmentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAttachmentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAttachmentResponse proto.InternalMessageInfo
+
+func (m *DeleteAttachmentResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteAttachmentResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+type SearchRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// words to look for in title and description, todos having any of them are returned
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	// maximum number of results returned, 50 by default and at most 1000
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{50}
+}
+
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+// SearchResult is todo matching the search query
+type SearchResult struct {
+	Todo *Todo `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	// relevance of the todo to the query, greater is better, comparable within one search only
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// HTML escaped fragment of the title with matching words wrapped in <em></em>, empty if
+	// no word of the title matches
+	TitleSnippet string `protobuf:"bytes,3,opt,name=title_snippet,json=titleSnippet,proto3" json:"title_snippet,omitempty"`
+	// HTML escaped fragment of the description with matching words wrapped in <em></em>, empty
+	// if no word of the description matches
+	DescriptionSnippet   string   `protobuf:"bytes,4,opt,name=description_snippet,json=descriptionSnippet,proto3" json:"description_snippet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchResult) Reset()         { *m = SearchResult{} }
+func (m *SearchResult) String() string { return proto.CompactTextString(m) }
+func (*SearchResult) ProtoMessage()    {}
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{51}
+}
+
+func (m *SearchResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResult.Unmarshal(m, b)
+}
+func (m *SearchResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResult.Marshal(b, m, deterministic)
+}
+func (m *SearchResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResult.Merge(m, src)
+}
+func (m *SearchResult) XXX_Size() int {
+	return xxx_messageInfo_SearchResult.Size(m)
+}
+func (m *SearchResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResult proto.InternalMessageInfo
+
+func (m *SearchResult) GetTodo() *Todo {
+	if m != nil {
+		return m.Todo
+	}
+	return nil
+}
+
+func (m *SearchResult) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SearchResult) GetTitleSnippet() string {
+	if m != nil {
+		return m.TitleSnippet
+	}
+	return ""
+}
+
+func (m *SearchResult) GetDescriptionSnippet() string {
+	if m != nil {
+		return m.DescriptionSnippet
+	}
+	return ""
+}
+
+type SearchResponse struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// todos not in trash from the most relevant one
+	Results []*SearchResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	// token of the next page, empty on the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
+func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse) ProtoMessage()    {}
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{52}
+}
+
+func (m *SearchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse.Unmarshal(m, b)
+}
+func (m *SearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse.Merge(m, src)
+}
+func (m *SearchResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse.Size(m)
+}
+func (m *SearchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse proto.InternalMessageInfo
+
+func (m *SearchResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *SearchResponse) GetResults() []*SearchResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *SearchResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// BatchResult is outcome of a single item of the batch request
+type BatchResult struct {
+	// id of the created, updated or deleted todo
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// etag of the todo after the change
+	Etag string `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	// code is OK when the item succeeded
+	Status               *status.Status `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BatchResult) Reset()         { *m = BatchResult{} }
+func (m *BatchResult) String() string { return proto.CompactTextString(m) }
+func (*BatchResult) ProtoMessage()    {}
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{53}
+}
+
+func (m *BatchResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchResult.Unmarshal(m, b)
+}
+func (m *BatchResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchResult.Marshal(b, m, deterministic)
+}
+func (m *BatchResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchResult.Merge(m, src)
+}
+func (m *BatchResult) XXX_Size() int {
+	return xxx_messageInfo_BatchResult.Size(m)
+}
+func (m *BatchResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchResult proto.InternalMessageInfo
+
+func (m *BatchResult) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *BatchResult) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *BatchResult) GetStatus() *status.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type BatchCreateRequest struct {
+	Api      string           `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Requests []*CreateRequest `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	// apply valid items and report failures per item instead of rejecting whole batch
+	BestEffort bool `protobuf:"varint,3,opt,name=best_effort,json=bestEffort,proto3" json:"best_effort,omitempty"`
+	// retries with the same key return the original response instead of applying the batch again,
+	// the Idempotency-Key header can be sent instead
+	IdempotencyKey       string   `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchCreateRequest) Reset()         { *m = BatchCreateRequest{} }
+func (m *BatchCreateRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateRequest) ProtoMessage()    {}
+func (*BatchCreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{54}
+}
+
+func (m *BatchCreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateRequest.Unmarshal(m, b)
+}
+func (m *BatchCreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateRequest.Merge(m, src)
+}
+func (m *BatchCreateRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateRequest.Size(m)
+}
+func (m *BatchCreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateRequest proto.InternalMessageInfo
+
+func (m *BatchCreateRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *BatchCreateRequest) GetRequests() []*CreateRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+func (m *BatchCreateRequest) GetBestEffort() bool {
+	if m != nil {
+		return m.BestEffort
+	}
+	return false
+}
+
+func (m *BatchCreateRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type BatchCreateResponse struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// results in the order of requests
+	Results              []*BatchResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BatchCreateResponse) Reset()         { *m = BatchCreateResponse{} }
+func (m *BatchCreateResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateResponse) ProtoMessage()    {}
+func (*BatchCreateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{55}
+}
+
+func (m *BatchCreateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateResponse.Unmarshal(m, b)
+}
+func (m *BatchCreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateResponse.Merge(m, src)
+}
+func (m *BatchCreateResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateResponse.Size(m)
+}
+func (m *BatchCreateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateResponse proto.InternalMessageInfo
+
+func (m *BatchCreateResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *BatchCreateResponse) GetResults() []*BatchResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchUpdateRequest struct {
+	Api      string           `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Requests []*UpdateRequest `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	// apply valid items and report failures per item instead of rejecting whole batch
+	BestEffort bool `protobuf:"varint,3,opt,name=best_effort,json=bestEffort,proto3" json:"best_effort,omitempty"`
+	// retries with the same key return the original response instead of applying the batch again,
+	// the Idempotency-Key header can be sent instead
+	IdempotencyKey       string   `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchUpdateRequest) Reset()         { *m = BatchUpdateRequest{} }
+func (m *BatchUpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateRequest) ProtoMessage()    {}
+func (*BatchUpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{56}
+}
+
+func (m *BatchUpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateRequest.Unmarshal(m, b)
+}
+func (m *BatchUpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchUpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateRequest.Merge(m, src)
+}
+func (m *BatchUpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateRequest.Size(m)
+}
+func (m *BatchUpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchUpdateRequest proto.InternalMessageInfo
+
+func (m *BatchUpdateRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *BatchUpdateRequest) GetRequests() []*UpdateRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+func (m *BatchUpdateRequest) GetBestEffort() bool {
+	if m != nil {
+		return m.BestEffort
+	}
+	return false
+}
+
+func (m *BatchUpdateRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type BatchUpdateResponse struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// results in the order of requests
+	Results              []*BatchResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BatchUpdateResponse) Reset()         { *m = BatchUpdateResponse{} }
+func (m *BatchUpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateResponse) ProtoMessage()    {}
+func (*BatchUpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{57}
+}
+
+func (m *BatchUpdateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateResponse.Unmarshal(m, b)
+}
+func (m *BatchUpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchUpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateResponse.Merge(m, src)
+}
+func (m *BatchUpdateResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateResponse.Size(m)
+}
+func (m *BatchUpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchUpdateResponse proto.InternalMessageInfo
+
+func (m *BatchUpdateResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *BatchUpdateResponse) GetResults() []*BatchResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchDeleteRequest struct {
+	Api      string           `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Requests []*DeleteRequest `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	// apply valid items and report failures per item instead of rejecting whole batch
+	BestEffort bool `protobuf:"varint,3,opt,name=best_effort,json=bestEffort,proto3" json:"best_effort,omitempty"`
+	// retries with the same key return the original response instead of applying the batch again,
+	// the Idempotency-Key header can be sent instead
+	IdempotencyKey       string   `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchDeleteRequest) Reset()         { *m = BatchDeleteRequest{} }
+func (m *BatchDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteRequest) ProtoMessage()    {}
+func (*BatchDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{58}
+}
+
+func (m *BatchDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteRequest.Unmarshal(m, b)
+}
+func (m *BatchDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteRequest.Merge(m, src)
+}
+func (m *BatchDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteRequest.Size(m)
+}
+func (m *BatchDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteRequest proto.InternalMessageInfo
+
+func (m *BatchDeleteRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *BatchDeleteRequest) GetRequests() []*DeleteRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+func (m *BatchDeleteRequest) GetBestEffort() bool {
+	if m != nil {
+		return m.BestEffort
+	}
+	return false
+}
+
+func (m *BatchDeleteRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type BatchDeleteResponse struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// results in the order of requests
+	Results              []*BatchResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BatchDeleteResponse) Reset()         { *m = BatchDeleteResponse{} }
+func (m *BatchDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteResponse) ProtoMessage()    {}
+func (*BatchDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{59}
+}
+
+func (m *BatchDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteResponse.Unmarshal(m, b)
+}
+func (m *BatchDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteResponse.Merge(m, src)
+}
+func (m *BatchDeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteResponse.Size(m)
+}
+func (m *BatchDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteResponse proto.InternalMessageInfo
+
+func (m *BatchDeleteResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *BatchDeleteResponse) GetResults() []*BatchResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type WatchRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// AIP-160 filter expression, same as in ReadAll
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// resume_token of the last received event, the stream continues after it
+	ResumeToken          string   `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{60}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+type TodoEvent struct {
+	Api  string         `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Type TodoEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=v1.TodoEvent_Type" json:"type,omitempty"`
+	// current state of the todo, only id is set if the todo was purged
+	Todo      *Todo                `protobuf:"bytes,3,opt,name=todo,proto3" json:"todo,omitempty"`
+	EventTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	// opaque token to resume watching after this event
+	ResumeToken          string   `protobuf:"bytes,5,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TodoEvent) Reset()         { *m = TodoEvent{} }
+func (m *TodoEvent) String() string { return proto.CompactTextString(m) }
+func (*TodoEvent) ProtoMessage()    {}
+func (*TodoEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{61}
+}
+
+func (m *TodoEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TodoEvent.Unmarshal(m, b)
+}
+func (m *TodoEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TodoEvent.Marshal(b, m, deterministic)
+}
+func (m *TodoEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TodoEvent.Merge(m, src)
+}
+func (m *TodoEvent) XXX_Size() int {
+	return xxx_messageInfo_TodoEvent.Size(m)
+}
+func (m *TodoEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_TodoEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TodoEvent proto.InternalMessageInfo
+
+func (m *TodoEvent) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *TodoEvent) GetType() TodoEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return TodoEvent_CREATED
+}
+
+func (m *TodoEvent) GetTodo() *Todo {
+	if m != nil {
+		return m.Todo
+	}
+	return nil
+}
+
+func (m *TodoEvent) GetEventTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EventTime
+	}
+	return nil
+}
+
+func (m *TodoEvent) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+type ExportTodosRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// AIP-160 filter expression, same as in ReadAll
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// include todos moved to trash
+	ShowDeleted          bool     `protobuf:"varint,3,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportTodosRequest) Reset()         { *m = ExportTodosRequest{} }
+func (m *ExportTodosRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportTodosRequest) ProtoMessage()    {}
+func (*ExportTodosRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{62}
+}
+
+func (m *ExportTodosRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportTodosRequest.Unmarshal(m, b)
+}
+func (m *ExportTodosRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportTodosRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportTodosRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportTodosRequest.Merge(m, src)
+}
+func (m *ExportTodosRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportTodosRequest.Size(m)
+}
+func (m *ExportTodosRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportTodosRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportTodosRequest proto.InternalMessageInfo
+
+func (m *ExportTodosRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ExportTodosRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *ExportTodosRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
+type ExportCalendarRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// AIP-160 filter expression, same as in ReadAll, todos in trash are not exported
+	Filter               string   `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportCalendarRequest) Reset()         { *m = ExportCalendarRequest{} }
+func (m *ExportCalendarRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportCalendarRequest) ProtoMessage()    {}
+func (*ExportCalendarRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{63}
+}
+
+func (m *ExportCalendarRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportCalendarRequest.Unmarshal(m, b)
+}
+func (m *ExportCalendarRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportCalendarRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportCalendarRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportCalendarRequest.Merge(m, src)
+}
+func (m *ExportCalendarRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportCalendarRequest.Size(m)
+}
+func (m *ExportCalendarRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportCalendarRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportCalendarRequest proto.InternalMessageInfo
+
+func (m *ExportCalendarRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ExportCalendarRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+type ImportCalendarRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// iCalendar object, each VTODO component is created as a todo and other components are ignored
+	Calendar             *httpbody.HttpBody `protobuf:"bytes,2,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ImportCalendarRequest) Reset()         { *m = ImportCalendarRequest{} }
+func (m *ImportCalendarRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportCalendarRequest) ProtoMessage()    {}
+func (*ImportCalendarRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{64}
+}
+
+func (m *ImportCalendarRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportCalendarRequest.Unmarshal(m, b)
+}
+func (m *ImportCalendarRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportCalendarRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportCalendarRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportCalendarRequest.Merge(m, src)
+}
+func (m *ImportCalendarRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportCalendarRequest.Size(m)
+}
+func (m *ImportCalendarRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportCalendarRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportCalendarRequest proto.InternalMessageInfo
+
+func (m *ImportCalendarRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ImportCalendarRequest) GetCalendar() *httpbody.HttpBody {
+	if m != nil {
+		return m.Calendar
+	}
+	return nil
+}
+
+type ExportCSVRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// AIP-160 filter expression, same as in ReadAll, todos in trash are not exported
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// columns in the order they are written, the names may also be separated by commas. All of
+	// id, title, description, reminder, status, completed_at, labels, external_id, recurrence
+	// and list_id are written if it is empty.
+	Columns              []string `protobuf:"bytes,3,rep,name=columns,proto3" json:"columns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportCSVRequest) Reset()         { *m = ExportCSVRequest{} }
+func (m *ExportCSVRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportCSVRequest) ProtoMessage()    {}
+func (*ExportCSVRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{65}
+}
+
+func (m *ExportCSVRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportCSVRequest.Unmarshal(m, b)
+}
+func (m *ExportCSVRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportCSVRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportCSVRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportCSVRequest.Merge(m, src)
+}
+func (m *ExportCSVRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportCSVRequest.Size(m)
+}
+func (m *ExportCSVRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportCSVRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportCSVRequest proto.InternalMessageInfo
+
+func (m *ExportCSVRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ExportCSVRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *ExportCSVRequest) GetColumns() []string {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+type ImportCSVRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// CSV file with a header row naming the columns as in ExportCSVRequest, id and completed_at
+	// columns are ignored
+	File                 *httpbody.HttpBody `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ImportCSVRequest) Reset()         { *m = ImportCSVRequest{} }
+func (m *ImportCSVRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportCSVRequest) ProtoMessage()    {}
+func (*ImportCSVRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{66}
+}
+
+func (m *ImportCSVRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportCSVRequest.Unmarshal(m, b)
+}
+func (m *ImportCSVRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportCSVRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportCSVRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportCSVRequest.Merge(m, src)
+}
+func (m *ImportCSVRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportCSVRequest.Size(m)
+}
+func (m *ImportCSVRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportCSVRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportCSVRequest proto.InternalMessageInfo
+
+func (m *ImportCSVRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ImportCSVRequest) GetFile() *httpbody.HttpBody {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+type ExportTodoTxtRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// AIP-160 filter expression, same as in ReadAll, todos in trash are not exported
+	Filter               string   `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportTodoTxtRequest) Reset()         { *m = ExportTodoTxtRequest{} }
+func (m *ExportTodoTxtRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportTodoTxtRequest) ProtoMessage()    {}
+func (*ExportTodoTxtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{67}
+}
+
+func (m *ExportTodoTxtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportTodoTxtRequest.Unmarshal(m, b)
+}
+func (m *ExportTodoTxtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportTodoTxtRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportTodoTxtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportTodoTxtRequest.Merge(m, src)
+}
+func (m *ExportTodoTxtRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportTodoTxtRequest.Size(m)
+}
+func (m *ExportTodoTxtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportTodoTxtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportTodoTxtRequest proto.InternalMessageInfo
+
+func (m *ExportTodoTxtRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ExportTodoTxtRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+type ImportTodoTxtRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// todo.txt file, one todo per line
+	File                 *httpbody.HttpBody `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ImportTodoTxtRequest) Reset()         { *m = ImportTodoTxtRequest{} }
+func (m *ImportTodoTxtRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportTodoTxtRequest) ProtoMessage()    {}
+func (*ImportTodoTxtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{68}
+}
+
+func (m *ImportTodoTxtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportTodoTxtRequest.Unmarshal(m, b)
+}
+func (m *ImportTodoTxtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportTodoTxtRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportTodoTxtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportTodoTxtRequest.Merge(m, src)
+}
+func (m *ImportTodoTxtRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportTodoTxtRequest.Size(m)
+}
+func (m *ImportTodoTxtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportTodoTxtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportTodoTxtRequest proto.InternalMessageInfo
+
+func (m *ImportTodoTxtRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ImportTodoTxtRequest) GetFile() *httpbody.HttpBody {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+// TodoRevision is a change of the todo recorded in its history
+type TodoRevision struct {
+	// version of the todo after the change, the etag of the after snapshot
+	Revision int64          `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Type     TodoEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=v1.TodoEvent_Type" json:"type,omitempty"`
+	// todo before the change, not set for the created todo
+	Before *Todo `protobuf:"bytes,3,opt,name=before,proto3" json:"before,omitempty"`
+	After  *Todo `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+	// who made the change, the authenticated user, empty if unknown. Only single-tenant
+	// server takes it from "actor" metadata sent by client, as it does not authenticate.
+	Actor                string               `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	ChangeTime           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=change_time,json=changeTime,proto3" json:"change_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TodoRevision) Reset()         { *m = TodoRevision{} }
+func (m *TodoRevision) String() string { return proto.CompactTextString(m) }
+func (*TodoRevision) ProtoMessage()    {}
+func (*TodoRevision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{69}
+}
+
+func (m *TodoRevision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TodoRevision.Unmarshal(m, b)
+}
+func (m *TodoRevision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TodoRevision.Marshal(b, m, deterministic)
+}
+func (m *TodoRevision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TodoRevision.Merge(m, src)
+}
+func (m *TodoRevision) XXX_Size() int {
+	return xxx_messageInfo_TodoRevision.Size(m)
+}
+func (m *TodoRevision) XXX_DiscardUnknown() {
+	xxx_messageInfo_TodoRevision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TodoRevision proto.InternalMessageInfo
+
+func (m *TodoRevision) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *TodoRevision) GetType() TodoEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return TodoEvent_CREATED
+}
+
+func (m *TodoRevision) GetBefore() *Todo {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *TodoRevision) GetAfter() *Todo {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *TodoRevision) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *TodoRevision) GetChangeTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ChangeTime
+	}
+	return nil
+}
+
+type GetHistoryRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHistoryRequest) Reset()         { *m = GetHistoryRequest{} }
+func (m *GetHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetHistoryRequest) ProtoMessage()    {}
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{70}
+}
+
+func (m *GetHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHistoryRequest.Unmarshal(m, b)
+}
+func (m *GetHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHistoryRequest.Merge(m, src)
+}
+func (m *GetHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetHistoryRequest.Size(m)
+}
+func (m *GetHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHistoryRequest proto.InternalMessageInfo
+
+func (m *GetHistoryRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetHistoryRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetHistoryResponse struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// revisions from the newest one
+	Revisions            []*TodoRevision `protobuf:"bytes,2,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetHistoryResponse) Reset()         { *m = GetHistoryResponse{} }
+func (m *GetHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetHistoryResponse) ProtoMessage()    {}
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{71}
+}
+
+func (m *GetHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHistoryResponse.Unmarshal(m, b)
+}
+func (m *GetHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHistoryResponse.Merge(m, src)
+}
+func (m *GetHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetHistoryResponse.Size(m)
+}
+func (m *GetHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHistoryResponse proto.InternalMessageInfo
+
+func (m *GetHistoryResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetHistoryResponse) GetRevisions() []*TodoRevision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+type RestoreRevisionRequest struct {
+	Api      string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id       int64  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Revision int64  `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	// todo is restored only if it still has this etag
+	Etag                 string   `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreRevisionRequest) Reset()         { *m = RestoreRevisionRequest{} }
+func (m *RestoreRevisionRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreRevisionRequest) ProtoMessage()    {}
+func (*RestoreRevisionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{72}
+}
+
+func (m *RestoreRevisionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreRevisionRequest.Unmarshal(m, b)
+}
+func (m *RestoreRevisionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreRevisionRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreRevisionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRevisionRequest.Merge(m, src)
+}
+func (m *RestoreRevisionRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreRevisionRequest.Size(m)
+}
+func (m *RestoreRevisionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRevisionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRevisionRequest proto.InternalMessageInfo
+
+func (m *RestoreRevisionRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RestoreRevisionRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *RestoreRevisionRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *RestoreRevisionRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type RestoreRevisionResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Todo                 *Todo    `protobuf:"bytes,2,opt,name=todo,proto3" json:"todo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreRevisionResponse) Reset()         { *m = RestoreRevisionResponse{} }
+func (m *RestoreRevisionResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreRevisionResponse) ProtoMessage()    {}
+func (*RestoreRevisionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{73}
+}
+
+func (m *RestoreRevisionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreRevisionResponse.Unmarshal(m, b)
+}
+func (m *RestoreRevisionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreRevisionResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreRevisionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRevisionResponse.Merge(m, src)
+}
+func (m *RestoreRevisionResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreRevisionResponse.Size(m)
+}
+func (m *RestoreRevisionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRevisionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRevisionResponse proto.InternalMessageInfo
+
+func (m *RestoreRevisionResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RestoreRevisionResponse) GetTodo() *Todo {
+	if m != nil {
+		return m.Todo
+	}
+	return nil
+}
+
+type ListOccurrencesRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id  int64  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// occurrences within [from, to) are returned
+	From                 *timestamp.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To                   *timestamp.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListOccurrencesRequest) Reset()         { *m = ListOccurrencesRequest{} }
+func (m *ListOccurrencesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOccurrencesRequest) ProtoMessage()    {}
+func (*ListOccurrencesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{74}
+}
+
+func (m *ListOccurrencesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOccurrencesRequest.Unmarshal(m, b)
+}
+func (m *ListOccurrencesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOccurrencesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListOccurrencesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOccurrencesRequest.Merge(m, src)
+}
+func (m *ListOccurrencesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOccurrencesRequest.Size(m)
+}
+func (m *ListOccurrencesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOccurrencesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOccurrencesRequest proto.InternalMessageInfo
+
+func (m *ListOccurrencesRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListOccurrencesRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ListOccurrencesRequest) GetFrom() *timestamp.Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *ListOccurrencesRequest) GetTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+type ListOccurrencesResponse struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// reminders of the todo and its upcoming recurrences, limited to the first 1000
+	Occurrences []*timestamp.Timestamp `protobuf:"bytes,2,rep,name=occurrences,proto3" json:"occurrences,omitempty"`
+	// occurrences as RFC 3339 local times in time_zone of the todo
+	LocalOccurrences     []string `protobuf:"bytes,3,rep,name=local_occurrences,json=localOccurrences,proto3" json:"local_occurrences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOccurrencesResponse) Reset()         { *m = ListOccurrencesResponse{} }
+func (m *ListOccurrencesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOccurrencesResponse) ProtoMessage()    {}
+func (*ListOccurrencesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{75}
+}
+
+func (m *ListOccurrencesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOccurrencesResponse.Unmarshal(m, b)
+}
+func (m *ListOccurrencesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOccurrencesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListOccurrencesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOccurrencesResponse.Merge(m, src)
+}
+func (m *ListOccurrencesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListOccurrencesResponse.Size(m)
+}
+func (m *ListOccurrencesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOccurrencesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOccurrencesResponse proto.InternalMessageInfo
+
+func (m *ListOccurrencesResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListOccurrencesResponse) GetOccurrences() []*timestamp.Timestamp {
+	if m != nil {
+		return m.Occurrences
+	}
+	return nil
+}
+
+func (m *ListOccurrencesResponse) GetLocalOccurrences() []string {
+	if m != nil {
+		return m.LocalOccurrences
+	}
+	return nil
+}
+
+// ImportError describes a todo which failed to import
+type ImportError struct {
+	// position of the todo in the import stream, starting from 0
+	Index                int64          `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	ExternalId           string         `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Status               *status.Status `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ImportError) Reset()         { *m = ImportError{} }
+func (m *ImportError) String() string { return proto.CompactTextString(m) }
+func (*ImportError) ProtoMessage()    {}
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{76}
+}
+
+func (m *ImportError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportError.Unmarshal(m, b)
+}
+func (m *ImportError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportError.Marshal(b, m, deterministic)
+}
+func (m *ImportError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportError.Merge(m, src)
+}
+func (m *ImportError) XXX_Size() int {
+	return xxx_messageInfo_ImportError.Size(m)
+}
+func (m *ImportError) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportError proto.InternalMessageInfo
+
+func (m *ImportError) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ImportError) GetExternalId() string {
+	if m != nil {
+		return m.ExternalId
+	}
+	return ""
+}
+
+func (m *ImportError) GetStatus() *status.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type ImportSummary struct {
+	Api     string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Created int64  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	// number of todos skipped because a todo with the same external id exists
+	Skipped int64 `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Failed  int64 `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	// details of the failed todos, limited to the first 1000 failures
+	Errors               []*ImportError `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ImportSummary) Reset()         { *m = ImportSummary{} }
+func (m *ImportSummary) String() string { return proto.CompactTextString(m) }
+func (*ImportSummary) ProtoMessage()    {}
+func (*ImportSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{77}
+}
+
+func (m *ImportSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportSummary.Unmarshal(m, b)
+}
+func (m *ImportSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportSummary.Marshal(b, m, deterministic)
+}
+func (m *ImportSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportSummary.Merge(m, src)
+}
+func (m *ImportSummary) XXX_Size() int {
+	return xxx_messageInfo_ImportSummary.Size(m)
+}
+func (m *ImportSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportSummary proto.InternalMessageInfo
+
+func (m *ImportSummary) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ImportSummary) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *ImportSummary) GetSkipped() int64 {
+	if m != nil {
+		return m.Skipped
+	}
+	return 0
+}
+
+func (m *ImportSummary) GetFailed() int64 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *ImportSummary) GetErrors() []*ImportError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+// WebhookSubscription registers URL todo events are pushed to
+type WebhookSubscription struct {
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// http or https URL the events are posted to
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// key of the HMAC-SHA256 signature of deliveries, generated by the server if not set,
+	// returned only when the subscription is created
+	Secret string `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	// types of the events pushed, all types if empty
+	EventTypes           []TodoEvent_Type     `protobuf:"varint,4,rep,packed,name=event_types,json=eventTypes,proto3,enum=v1.TodoEvent_Type" json:"event_types,omitempty"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WebhookSubscription) Reset()         { *m = WebhookSubscription{} }
+func (m *WebhookSubscription) String() string { return proto.CompactTextString(m) }
+func (*WebhookSubscription) ProtoMessage()    {}
+func (*WebhookSubscription) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{78}
+}
+
+func (m *WebhookSubscription) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookSubscription.Unmarshal(m, b)
+}
+func (m *WebhookSubscription) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookSubscription.Marshal(b, m, deterministic)
+}
+func (m *WebhookSubscription) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookSubscription.Merge(m, src)
+}
+func (m *WebhookSubscription) XXX_Size() int {
+	return xxx_messageInfo_WebhookSubscription.Size(m)
+}
+func (m *WebhookSubscription) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookSubscription.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookSubscription proto.InternalMessageInfo
+
+func (m *WebhookSubscription) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *WebhookSubscription) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *WebhookSubscription) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *WebhookSubscription) GetEventTypes() []TodoEvent_Type {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
+func (m *WebhookSubscription) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+type CreateSubscriptionRequest struct {
+	Api                  string               `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Subscription         *WebhookSubscription `protobuf:"bytes,2,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateSubscriptionRequest) Reset()         { *m = CreateSubscriptionRequest{} }
+func (m *CreateSubscriptionRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateSubscriptionRequest) ProtoMessage()    {}
+func (*CreateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{79}
+}
+
+func (m *CreateSubscriptionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSubscriptionRequest.Unmarshal(m, b)
+}
+func (m *CreateSubscriptionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSubscriptionRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateSubscriptionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSubscriptionRequest.Merge(m, src)
+}
+func (m *CreateSubscriptionRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateSubscriptionRequest.Size(m)
+}
+func (m *CreateSubscriptionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSubscriptionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateSubscriptionRequest proto.InternalMessageInfo
+
+func (m *CreateSubscriptionRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateSubscriptionRequest) GetSubscription() *WebhookSubscription {
+	if m != nil {
+		return m.Subscription
+	}
+	return nil
+}
+
+type CreateSubscriptionResponse struct {
+	Api                  string               `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Subscription         *WebhookSubscription `protobuf:"bytes,2,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateSubscriptionResponse) Reset()         { *m = CreateSubscriptionResponse{} }
+func (m *CreateSubscriptionResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateSubscriptionResponse) ProtoMessage()    {}
+func (*CreateSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{80}
+}
+
+func (m *CreateSubscriptionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSubscriptionResponse.Unmarshal(m, b)
+}
+func (m *CreateSubscriptionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSubscriptionResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateSubscriptionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSubscriptionResponse.Merge(m, src)
+}
+func (m *CreateSubscriptionResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateSubscriptionResponse.Size(m)
+}
+func (m *CreateSubscriptionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSubscriptionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateSubscriptionResponse proto.InternalMessageInfo
+
+func (m *CreateSubscriptionResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateSubscriptionResponse) GetSubscription() *WebhookSubscription {
+	if m != nil {
+		return m.Subscription
+	}
+	return nil
+}
+
+type ListSubscriptionsRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSubscriptionsRequest) Reset()         { *m = ListSubscriptionsRequest{} }
+func (m *ListSubscriptionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSubscriptionsRequest) ProtoMessage()    {}
+func (*ListSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{81}
+}
+
+func (m *ListSubscriptionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSubscriptionsRequest.Unmarshal(m, b)
+}
+func (m *ListSubscriptionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSubscriptionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSubscriptionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSubscriptionsRequest.Merge(m, src)
+}
+func (m *ListSubscriptionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSubscriptionsRequest.Size(m)
+}
+func (m *ListSubscriptionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSubscriptionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSubscriptionsRequest proto.InternalMessageInfo
+
+func (m *ListSubscriptionsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+type ListSubscriptionsResponse struct {
+	Api                  string                 `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Subscriptions        []*WebhookSubscription `protobuf:"bytes,2,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ListSubscriptionsResponse) Reset()         { *m = ListSubscriptionsResponse{} }
+func (m *ListSubscriptionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSubscriptionsResponse) ProtoMessage()    {}
+func (*ListSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{82}
+}
+
+func (m *ListSubscriptionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSubscriptionsResponse.Unmarshal(m, b)
+}
+func (m *ListSubscriptionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSubscriptionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSubscriptionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSubscriptionsResponse.Merge(m, src)
+}
+func (m *ListSubscriptionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSubscriptionsResponse.Size(m)
+}
+func (m *ListSubscriptionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSubscriptionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSubscriptionsResponse proto.InternalMessageInfo
+
+func (m *ListSubscriptionsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListSubscriptionsResponse) GetSubscriptions() []*WebhookSubscription {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+type DeleteSubscriptionRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSubscriptionRequest) Reset()         { *m = DeleteSubscriptionRequest{} }
+func (m *DeleteSubscriptionRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteSubscriptionRequest) ProtoMessage()    {}
+func (*DeleteSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{83}
+}
+
+func (m *DeleteSubscriptionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSubscriptionRequest.Unmarshal(m, b)
+}
+func (m *DeleteSubscriptionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSubscriptionRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteSubscriptionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSubscriptionRequest.Merge(m, src)
+}
+func (m *DeleteSubscriptionRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteSubscriptionRequest.Size(m)
+}
+func (m *DeleteSubscriptionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSubscriptionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSubscriptionRequest proto.InternalMessageInfo
+
+func (m *DeleteSubscriptionRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteSubscriptionRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type DeleteSubscriptionResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Deleted              int64    `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSubscriptionResponse) Reset()         { *m = DeleteSubscriptionResponse{} }
+func (m *DeleteSubscriptionResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteSubscriptionResponse) ProtoMessage()    {}
+func (*DeleteSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{84}
+}
+
+func (m *DeleteSubscriptionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSubscriptionResponse.Unmarshal(m, b)
+}
+func (m *DeleteSubscriptionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSubscriptionResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteSubscriptionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSubscriptionResponse.Merge(m, src)
+}
+func (m *DeleteSubscriptionResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteSubscriptionResponse.Size(m)
+}
+func (m *DeleteSubscriptionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSubscriptionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSubscriptionResponse proto.InternalMessageInfo
+
+func (m *DeleteSubscriptionResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteSubscriptionResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+// WebhookDeadLetter is delivery which failed after all retries
+type WebhookDeadLetter struct {
+	Id             int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubscriptionId int64 `protobuf:"varint,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	// id of the todo event, same as its resume token
+	EventId   int64          `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType TodoEvent_Type `protobuf:"varint,4,opt,name=event_type,json=eventType,proto3,enum=v1.TodoEvent_Type" json:"event_type,omitempty"`
+	// JSON body of the delivery
+	Payload              string               `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Attempts             int32                `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError            string               `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	FailTime             *timestamp.Timestamp `protobuf:"bytes,9,opt,name=fail_time,json=failTime,proto3" json:"fail_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WebhookDeadLetter) Reset()         { *m = WebhookDeadLetter{} }
+func (m *WebhookDeadLetter) String() string { return proto.CompactTextString(m) }
+func (*WebhookDeadLetter) ProtoMessage()    {}
+func (*WebhookDeadLetter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{85}
+}
+
+func (m *WebhookDeadLetter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDeadLetter.Unmarshal(m, b)
+}
+func (m *WebhookDeadLetter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDeadLetter.Marshal(b, m, deterministic)
+}
+func (m *WebhookDeadLetter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDeadLetter.Merge(m, src)
+}
+func (m *WebhookDeadLetter) XXX_Size() int {
+	return xxx_messageInfo_WebhookDeadLetter.Size(m)
+}
+func (m *WebhookDeadLetter) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDeadLetter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDeadLetter proto.InternalMessageInfo
+
+func (m *WebhookDeadLetter) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *WebhookDeadLetter) GetSubscriptionId() int64 {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return 0
+}
+
+func (m *WebhookDeadLetter) GetEventId() int64 {
+	if m != nil {
+		return m.EventId
+	}
+	return 0
+}
+
+func (m *WebhookDeadLetter) GetEventType() TodoEvent_Type {
+	if m != nil {
+		return m.EventType
+	}
+	return TodoEvent_CREATED
+}
+
+func (m *WebhookDeadLetter) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+func (m *WebhookDeadLetter) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDeadLetter) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *WebhookDeadLetter) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *WebhookDeadLetter) GetFailTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.FailTime
+	}
+	return nil
+}
+
+type ListDeadLettersRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// dead letters of all subscriptions are listed if not set
+	SubscriptionId int64 `protobuf:"varint,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	// maximum number of dead letters returned, 50 by default and at most 1000
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeadLettersRequest) Reset()         { *m = ListDeadLettersRequest{} }
+func (m *ListDeadLettersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLettersRequest) ProtoMessage()    {}
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{86}
+}
+
+func (m *ListDeadLettersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLettersRequest.Unmarshal(m, b)
+}
+func (m *ListDeadLettersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLettersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDeadLettersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLettersRequest.Merge(m, src)
+}
+func (m *ListDeadLettersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLettersRequest.Size(m)
+}
+func (m *ListDeadLettersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLettersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLettersRequest proto.InternalMessageInfo
+
+func (m *ListDeadLettersRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListDeadLettersRequest) GetSubscriptionId() int64 {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return 0
+}
+
+func (m *ListDeadLettersRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListDeadLettersRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListDeadLettersResponse struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// dead letters from the oldest one
+	DeadLetters []*WebhookDeadLetter `protobuf:"bytes,2,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	// token of the next page, empty on the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeadLettersResponse) Reset()         { *m = ListDeadLettersResponse{} }
+func (m *ListDeadLettersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLettersResponse) ProtoMessage()    {}
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{87}
+}
+
+func (m *ListDeadLettersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLettersResponse.Unmarshal(m, b)
+}
+func (m *ListDeadLettersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLettersResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDeadLettersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLettersResponse.Merge(m, src)
+}
+func (m *ListDeadLettersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLettersResponse.Size(m)
+}
+func (m *ListDeadLettersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLettersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLettersResponse proto.InternalMessageInfo
+
+func (m *ListDeadLettersResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListDeadLettersResponse) GetDeadLetters() []*WebhookDeadLetter {
+	if m != nil {
+		return m.DeadLetters
+	}
+	return nil
+}
+
+func (m *ListDeadLettersResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ReplayDeadLetterRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayDeadLetterRequest) Reset()         { *m = ReplayDeadLetterRequest{} }
+func (m *ReplayDeadLetterRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplayDeadLetterRequest) ProtoMessage()    {}
+func (*ReplayDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{88}
+}
+
+func (m *ReplayDeadLetterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplayDeadLetterRequest.Unmarshal(m, b)
+}
+func (m *ReplayDeadLetterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplayDeadLetterRequest.Marshal(b, m, deterministic)
+}
+func (m *ReplayDeadLetterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayDeadLetterRequest.Merge(m, src)
+}
+func (m *ReplayDeadLetterRequest) XXX_Size() int {
+	return xxx_messageInfo_ReplayDeadLetterRequest.Size(m)
+}
+func (m *ReplayDeadLetterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayDeadLetterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayDeadLetterRequest proto.InternalMessageInfo
+
+func (m *ReplayDeadLetterRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReplayDeadLetterRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type ReplayDeadLetterResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayDeadLetterResponse) Reset()         { *m = ReplayDeadLetterResponse{} }
+func (m *ReplayDeadLetterResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplayDeadLetterResponse) ProtoMessage()    {}
+func (*ReplayDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{89}
+}
+
+func (m *ReplayDeadLetterResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplayDeadLetterResponse.Unmarshal(m, b)
+}
+func (m *ReplayDeadLetterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplayDeadLetterResponse.Marshal(b, m, deterministic)
+}
+func (m *ReplayDeadLetterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayDeadLetterResponse.Merge(m, src)
+}
+func (m *ReplayDeadLetterResponse) XXX_Size() int {
+	return xxx_messageInfo_ReplayDeadLetterResponse.Size(m)
+}
+func (m *ReplayDeadLetterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayDeadLetterResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayDeadLetterResponse proto.InternalMessageInfo
+
+func (m *ReplayDeadLetterResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("v1.Todo_Status", Todo_Status_name, Todo_Status_value)
+	proto.RegisterEnum("v1.TodoEvent_Type", TodoEvent_Type_name, TodoEvent_Type_value)
+	proto.RegisterType((*Todo)(nil), "v1.Todo")
+	proto.RegisterType((*TodoList)(nil), "v1.TodoList")
+	proto.RegisterType((*Comment)(nil), "v1.Comment")
+	proto.RegisterType((*Attachment)(nil), "v1.Attachment")
+	proto.RegisterType((*Label)(nil), "v1.Label")
+	proto.RegisterType((*CreateRequest)(nil), "v1.CreateRequest")
+	proto.RegisterType((*CreateResponse)(nil), "v1.CreateResponse")
+	proto.RegisterType((*ReadRequest)(nil), "v1.ReadRequest")
+	proto.RegisterType((*ReadResponse)(nil), "v1.ReadResponse")
+	proto.RegisterType((*UpdateRequest)(nil), "v1.UpdateRequest")
+	proto.RegisterType((*UpdateResponse)(nil), "v1.UpdateResponse")
+	proto.RegisterType((*DeleteRequest)(nil), "v1.DeleteRequest")
+	proto.RegisterType((*DeleteResponse)(nil), "v1.DeleteResponse")
+	proto.RegisterType((*UndeleteRequest)(nil), "v1.UndeleteRequest")
+	proto.RegisterType((*UndeleteResponse)(nil), "v1.UndeleteResponse")
+	proto.RegisterType((*CompleteRequest)(nil), "v1.CompleteRequest")
+	proto.RegisterType((*CompleteResponse)(nil), "v1.CompleteResponse")
+	proto.RegisterType((*ReopenRequest)(nil), "v1.ReopenRequest")
+	proto.RegisterType((*ReopenResponse)(nil), "v1.ReopenResponse")
+	proto.RegisterType((*ListLabelsRequest)(nil), "v1.ListLabelsRequest")
+	proto.RegisterType((*ListLabelsResponse)(nil), "v1.ListLabelsResponse")
+	proto.RegisterType((*RenameLabelRequest)(nil), "v1.RenameLabelRequest")
+	proto.RegisterType((*RenameLabelResponse)(nil), "v1.RenameLabelResponse")
+	proto.RegisterType((*ReadAllRequest)(nil), "v1.ReadAllRequest")
+	proto.RegisterType((*ReadAllResponse)(nil), "v1.ReadAllResponse")
+	proto.RegisterType((*CreateListRequest)(nil), "v1.CreateListRequest")
+	proto.RegisterType((*CreateListResponse)(nil), "v1.CreateListResponse")
+	proto.RegisterType((*ReadListRequest)(nil), "v1.ReadListRequest")
+	proto.RegisterType((*ReadListResponse)(nil), "v1.ReadListResponse")
+	proto.RegisterType((*UpdateListRequest)(nil), "v1.UpdateListRequest")
+	proto.RegisterType((*UpdateListResponse)(nil), "v1.UpdateListResponse")
+	proto.RegisterType((*DeleteListRequest)(nil), "v1.DeleteListRequest")
+	proto.RegisterType((*DeleteListResponse)(nil), "v1.DeleteListResponse")
+	proto.RegisterType((*ListListsRequest)(nil), "v1.ListListsRequest")
+	proto.RegisterType((*ListListsResponse)(nil), "v1.ListListsResponse")
+	proto.RegisterType((*AddCommentRequest)(nil), "v1.AddCommentRequest")
+	proto.RegisterType((*AddCommentResponse)(nil), "v1.AddCommentResponse")
+	proto.RegisterType((*ListCommentsRequest)(nil), "v1.ListCommentsRequest")
+	proto.RegisterType((*ListCommentsResponse)(nil), "v1.ListCommentsResponse")
+	proto.RegisterType((*EditCommentRequest)(nil), "v1.EditCommentRequest")
+	proto.RegisterType((*EditCommentResponse)(nil), "v1.EditCommentResponse")
+	proto.RegisterType((*DeleteCommentRequest)(nil), "v1.DeleteCommentRequest")
+	proto.RegisterType((*DeleteCommentResponse)(nil), "v1.DeleteCommentResponse")
+	proto.RegisterType((*UploadAttachmentRequest)(nil), "v1.UploadAttachmentRequest")
+	proto.RegisterType((*UploadAttachmentResponse)(nil), "v1.UploadAttachmentResponse")
+	proto.RegisterType((*DownloadAttachmentRequest)(nil), "v1.DownloadAttachmentRequest")
+	proto.RegisterType((*ListAttachmentsRequest)(nil), "v1.ListAttachmentsRequest")
+	proto.RegisterType((*ListAttachmentsResponse)(nil), "v1.ListAttachmentsResponse")
+	proto.RegisterType((*DeleteAttachmentRequest)(nil), "v1.DeleteAttachmentRequest")
+	proto.RegisterType((*DeleteAttachmentResponse)(nil), "v1.DeleteAttachmentResponse")
+	proto.RegisterType((*SearchRequest)(nil), "v1.SearchRequest")
+	proto.RegisterType((*SearchResult)(nil), "v1.SearchResult")
+	proto.RegisterType((*SearchResponse)(nil), "v1.SearchResponse")
+	proto.RegisterType((*BatchResult)(nil), "v1.BatchResult")
+	proto.RegisterType((*BatchCreateRequest)(nil), "v1.BatchCreateRequest")
+	proto.RegisterType((*BatchCreateResponse)(nil), "v1.BatchCreateResponse")
+	proto.RegisterType((*BatchUpdateRequest)(nil), "v1.BatchUpdateRequest")
+	proto.RegisterType((*BatchUpdateResponse)(nil), "v1.BatchUpdateResponse")
+	proto.RegisterType((*BatchDeleteRequest)(nil), "v1.BatchDeleteRequest")
+	proto.RegisterType((*BatchDeleteResponse)(nil), "v1.BatchDeleteResponse")
+	proto.RegisterType((*WatchRequest)(nil), "v1.WatchRequest")
+	proto.RegisterType((*TodoEvent)(nil), "v1.TodoEvent")
+	proto.RegisterType((*ExportTodosRequest)(nil), "v1.ExportTodosRequest")
+	proto.RegisterType((*ExportCalendarRequest)(nil), "v1.ExportCalendarRequest")
+	proto.RegisterType((*ImportCalendarRequest)(nil), "v1.ImportCalendarRequest")
+	proto.RegisterType((*ExportCSVRequest)(nil), "v1.ExportCSVRequest")
+	proto.RegisterType((*ImportCSVRequest)(nil), "v1.ImportCSVRequest")
+	proto.RegisterType((*ExportTodoTxtRequest)(nil), "v1.ExportTodoTxtRequest")
+	proto.RegisterType((*ImportTodoTxtRequest)(nil), "v1.ImportTodoTxtRequest")
+	proto.RegisterType((*TodoRevision)(nil), "v1.TodoRevision")
+	proto.RegisterType((*GetHistoryRequest)(nil), "v1.GetHistoryRequest")
+	proto.RegisterType((*GetHistoryResponse)(nil), "v1.GetHistoryResponse")
+	proto.RegisterType((*RestoreRevisionRequest)(nil), "v1.RestoreRevisionRequest")
+	proto.RegisterType((*RestoreRevisionResponse)(nil), "v1.RestoreRevisionResponse")
+	proto.RegisterType((*ListOccurrencesRequest)(nil), "v1.ListOccurrencesRequest")
+	proto.RegisterType((*ListOccurrencesResponse)(nil), "v1.ListOccurrencesResponse")
+	proto.RegisterType((*ImportError)(nil), "v1.ImportError")
+	proto.RegisterType((*ImportSummary)(nil), "v1.ImportSummary")
+	proto.RegisterType((*WebhookSubscription)(nil), "v1.WebhookSubscription")
+	proto.RegisterType((*CreateSubscriptionRequest)(nil), "v1.CreateSubscriptionRequest")
+	proto.RegisterType((*CreateSubscriptionResponse)(nil), "v1.CreateSubscriptionResponse")
+	proto.RegisterType((*ListSubscriptionsRequest)(nil), "v1.ListSubscriptionsRequest")
+	proto.RegisterType((*ListSubscriptionsResponse)(nil), "v1.ListSubscriptionsResponse")
+	proto.RegisterType((*DeleteSubscriptionRequest)(nil), "v1.DeleteSubscriptionRequest")
+	proto.RegisterType((*DeleteSubscriptionResponse)(nil), "v1.DeleteSubscriptionResponse")
+	proto.RegisterType((*WebhookDeadLetter)(nil), "v1.WebhookDeadLetter")
+	proto.RegisterType((*ListDeadLettersRequest)(nil), "v1.ListDeadLettersRequest")
+	proto.RegisterType((*ListDeadLettersResponse)(nil), "v1.ListDeadLettersResponse")
+	proto.RegisterType((*ReplayDeadLetterRequest)(nil), "v1.ReplayDeadLetterRequest")
+	proto.RegisterType((*ReplayDeadLetterResponse)(nil), "v1.ReplayDeadLetterResponse")
+}
+
+func init() { proto.RegisterFile("todo-service.proto", fileDescriptor_80b701c7b1c502fe) }
+
+var fileDescriptor_80b701c7b1c502fe = []byte{
+	// 3858 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3b, 0x4d, 0x6f, 0x1b, 0x49,
+	0x76, 0xdb, 0x24, 0x25, 0x91, 0x8f, 0x12, 0x45, 0x95, 0x3e, 0x48, 0xb5, 0x6c, 0x0f, 0xa7, 0x67,
+	0xc7, 0xa3, 0xd5, 0x8c, 0x44, 0x5b, 0x9e, 0x7c, 0x8c, 0x66, 0x66, 0x61, 0xd9, 0x92, 0x47, 0xca,
+	0x78, 0x6c, 0xa7, 0x25, 0x7b, 0x17, 0xde, 0x0f, 0xa2, 0xd5, 0x5d, 0x92, 0xda, 0x22, 0xbb, 0xb9,
+	0xdd, 0x4d, 0xc9, 0x1c, 0xef, 0x24, 0x40, 0x90, 0x53, 0x80, 0x45, 0x80, 0x04, 0x18, 0x04, 0xb9,
+	0x04, 0xc8, 0xcf, 0xc8, 0x2d, 0x97, 0xdc, 0x82, 0x1c, 0x92, 0x9f, 0x90, 0x20, 0xc8, 0x2d, 0x39,
+	0xe4, 0x1e, 0xbc, 0xaa, 0xea, 0xef, 0x6e, 0x8a, 0x92, 0x32, 0x17, 0xa9, 0xeb, 0xd5, 0xab, 0xf7,
+	0x55, 0xaf, 0x5e, 0x55, 0xbd, 0x7a, 0x04, 0xe2, 0xd9, 0x86, 0xbd, 0xee, 0x52, 0xe7, 0xdc, 0xd4,
+	0xe9, 0x46, 0xdf, 0xb1, 0x3d, 0x9b, 0x14, 0xce, 0xef, 0xcb, 0xef, 0x9d, 0xd8, 0xf6, 0x49, 0x97,
+	0xb6, 0x19, 0xe4, 0x68, 0x70, 0xdc, 0xf6, 0xcc, 0x1e, 0x75, 0x3d, 0xad, 0xd7, 0xe7, 0x48, 0x72,
+	0x2b, 0x89, 0x70, 0x6c, 0xd2, 0xae, 0xd1, 0xe9, 0x69, 0xee, 0x99, 0xc0, 0xb8, 0x25, 0x30, 0xb4,
+	0xbe, 0xd9, 0xd6, 0x2c, 0xcb, 0xf6, 0x34, 0xcf, 0xb4, 0x2d, 0x57, 0xf4, 0x2e, 0x47, 0x7a, 0x4f,
+	0x3d, 0xaf, 0x7f, 0x64, 0x1b, 0x43, 0xd1, 0xd5, 0x10, 0x5d, 0x4e, 0x5f, 0x6f, 0xbb, 0x9e, 0xe6,
+	0x0d, 0xfc, 0x31, 0x9f, 0xb0, 0x7f, 0xfa, 0xfa, 0x09, 0xb5, 0xd6, 0xdd, 0x0b, 0xed, 0xe4, 0x84,
+	0x3a, 0x6d, 0xbb, 0xcf, 0xa8, 0xa6, 0x39, 0x28, 0x7f, 0x5f, 0x82, 0xd2, 0xa1, 0x6d, 0xd8, 0xa4,
+	0x06, 0x05, 0xd3, 0x68, 0x4a, 0x2d, 0x69, 0xb5, 0xa8, 0x16, 0x4c, 0x83, 0x2c, 0xc0, 0x84, 0x67,
+	0x7a, 0x5d, 0xda, 0x2c, 0xb4, 0xa4, 0xd5, 0x8a, 0xca, 0x1b, 0xa4, 0x05, 0x55, 0x83, 0xba, 0xba,
+	0x63, 0x32, 0x82, 0xcd, 0x22, 0xeb, 0x8b, 0x82, 0xc8, 0xef, 0x43, 0xd9, 0xa1, 0x3d, 0xd3, 0x32,
+	0xa8, 0xd3, 0x2c, 0xb5, 0xa4, 0xd5, 0xea, 0xa6, 0xbc, 0xc1, 0x45, 0xdd, 0xf0, 0xad, 0xb0, 0x71,
+	0xe8, 0x9b, 0x49, 0x0d, 0x70, 0xc9, 0x47, 0x30, 0xc9, 0xd5, 0x68, 0x4e, 0xb4, 0xa4, 0xd5, 0xda,
+	0xe6, 0xec, 0xc6, 0xf9, 0xfd, 0x0d, 0x94, 0x6c, 0xe3, 0x80, 0x81, 0x55, 0xd1, 0x4d, 0xbe, 0x84,
+	0x69, 0xdd, 0xee, 0xf5, 0xbb, 0xd4, 0xa3, 0x46, 0x47, 0xf3, 0x9a, 0x93, 0x97, 0x32, 0xa9, 0x06,
+	0xf8, 0xdb, 0x1e, 0x59, 0x82, 0xc9, 0xae, 0x76, 0x44, 0xbb, 0x6e, 0x73, 0xaa, 0x55, 0x5c, 0xad,
+	0xa8, 0xa2, 0x45, 0x3e, 0x03, 0x30, 0x68, 0x40, 0xb4, 0x7c, 0x29, 0xd1, 0x8a, 0xc0, 0xde, 0xf6,
+	0x08, 0x81, 0x12, 0xf5, 0xb4, 0x93, 0x66, 0x85, 0x59, 0x83, 0x7d, 0x93, 0xf7, 0xa0, 0x4a, 0xdf,
+	0x7a, 0xd4, 0xb1, 0xb4, 0x6e, 0xc7, 0x34, 0x9a, 0xc0, 0xba, 0xc0, 0x07, 0xed, 0x1b, 0xe4, 0x0e,
+	0x80, 0x43, 0xf5, 0x81, 0xe3, 0x50, 0x4b, 0xa7, 0xcd, 0x2a, 0xef, 0x0f, 0x21, 0xa4, 0x01, 0x53,
+	0x5d, 0xd3, 0xf5, 0x70, 0xf0, 0x34, 0x9b, 0x94, 0x49, 0x6c, 0xee, 0x1b, 0x64, 0x05, 0x2a, 0xe8,
+	0x66, 0x9d, 0x6f, 0x6d, 0x8b, 0x36, 0x67, 0xd8, 0xb8, 0x32, 0x02, 0x5e, 0xdb, 0x16, 0x25, 0x1f,
+	0x42, 0xad, 0x6b, 0xeb, 0x5a, 0xb7, 0x13, 0xcc, 0x41, 0x8d, 0x61, 0xcc, 0x30, 0xa8, 0x2a, 0x80,
+	0xca, 0x3a, 0x4c, 0x72, 0xab, 0x92, 0x32, 0x94, 0x9e, 0xbf, 0xd8, 0x7d, 0x56, 0xff, 0x11, 0x99,
+	0x85, 0xea, 0xfe, 0xb3, 0xce, 0x0b, 0xf5, 0xf9, 0x57, 0xea, 0xee, 0xc1, 0x41, 0x5d, 0xc2, 0xae,
+	0x9d, 0xe7, 0xcf, 0x76, 0xeb, 0x05, 0xe5, 0xbf, 0x24, 0x28, 0xe3, 0x54, 0x3c, 0x35, 0x5d, 0x2f,
+	0xe5, 0x28, 0x04, 0x4a, 0x96, 0xd6, 0xf3, 0xfd, 0x84, 0x7d, 0x8f, 0xe1, 0x26, 0x9f, 0x43, 0x55,
+	0x77, 0xa8, 0xe6, 0xd1, 0x0e, 0xca, 0x3e, 0x86, 0xa7, 0x00, 0x47, 0x47, 0x00, 0x0e, 0x1e, 0xf4,
+	0x8d, 0x60, 0xf0, 0xc4, 0xe5, 0x83, 0x39, 0x3a, 0x1b, 0x7c, 0x1b, 0x00, 0x97, 0x73, 0x47, 0xb7,
+	0x07, 0x16, 0xf7, 0x9e, 0xa2, 0x5a, 0x41, 0xc8, 0x63, 0x04, 0x28, 0xff, 0x26, 0xc1, 0xd4, 0x63,
+	0xbb, 0xd7, 0xa3, 0x56, 0x5a, 0xd5, 0x06, 0x4c, 0xb1, 0xa1, 0xa6, 0xc1, 0xb4, 0x2d, 0xaa, 0x93,
+	0xd8, 0xdc, 0x37, 0xd0, 0xa9, 0xb4, 0x81, 0x77, 0x6a, 0x3b, 0x42, 0x55, 0xd1, 0x42, 0xdb, 0xe0,
+	0x92, 0x65, 0xea, 0x55, 0x54, 0xf6, 0x9d, 0xd4, 0x7c, 0xe2, 0x4a, 0x9a, 0xff, 0x01, 0x54, 0xa8,
+	0x61, 0x7a, 0x7c, 0xe8, 0xe5, 0x9e, 0x5f, 0x46, 0x64, 0x6c, 0x2a, 0xff, 0x24, 0x01, 0x6c, 0x7b,
+	0x9e, 0xa6, 0x9f, 0x5e, 0x4d, 0xb3, 0x15, 0xa8, 0x1c, 0x9b, 0x5d, 0xda, 0x61, 0x53, 0xcc, 0x95,
+	0x2b, 0x23, 0xe0, 0x19, 0x4e, 0xf3, 0xfb, 0xb8, 0x14, 0x2d, 0x8f, 0x5a, 0x5e, 0xc7, 0x1b, 0xf6,
+	0xa9, 0x50, 0xb3, 0x2a, 0x60, 0x87, 0xc3, 0x3e, 0x45, 0x0b, 0xb8, 0xe6, 0xb7, 0x5c, 0xcd, 0xa2,
+	0xca, 0xbe, 0x93, 0x16, 0x98, 0xbc, 0x8a, 0x05, 0x94, 0x2d, 0x98, 0x78, 0x8a, 0x2b, 0x36, 0xf0,
+	0x3b, 0x29, 0xe2, 0x77, 0xf1, 0xb9, 0x2d, 0x24, 0xe7, 0xf6, 0x14, 0x66, 0x1e, 0x33, 0x4a, 0x2a,
+	0xfd, 0xcd, 0x80, 0xba, 0x1e, 0xa9, 0x43, 0x51, 0xeb, 0x9b, 0x82, 0x04, 0x7e, 0x92, 0x5b, 0x50,
+	0x42, 0x7c, 0x36, 0xb6, 0xba, 0x59, 0xf6, 0x83, 0x90, 0xca, 0xa0, 0xe4, 0x23, 0x98, 0x35, 0x0d,
+	0xda, 0xeb, 0xdb, 0x1e, 0xb5, 0xf4, 0x61, 0xe7, 0x8c, 0x0e, 0x85, 0x4d, 0x6a, 0x11, 0xf0, 0xd7,
+	0x74, 0xa8, 0x3c, 0x81, 0x9a, 0xcf, 0xc9, 0xed, 0xdb, 0x96, 0x4b, 0x33, 0x58, 0xf1, 0x39, 0x28,
+	0x44, 0x17, 0x12, 0x0b, 0x23, 0xc5, 0x30, 0x8c, 0x28, 0x6d, 0xa8, 0xaa, 0x54, 0x33, 0xf2, 0xe5,
+	0x4d, 0x10, 0x51, 0x7e, 0x0a, 0xd3, 0x7c, 0x40, 0x2e, 0xdb, 0x91, 0x1a, 0x2a, 0xbf, 0x85, 0x99,
+	0x97, 0x6c, 0xad, 0x5c, 0xd7, 0x44, 0xe1, 0xda, 0xc4, 0x5d, 0xae, 0x59, 0xcc, 0x99, 0xdc, 0x27,
+	0xb8, 0x11, 0x7e, 0xa3, 0xb9, 0x67, 0xfe, 0xda, 0xc4, 0x6f, 0x34, 0x9b, 0xcf, 0xfd, 0x46, 0x66,
+	0xdb, 0x85, 0x99, 0x1d, 0x16, 0x9e, 0xc7, 0x36, 0x5c, 0x26, 0x99, 0x2f, 0xa0, 0xe6, 0x93, 0xc9,
+	0x15, 0xa7, 0x09, 0x53, 0x62, 0x27, 0x10, 0xc4, 0xfc, 0xa6, 0xf2, 0x00, 0x66, 0x5f, 0x5a, 0xc6,
+	0xd5, 0xc4, 0x50, 0x1e, 0x41, 0x3d, 0x1c, 0x74, 0xcd, 0x39, 0x7c, 0x00, 0xb3, 0x8f, 0xc5, 0x8e,
+	0x77, 0x25, 0xc6, 0xe1, 0xa0, 0x6b, 0x32, 0xbe, 0x0f, 0x33, 0x2a, 0xb5, 0xfb, 0xd4, 0x1a, 0x9f,
+	0xed, 0x43, 0xa8, 0xf9, 0x43, 0xae, 0xc9, 0xf4, 0x43, 0x98, 0xc3, 0x7d, 0x89, 0x05, 0x05, 0x37,
+	0x97, 0xb1, 0xb2, 0x0f, 0x24, 0x8a, 0x96, 0xcb, 0xec, 0xfd, 0xe0, 0x7c, 0x50, 0x68, 0x15, 0x57,
+	0xab, 0x9b, 0x15, 0x64, 0xc7, 0x46, 0xf9, 0x47, 0x05, 0xe5, 0x25, 0x10, 0x95, 0x62, 0xbc, 0xe1,
+	0xe0, 0x5c, 0x5d, 0xb3, 0x76, 0xc6, 0x65, 0x28, 0x5b, 0xf4, 0x22, 0x1a, 0x4e, 0xa7, 0x2c, 0x7a,
+	0x81, 0xd1, 0x54, 0xd9, 0x83, 0xf9, 0x18, 0xd9, 0x5c, 0x11, 0xdf, 0x83, 0x09, 0x26, 0x89, 0x30,
+	0x48, 0x44, 0x42, 0x0e, 0x57, 0xfe, 0x59, 0x42, 0xab, 0x6a, 0xc6, 0x76, 0x77, 0x84, 0x74, 0x2b,
+	0x50, 0xe9, 0x6b, 0x27, 0xb4, 0xc3, 0xc2, 0x33, 0x52, 0x9a, 0x50, 0xcb, 0x08, 0x38, 0xc0, 0x10,
+	0x7d, 0x1b, 0x80, 0x75, 0x7a, 0xf6, 0x19, 0xf5, 0xf7, 0x6f, 0x86, 0x7e, 0x88, 0x00, 0xdc, 0xef,
+	0x8e, 0xcd, 0xae, 0x27, 0x8e, 0x78, 0x15, 0x55, 0xb4, 0x50, 0x3b, 0xdb, 0x31, 0xa8, 0xd3, 0x39,
+	0x1a, 0xb2, 0x88, 0x5f, 0x51, 0xa7, 0x58, 0xfb, 0xd1, 0x10, 0xf7, 0x0a, 0xf7, 0xd4, 0xbe, 0xe8,
+	0xf8, 0x8b, 0x05, 0xa3, 0x7e, 0x59, 0xad, 0x22, 0x8c, 0xaf, 0x31, 0x23, 0x7a, 0xe4, 0x99, 0x8a,
+	0x1e, 0x79, 0x94, 0x33, 0x98, 0x0d, 0xd4, 0xc9, 0xb5, 0xca, 0x1d, 0x98, 0x40, 0x7f, 0xf0, 0xe7,
+	0x2d, 0x74, 0x13, 0x0e, 0x26, 0x77, 0x61, 0xd6, 0xa2, 0x6f, 0xbd, 0x4e, 0x4a, 0xaf, 0x19, 0x04,
+	0xbf, 0xf0, 0x75, 0x53, 0xbe, 0x82, 0x39, 0x1e, 0xba, 0xd1, 0x5d, 0xf2, 0xcd, 0xd7, 0x82, 0x12,
+	0x4a, 0x27, 0xe6, 0x60, 0xda, 0xe7, 0xc6, 0x06, 0xb1, 0x1e, 0x65, 0x0f, 0x48, 0x94, 0x50, 0xae,
+	0xe0, 0x97, 0x53, 0x7a, 0xc0, 0xf5, 0x1f, 0x2d, 0x50, 0x72, 0x65, 0x3d, 0x81, 0x7a, 0x38, 0xe8,
+	0x06, 0xcc, 0xff, 0x5c, 0x82, 0x39, 0x1e, 0x94, 0x6f, 0x68, 0x90, 0x9b, 0x6d, 0x0d, 0x7b, 0x40,
+	0xa2, 0x52, 0xdc, 0x40, 0xa1, 0xe7, 0x30, 0xc7, 0x3d, 0xee, 0x4a, 0xf6, 0xc4, 0x40, 0xaf, 0x6b,
+	0xae, 0xae, 0x19, 0x7c, 0xe1, 0x96, 0x55, 0xbf, 0xa9, 0x50, 0x20, 0x51, 0x82, 0x57, 0xdf, 0x2a,
+	0xc8, 0x07, 0x30, 0x23, 0x3e, 0x3b, 0xdc, 0x87, 0x8b, 0xac, 0x7f, 0x5a, 0x00, 0x51, 0x01, 0x57,
+	0xf9, 0x31, 0xd4, 0x59, 0x04, 0x33, 0x5d, 0x6f, 0x64, 0x9c, 0x9b, 0x8b, 0x60, 0xe5, 0xca, 0xa2,
+	0xc0, 0x04, 0x1a, 0xc3, 0x5f, 0x2d, 0x71, 0x3b, 0xf1, 0x2e, 0x85, 0xc2, 0xdc, 0xb6, 0x61, 0x88,
+	0xc3, 0x70, 0xbe, 0xa1, 0x72, 0xcf, 0x8e, 0x1f, 0xc2, 0x94, 0xce, 0x07, 0x8b, 0xb9, 0xae, 0x22,
+	0x17, 0x9f, 0x9e, 0xdf, 0xa7, 0x7c, 0x03, 0x24, 0xca, 0x26, 0x57, 0xe4, 0x08, 0xb9, 0xc2, 0x08,
+	0x72, 0xbf, 0x85, 0x79, 0x54, 0x42, 0xc0, 0xdd, 0x6b, 0xc8, 0x1d, 0x8b, 0x8c, 0xc5, 0x91, 0x91,
+	0xb1, 0x94, 0x88, 0x8c, 0xca, 0x10, 0x16, 0xe2, 0xdc, 0x73, 0xd5, 0xf9, 0x08, 0xca, 0x42, 0x64,
+	0x7f, 0x12, 0x62, 0xfa, 0x04, 0x9d, 0x63, 0x07, 0xae, 0x63, 0x20, 0xbb, 0x86, 0xe9, 0xfd, 0xe0,
+	0xf3, 0xf5, 0x0c, 0xe6, 0x63, 0x7c, 0x6e, 0x3a, 0x61, 0x7f, 0x0c, 0x0b, 0x7c, 0xf9, 0x5c, 0x5f,
+	0x72, 0xbe, 0x56, 0x8b, 0x41, 0xec, 0x7b, 0x0c, 0x8b, 0x09, 0x92, 0xd7, 0x38, 0xbf, 0xfd, 0x4e,
+	0x82, 0xc6, 0xcb, 0x7e, 0xd7, 0xd6, 0x8c, 0xf0, 0xe2, 0x74, 0x3d, 0x6f, 0xca, 0xbf, 0x41, 0xad,
+	0xc1, 0x84, 0x7e, 0x3a, 0xb0, 0xce, 0xc4, 0x05, 0x78, 0xc1, 0x0f, 0x86, 0x5a, 0xdf, 0xdc, 0xd8,
+	0xf3, 0xbc, 0xfe, 0x23, 0xdb, 0x18, 0xaa, 0x1c, 0x45, 0xf9, 0x25, 0x34, 0xd3, 0xe2, 0xe4, 0xea,
+	0xb5, 0x01, 0xa0, 0x05, 0x78, 0xc2, 0xfe, 0x35, 0xb4, 0x7f, 0x64, 0x74, 0x04, 0x43, 0x79, 0x05,
+	0xcb, 0x3b, 0xf6, 0x85, 0x75, 0x63, 0x75, 0xd3, 0x53, 0xb1, 0x84, 0x0b, 0x22, 0xa4, 0x79, 0x8d,
+	0x15, 0xa9, 0xfc, 0x0a, 0x1a, 0x29, 0x22, 0xb9, 0x9a, 0xdf, 0x83, 0x6a, 0xa8, 0x97, 0xbf, 0xb6,
+	0x92, 0xaa, 0x47, 0x51, 0x94, 0x43, 0x68, 0x70, 0x77, 0xf9, 0x7f, 0xd5, 0xfc, 0x09, 0x34, 0xd3,
+	0x54, 0xaf, 0xe1, 0x87, 0x2e, 0xcc, 0x1c, 0x50, 0xcd, 0xd1, 0x4f, 0xf3, 0x65, 0x5a, 0x80, 0x89,
+	0xdf, 0x0c, 0xa8, 0x33, 0xf4, 0x93, 0x75, 0xac, 0x71, 0xa3, 0x38, 0xf6, 0x37, 0x12, 0x4c, 0xfb,
+	0x5c, 0xdd, 0x41, 0xd7, 0x0b, 0x0e, 0xe1, 0x52, 0xe6, 0xad, 0x6f, 0x01, 0x26, 0x5c, 0xdd, 0x76,
+	0xf8, 0x41, 0x52, 0x52, 0x79, 0x03, 0xb7, 0x35, 0x96, 0x36, 0xec, 0xb8, 0x96, 0xd9, 0xef, 0x53,
+	0x4f, 0xb8, 0xff, 0x34, 0x03, 0x1e, 0x70, 0x18, 0x69, 0xc3, 0x7c, 0x24, 0x31, 0x14, 0xa0, 0x72,
+	0x89, 0x48, 0xa4, 0x4b, 0x0c, 0x50, 0xce, 0xa1, 0x16, 0x48, 0x96, 0x67, 0xcd, 0x35, 0x98, 0x72,
+	0x98, 0xdc, 0xfe, 0xfc, 0xd7, 0x51, 0xe0, 0xa8, 0x42, 0xaa, 0x8f, 0x30, 0x76, 0x7c, 0xfd, 0x15,
+	0x54, 0x1f, 0x69, 0x5e, 0x60, 0x90, 0x8c, 0x3c, 0x18, 0xbb, 0x40, 0x16, 0x22, 0x59, 0xc0, 0xb5,
+	0x20, 0xa9, 0xc9, 0x03, 0x2a, 0xf1, 0xd7, 0xb7, 0xd3, 0xd7, 0x13, 0x79, 0x4d, 0xe5, 0xef, 0x24,
+	0x20, 0x8c, 0xfe, 0x65, 0x29, 0x8a, 0x75, 0xcc, 0xb0, 0xb2, 0x4e, 0x5f, 0xb9, 0x39, 0x16, 0x57,
+	0xa3, 0xc3, 0xd4, 0x00, 0x05, 0x33, 0x91, 0x47, 0xd4, 0xf5, 0x3a, 0xf4, 0xf8, 0xd8, 0x76, 0x3c,
+	0x71, 0x76, 0x01, 0x04, 0xed, 0x32, 0x48, 0x56, 0x52, 0xa3, 0x94, 0x99, 0xd4, 0x50, 0x61, 0x3e,
+	0x26, 0x60, 0xae, 0xf5, 0x7f, 0x92, 0xb4, 0x3e, 0x4b, 0xe6, 0x46, 0x8c, 0x17, 0x18, 0x3f, 0xd4,
+	0xfa, 0xb2, 0xac, 0x43, 0x8e, 0xd6, 0xb1, 0x61, 0x3f, 0xa8, 0xd6, 0x97, 0x26, 0x26, 0xae, 0xa3,
+	0xf5, 0x65, 0x59, 0x8a, 0x1c, 0xad, 0x63, 0xc3, 0x7e, 0x50, 0xad, 0x2f, 0xcd, 0x7f, 0x5c, 0x41,
+	0xeb, 0x5f, 0xc0, 0xf4, 0xcf, 0x38, 0x3c, 0x4f, 0xdd, 0xf0, 0x5e, 0x59, 0x88, 0xdd, 0x2b, 0xdf,
+	0x87, 0x69, 0x24, 0xd2, 0x8b, 0xaf, 0xcf, 0x2a, 0x87, 0xf1, 0xd5, 0xf9, 0xdf, 0x12, 0x54, 0x30,
+	0x20, 0xed, 0x9e, 0x53, 0x2b, 0x8b, 0xf4, 0x5d, 0x28, 0xb1, 0x1c, 0x65, 0x81, 0xbd, 0x2e, 0x10,
+	0x3f, 0x7e, 0x31, 0xf4, 0x0d, 0x4c, 0x55, 0xaa, 0xac, 0x3f, 0x88, 0x73, 0xc5, 0xcc, 0x38, 0xf7,
+	0x19, 0x00, 0x3d, 0x67, 0xf9, 0xce, 0xf1, 0xb2, 0xd6, 0x15, 0x86, 0x8d, 0xed, 0x94, 0x0e, 0x13,
+	0x69, 0x1d, 0xd6, 0xa1, 0x84, 0x92, 0x90, 0x2a, 0x4c, 0x3d, 0x56, 0x77, 0xb7, 0x0f, 0x77, 0x77,
+	0xea, 0x3f, 0xc2, 0xc6, 0xcb, 0x17, 0x3b, 0xac, 0x21, 0x61, 0x63, 0x67, 0xf7, 0xe9, 0x2e, 0x36,
+	0x0a, 0x8a, 0x06, 0x64, 0xf7, 0x6d, 0xdf, 0x76, 0x3c, 0x14, 0xd0, 0xbd, 0x96, 0x55, 0x63, 0x57,
+	0xf2, 0x62, 0xea, 0x4a, 0xae, 0x6c, 0xc3, 0x22, 0x67, 0xf1, 0x58, 0xeb, 0x52, 0xcb, 0xd0, 0x9c,
+	0x2b, 0x73, 0x51, 0x7e, 0x01, 0x8b, 0xfb, 0xbd, 0xf1, 0x48, 0xdc, 0x83, 0xb2, 0x2e, 0x90, 0x9a,
+	0x85, 0x11, 0x07, 0xa2, 0x00, 0x4b, 0x79, 0x05, 0x75, 0x21, 0xdf, 0xc1, 0xab, 0xab, 0x1b, 0x00,
+	0xaf, 0x74, 0x76, 0x77, 0xd0, 0xb3, 0x30, 0x3e, 0xe3, 0x63, 0x90, 0xdf, 0x54, 0x9e, 0x41, 0x7d,
+	0xbf, 0x77, 0x29, 0xdd, 0x55, 0x28, 0xe1, 0x49, 0x6e, 0xa4, 0xac, 0x0c, 0x43, 0x79, 0x08, 0x0b,
+	0xe1, 0x54, 0x1d, 0xbe, 0xf5, 0xae, 0x6e, 0x46, 0x15, 0x16, 0xf6, 0x7b, 0x63, 0x51, 0x18, 0x5f,
+	0xaa, 0xff, 0x94, 0x60, 0x9a, 0x39, 0x37, 0x3d, 0x37, 0x5d, 0x7c, 0x95, 0x91, 0x31, 0xdc, 0xf0,
+	0x6f, 0xb1, 0xb3, 0x05, 0xed, 0xb1, 0x17, 0x50, 0x0b, 0x26, 0x8f, 0xe8, 0x31, 0x9e, 0x05, 0x92,
+	0x4b, 0x48, 0xc0, 0x31, 0x53, 0xa3, 0x1d, 0x7b, 0xc1, 0xfb, 0x60, 0x24, 0x53, 0xc3, 0xc0, 0x78,
+	0x98, 0xd0, 0x74, 0xcf, 0x76, 0xc4, 0x12, 0xe1, 0x0d, 0xf6, 0x6a, 0x70, 0xaa, 0x59, 0x27, 0x57,
+	0x78, 0x35, 0x60, 0xe8, 0x08, 0x50, 0x7e, 0x0f, 0xe6, 0xbe, 0xa2, 0xde, 0x9e, 0xe9, 0x7a, 0xb6,
+	0x33, 0x1c, 0x3f, 0x87, 0xf2, 0x0a, 0x48, 0x74, 0xd8, 0x88, 0xc3, 0x76, 0xc5, 0xb7, 0x53, 0xec,
+	0xc0, 0x11, 0x35, 0xae, 0x1a, 0xa2, 0x28, 0x6f, 0x60, 0x49, 0xa5, 0x48, 0x94, 0x06, 0xbd, 0xe3,
+	0xca, 0x14, 0x9b, 0xa3, 0x62, 0x62, 0x8e, 0xfc, 0x33, 0x48, 0x29, 0x92, 0xc4, 0xde, 0x87, 0x46,
+	0x8a, 0xd7, 0x35, 0x53, 0xad, 0xdf, 0x4b, 0xfc, 0x30, 0xff, 0x5c, 0xf7, 0x9f, 0x29, 0xdd, 0xf1,
+	0xe5, 0xde, 0x80, 0xd2, 0xb1, 0x63, 0xf7, 0x72, 0xd3, 0x3e, 0xe1, 0xc4, 0x31, 0x3c, 0xb2, 0x06,
+	0x05, 0xcf, 0x1e, 0x23, 0xc4, 0x16, 0x3c, 0x26, 0x58, 0x23, 0x25, 0x58, 0xae, 0x92, 0x5f, 0x40,
+	0xd5, 0x0e, 0x11, 0xc5, 0x7c, 0x8d, 0x7c, 0x40, 0x8e, 0xa0, 0x93, 0x8f, 0x61, 0x8e, 0x3f, 0xb1,
+	0x46, 0x69, 0xf0, 0xf0, 0x51, 0x67, 0x1d, 0x11, 0x21, 0x94, 0x3e, 0x54, 0xf9, 0xaa, 0xdd, 0x75,
+	0x1c, 0x9b, 0x79, 0x36, 0x3e, 0xc0, 0xbe, 0x15, 0x8b, 0x8b, 0x37, 0x92, 0x6f, 0xc5, 0x85, 0xd4,
+	0x5b, 0xf1, 0x55, 0x8e, 0x91, 0xdf, 0x4b, 0x30, 0xc3, 0x59, 0x1e, 0x0c, 0x7a, 0x3d, 0xcd, 0x19,
+	0x66, 0xdf, 0x35, 0xf8, 0x8b, 0x5a, 0x70, 0xd7, 0x10, 0x4d, 0xec, 0x71, 0xcf, 0xf0, 0x98, 0xed,
+	0x5f, 0x64, 0xfc, 0x26, 0x8b, 0x4b, 0x9a, 0xd9, 0xa5, 0x06, 0x9b, 0x92, 0xa2, 0x2a, 0x5a, 0xf8,
+	0x6e, 0x4f, 0x51, 0x37, 0x7c, 0xb7, 0x0f, 0xb6, 0xff, 0x88, 0xce, 0xaa, 0xe8, 0x56, 0xfe, 0x51,
+	0x82, 0xf9, 0x9f, 0xd1, 0xa3, 0x53, 0xdb, 0x3e, 0x3b, 0x18, 0x1c, 0x85, 0x2f, 0xc1, 0xc9, 0x73,
+	0x74, 0x1d, 0x8a, 0x03, 0xa7, 0x2b, 0xac, 0x80, 0x9f, 0xc8, 0xda, 0xa5, 0xba, 0x13, 0xdc, 0x1f,
+	0x44, 0x8b, 0x3c, 0x80, 0xaa, 0xd8, 0x8c, 0x87, 0x7d, 0xea, 0x36, 0x4b, 0xad, 0x62, 0x4e, 0x60,
+	0xe2, 0x7b, 0x36, 0x7e, 0xba, 0x37, 0x7a, 0x7e, 0x55, 0xde, 0xc0, 0x32, 0x3f, 0xfc, 0x46, 0x35,
+	0xc8, 0x5f, 0x02, 0x9f, 0xc3, 0xb4, 0x1b, 0x41, 0x14, 0xab, 0xaa, 0x81, 0x12, 0x66, 0x58, 0x42,
+	0x8d, 0x21, 0x2b, 0x67, 0x20, 0x67, 0xf1, 0xca, 0xf5, 0xea, 0x1b, 0x31, 0xfb, 0x04, 0x9a, 0xb8,
+	0x7e, 0xa2, 0x18, 0x23, 0x72, 0x8c, 0x5d, 0x58, 0xce, 0xc0, 0xce, 0x95, 0xec, 0x4b, 0x98, 0x89,
+	0x32, 0xf3, 0x57, 0x5c, 0xae, 0x68, 0x71, 0x6c, 0xe5, 0x4b, 0x58, 0xe6, 0xc7, 0x91, 0xf1, 0x8c,
+	0x9e, 0x8c, 0xe1, 0x7b, 0x20, 0x67, 0x0d, 0xbf, 0xc6, 0x45, 0xfc, 0x3f, 0x0a, 0x30, 0x27, 0xe4,
+	0xdd, 0xc1, 0xcc, 0x3a, 0xf5, 0x70, 0xb7, 0x4a, 0xfa, 0xef, 0x47, 0x30, 0x1b, 0x95, 0x3f, 0xcc,
+	0x13, 0xd4, 0xa2, 0xe0, 0x7d, 0x03, 0x1f, 0x4b, 0xb8, 0xfb, 0x06, 0x59, 0x83, 0x29, 0xd6, 0xde,
+	0x37, 0xc8, 0xfd, 0xe0, 0x98, 0xe9, 0x3f, 0xab, 0x67, 0x3b, 0x76, 0x25, 0x70, 0x6c, 0x14, 0xbb,
+	0xaf, 0x0d, 0x31, 0x7d, 0xe3, 0xbf, 0xbc, 0x88, 0x26, 0x6e, 0x18, 0x9a, 0xe7, 0xd1, 0x5e, 0xdf,
+	0x73, 0xd9, 0xae, 0x39, 0xa1, 0x06, 0x6d, 0xcc, 0x02, 0x74, 0x35, 0xbc, 0x30, 0xe0, 0x1a, 0x65,
+	0xaf, 0x2e, 0x15, 0xb5, 0x82, 0x10, 0x1e, 0xaf, 0x12, 0x8b, 0xa5, 0x7c, 0xd5, 0x5a, 0x05, 0x8c,
+	0x11, 0x7c, 0x68, 0xe5, 0xd2, 0xa1, 0x65, 0x44, 0x66, 0xab, 0xec, 0x2f, 0xc5, 0x36, 0x13, 0x1a,
+	0x79, 0xc4, 0x36, 0x33, 0xb6, 0xb9, 0x6f, 0x92, 0x0d, 0xf9, 0x9d, 0xd8, 0x5f, 0x62, 0x12, 0xe5,
+	0x7a, 0xd0, 0x1f, 0xc2, 0xb4, 0x41, 0x35, 0xa3, 0xd3, 0xe5, 0x98, 0xc2, 0xdd, 0x17, 0x23, 0xee,
+	0x1e, 0xd2, 0xc1, 0xaa, 0x98, 0x80, 0xe6, 0xd8, 0xa9, 0x88, 0xcf, 0x71, 0x4f, 0xef, 0x77, 0xb5,
+	0x61, 0x84, 0xd0, 0xd8, 0x0b, 0xe2, 0x13, 0x68, 0xa6, 0x07, 0xe7, 0x29, 0xb3, 0xf9, 0x0f, 0x2b,
+	0x50, 0x45, 0xaf, 0x3b, 0xe0, 0xd5, 0x6f, 0x84, 0xc2, 0x94, 0x78, 0x8b, 0x23, 0xcc, 0x23, 0xe3,
+	0xef, 0x8c, 0xf2, 0x7c, 0x0c, 0xc6, 0xa9, 0x2a, 0xf7, 0xff, 0xec, 0x5f, 0xff, 0xfd, 0xaf, 0x0b,
+	0x1f, 0x93, 0xe9, 0xf6, 0xf9, 0xfd, 0xb6, 0x67, 0x1b, 0x76, 0x5b, 0xeb, 0x76, 0x5f, 0xaf, 0x90,
+	0x65, 0x6c, 0xb3, 0xb7, 0x86, 0xf6, 0x3b, 0xf1, 0xf2, 0xf7, 0x5d, 0x9b, 0xbf, 0xd6, 0xed, 0xc0,
+	0x24, 0x8f, 0x7e, 0x24, 0x9d, 0xdc, 0x90, 0x49, 0x14, 0x24, 0x78, 0xcc, 0x33, 0x1e, 0x33, 0x4a,
+	0xd9, 0xe7, 0xb1, 0x25, 0xad, 0x91, 0x37, 0x30, 0xc9, 0xaf, 0xed, 0x24, 0x9d, 0x2c, 0x90, 0x49,
+	0x14, 0x24, 0xa8, 0x7c, 0xc6, 0xa8, 0x3c, 0x90, 0x49, 0x20, 0xe9, 0x3b, 0xfc, 0xbb, 0x61, 0x1a,
+	0xdf, 0x6d, 0x49, 0x6b, 0xaf, 0xe5, 0xcd, 0xac, 0x0e, 0x7e, 0x35, 0xfc, 0x35, 0xd4, 0xe2, 0x57,
+	0x25, 0xb2, 0x8c, 0x0c, 0x32, 0xaf, 0x4f, 0x72, 0xe6, 0xa9, 0x5c, 0x59, 0x61, 0xdc, 0x17, 0xc9,
+	0x7c, 0xc0, 0x84, 0xb2, 0xd1, 0x1b, 0xa6, 0xee, 0x92, 0x43, 0xa8, 0x04, 0x57, 0x1d, 0xb2, 0x10,
+	0x21, 0x7d, 0xf0, 0xea, 0x9a, 0x54, 0x75, 0xf7, 0x9c, 0xfc, 0x12, 0x66, 0x62, 0x17, 0x13, 0xd2,
+	0x0c, 0x29, 0xc7, 0x6f, 0x1a, 0x57, 0xa5, 0xee, 0xbd, 0xf5, 0xc8, 0x43, 0x28, 0xa1, 0x2f, 0x90,
+	0x59, 0xdf, 0x2b, 0x7c, 0x5a, 0xf5, 0x10, 0x20, 0x2c, 0xbf, 0xc8, 0xe8, 0xcc, 0x92, 0x99, 0xd0,
+	0xc0, 0xa6, 0xf1, 0x1d, 0x79, 0x02, 0x93, 0x3c, 0x7a, 0x93, 0x74, 0xe2, 0x43, 0x26, 0x51, 0x50,
+	0x9c, 0xce, 0x5a, 0x82, 0xce, 0xcf, 0xa1, 0xec, 0xd7, 0x55, 0x10, 0xe6, 0xa3, 0x89, 0xd2, 0x0c,
+	0x79, 0x21, 0x0e, 0x14, 0xd4, 0xde, 0x67, 0xd4, 0x56, 0x94, 0xa5, 0x18, 0xb5, 0xad, 0x81, 0xc0,
+	0x43, 0x1f, 0xfb, 0x39, 0x94, 0xfd, 0xc2, 0x09, 0x4e, 0x39, 0x51, 0x7b, 0x21, 0x2f, 0xc4, 0x81,
+	0xa3, 0x29, 0xfb, 0xa5, 0x8a, 0x48, 0xf9, 0x05, 0x4c, 0xf2, 0xda, 0x08, 0xae, 0x7b, 0xac, 0xb4,
+	0x42, 0x26, 0x51, 0x90, 0xa0, 0xf9, 0x1e, 0xa3, 0xb9, 0xac, 0x2c, 0xc4, 0x69, 0x3a, 0x0c, 0x0b,
+	0x29, 0x3e, 0x07, 0x08, 0x8b, 0x20, 0x08, 0x8b, 0x48, 0xa9, 0xda, 0x09, 0x79, 0x29, 0x09, 0x16,
+	0xd4, 0x09, 0xa3, 0x3e, 0x4d, 0x80, 0xad, 0x5a, 0x4e, 0x42, 0xc7, 0xfa, 0xa4, 0xa0, 0x66, 0x81,
+	0x2c, 0x71, 0xa1, 0x92, 0xb5, 0x11, 0x72, 0x23, 0x05, 0x17, 0x34, 0x3f, 0x60, 0x34, 0x6f, 0x2b,
+	0xcd, 0x90, 0x66, 0xfb, 0x1d, 0xa2, 0xa1, 0xd4, 0xf8, 0x1f, 0xa5, 0xee, 0x88, 0xc4, 0xab, 0x08,
+	0x08, 0x4b, 0x41, 0x82, 0x29, 0x1e, 0x15, 0x1a, 0x29, 0x78, 0x9e, 0x59, 0xb6, 0x8e, 0x42, 0xac,
+	0x28, 0x03, 0x11, 0x2b, 0x42, 0x06, 0xf1, 0x80, 0xd1, 0x48, 0xc1, 0x47, 0x33, 0xe0, 0x58, 0x51,
+	0x06, 0xc2, 0x95, 0x43, 0x06, 0x71, 0x7f, 0x6e, 0xa4, 0xe0, 0xa3, 0x19, 0xec, 0x04, 0x4e, 0xf8,
+	0x08, 0x26, 0x58, 0x6a, 0x8d, 0xb0, 0x85, 0x15, 0xcd, 0xb2, 0xc9, 0x33, 0xb1, 0x73, 0x83, 0xb2,
+	0xc4, 0x48, 0xd5, 0x49, 0x2d, 0x20, 0x75, 0x81, 0xd8, 0xf7, 0x24, 0xf2, 0x47, 0x50, 0x0d, 0xd7,
+	0xbd, 0xcb, 0x85, 0x4c, 0xe7, 0x97, 0xe4, 0xe0, 0x52, 0xa8, 0x34, 0x18, 0xa9, 0x39, 0x32, 0x1b,
+	0x90, 0xe2, 0x4b, 0xff, 0x9e, 0x44, 0xf6, 0xfc, 0x7b, 0x0f, 0xa7, 0x15, 0x8c, 0x91, 0xe7, 0xc2,
+	0xeb, 0x81, 0xb8, 0x9f, 0x28, 0x32, 0x23, 0xb3, 0xa0, 0x84, 0x64, 0x4c, 0xd6, 0xbf, 0x25, 0xad,
+	0xad, 0x4a, 0xe4, 0xa7, 0x50, 0xdb, 0xef, 0xa5, 0xc3, 0x6a, 0x66, 0x4a, 0x29, 0x83, 0x3a, 0xf9,
+	0x14, 0x2a, 0xfb, 0xbd, 0x58, 0xd8, 0x4c, 0x26, 0x76, 0xb2, 0x46, 0x7d, 0xe1, 0x5f, 0xa2, 0x62,
+	0x61, 0x31, 0x2b, 0x01, 0x93, 0x35, 0xfa, 0x35, 0x40, 0x98, 0x36, 0xe0, 0xcb, 0x2c, 0x95, 0x7d,
+	0x90, 0x97, 0x92, 0x60, 0x31, 0xd7, 0xb7, 0x99, 0x39, 0x1a, 0x64, 0x31, 0xb6, 0x88, 0xdb, 0xa7,
+	0x82, 0xda, 0x9f, 0x62, 0x2d, 0x48, 0xec, 0x3a, 0x4f, 0x64, 0xbe, 0xba, 0xb2, 0xf2, 0x09, 0xf2,
+	0x4a, 0x66, 0x9f, 0x60, 0xf5, 0x80, 0xb1, 0x5a, 0x57, 0x56, 0x33, 0x59, 0xb5, 0xdf, 0xf9, 0x79,
+	0x05, 0x5c, 0x8c, 0x8c, 0x02, 0xba, 0x5a, 0x0f, 0x66, 0x13, 0x57, 0x6d, 0x2e, 0x40, 0x76, 0x62,
+	0x40, 0x5e, 0xc9, 0xec, 0x8b, 0x07, 0x41, 0xb2, 0x1c, 0x17, 0x20, 0x7a, 0xdd, 0x3e, 0x04, 0x08,
+	0xab, 0x68, 0xb8, 0x2d, 0x53, 0xe5, 0x39, 0xf2, 0x52, 0x12, 0x2c, 0xe8, 0x0b, 0x0f, 0x55, 0x2a,
+	0xc1, 0x41, 0x63, 0x8b, 0x97, 0xa2, 0x7c, 0x0d, 0x95, 0xa0, 0x4a, 0x82, 0x7b, 0x45, 0xb2, 0xb4,
+	0x42, 0x5e, 0x4c, 0x40, 0x05, 0xc9, 0x39, 0x46, 0xb2, 0x4a, 0x42, 0x92, 0xe4, 0x1b, 0x28, 0xfb,
+	0x95, 0x36, 0x24, 0x38, 0xff, 0x44, 0xc5, 0x5b, 0x88, 0x03, 0x05, 0xa5, 0xd8, 0x4a, 0x14, 0xa7,
+	0x20, 0xdc, 0xaa, 0x7e, 0x0d, 0x10, 0x56, 0xba, 0x70, 0x8d, 0x53, 0xf5, 0x37, 0xf2, 0x52, 0x12,
+	0x1c, 0xb7, 0xe8, 0xe6, 0x7c, 0xe2, 0x68, 0xc5, 0x0f, 0x2a, 0x4c, 0xf7, 0x03, 0x80, 0xb0, 0x5c,
+	0x85, 0xd3, 0x4f, 0xd5, 0xc3, 0xc8, 0x4b, 0x49, 0x70, 0x5c, 0xe8, 0xb5, 0xa4, 0xd0, 0x26, 0x40,
+	0x58, 0xc4, 0xc1, 0x89, 0xa6, 0x6a, 0x47, 0xe4, 0xa5, 0x24, 0x58, 0x10, 0xfd, 0x84, 0x11, 0xbd,
+	0xab, 0xac, 0xc4, 0x0f, 0x57, 0xec, 0x38, 0xe8, 0x97, 0x41, 0x6c, 0xf9, 0xf5, 0x02, 0xe4, 0x18,
+	0xa6, 0xa3, 0x25, 0x16, 0xa4, 0xe1, 0x4f, 0x54, 0xa2, 0xe4, 0x43, 0x6e, 0xa6, 0x3b, 0xe2, 0xdb,
+	0x0e, 0x19, 0xc5, 0x90, 0x0c, 0xa1, 0x1a, 0xa9, 0x73, 0x10, 0xf1, 0x30, 0x55, 0x60, 0x21, 0x37,
+	0x52, 0xf0, 0xf8, 0x59, 0x72, 0x73, 0x75, 0x04, 0x93, 0xf6, 0x3b, 0xf1, 0xc5, 0xe6, 0x27, 0x50,
+	0xd1, 0xf2, 0xeb, 0x57, 0x7d, 0xe6, 0xcd, 0x70, 0x3a, 0x12, 0xec, 0x97, 0x33, 0x7a, 0x84, 0x00,
+	0xab, 0x4c, 0x00, 0x65, 0xad, 0x35, 0x52, 0x00, 0x9c, 0xbd, 0xe7, 0x50, 0x4f, 0x96, 0x16, 0x90,
+	0x15, 0xee, 0x61, 0x99, 0x05, 0x01, 0xf2, 0xad, 0xec, 0x4e, 0xce, 0x78, 0x55, 0x22, 0x5f, 0x03,
+	0x49, 0x57, 0x13, 0x90, 0xdb, 0x4c, 0xd6, 0xbc, 0x2a, 0x83, 0xec, 0x03, 0xe6, 0x3d, 0x89, 0x38,
+	0x3c, 0xe2, 0x84, 0x03, 0x22, 0x11, 0x27, 0x5d, 0x57, 0x20, 0xaf, 0x64, 0xf6, 0x09, 0x9b, 0xdc,
+	0x65, 0x36, 0x69, 0x91, 0x3b, 0x19, 0x36, 0x89, 0x94, 0x04, 0x90, 0x6f, 0xa1, 0x9e, 0x7c, 0xbc,
+	0xe7, 0x16, 0xc9, 0x29, 0x14, 0x90, 0x6f, 0x65, 0x77, 0x0a, 0xb6, 0x1f, 0x33, 0xb6, 0x1f, 0xae,
+	0x7d, 0x30, 0x9a, 0x2d, 0x9f, 0x8d, 0x3d, 0x98, 0xe4, 0x2f, 0xd5, 0xfc, 0xdc, 0x17, 0x7b, 0xfc,
+	0x97, 0x49, 0x14, 0x14, 0x0f, 0x73, 0x91, 0x8d, 0xd8, 0x65, 0x08, 0x9b, 0xff, 0x52, 0x82, 0x9a,
+	0x9f, 0x61, 0x11, 0xf7, 0xb7, 0x0b, 0xbf, 0x2a, 0x31, 0x96, 0x84, 0xbb, 0x1d, 0x06, 0xd0, 0x8c,
+	0x2c, 0x8b, 0x7c, 0x27, 0xaf, 0x5b, 0x08, 0xa0, 0x30, 0x01, 0x6e, 0x29, 0xec, 0x82, 0x77, 0xc1,
+	0x19, 0xba, 0x5b, 0xb1, 0x14, 0x13, 0x39, 0xe6, 0x85, 0x69, 0xd1, 0xf1, 0x2e, 0xb9, 0xe5, 0xcf,
+	0x55, 0x56, 0xe6, 0x49, 0xbe, 0x9d, 0xd3, 0x2b, 0xb8, 0x2e, 0x30, 0xae, 0x35, 0x12, 0xe3, 0x4a,
+	0x2c, 0xbf, 0x1a, 0x2f, 0xad, 0x60, 0x6e, 0x1a, 0x49, 0xbe, 0x93, 0xd7, 0x2d, 0x58, 0x2d, 0x33,
+	0x56, 0xf3, 0x6b, 0x73, 0x51, 0x56, 0x7c, 0xb6, 0xba, 0xdc, 0x3b, 0x23, 0xa9, 0x81, 0xd0, 0x3b,
+	0xd3, 0x19, 0x0c, 0x79, 0x25, 0xb3, 0x4f, 0xb0, 0x69, 0x31, 0x36, 0x32, 0x69, 0xc6, 0xd8, 0x60,
+	0x86, 0x40, 0xe4, 0x12, 0xc8, 0x9f, 0x40, 0x3d, 0x79, 0x79, 0x27, 0x62, 0x8f, 0xcf, 0xcc, 0x07,
+	0xc8, 0xb7, 0xb2, 0x3b, 0x05, 0xc3, 0x36, 0x63, 0xf8, 0x13, 0xe5, 0xc7, 0x79, 0x0c, 0xfd, 0x1b,
+	0x04, 0x8e, 0xdf, 0x92, 0xd6, 0x1e, 0xfd, 0xaf, 0xf4, 0x57, 0xdb, 0xff, 0x23, 0x91, 0xbf, 0x10,
+	0x2f, 0x47, 0x2d, 0xf1, 0xa3, 0x38, 0x65, 0x00, 0x77, 0x4f, 0xec, 0xf5, 0x13, 0xa7, 0xaf, 0xaf,
+	0xe3, 0x0f, 0xd5, 0xd6, 0x1d, 0xea, 0x7a, 0xeb, 0x3d, 0x53, 0x77, 0x6c, 0x81, 0xd1, 0xea, 0x3b,
+	0xf6, 0x1b, 0xaa, 0x7b, 0xe4, 0x33, 0xec, 0x77, 0xb7, 0xda, 0xed, 0x13, 0xd3, 0x3b, 0x1d, 0x1c,
+	0x6d, 0xe8, 0x76, 0xaf, 0xfd, 0xd4, 0xec, 0x6a, 0xd6, 0x89, 0xd6, 0x1e, 0x4d, 0x42, 0xae, 0x77,
+	0x39, 0xde, 0xc3, 0xae, 0x79, 0x4e, 0x71, 0xe0, 0x66, 0xf1, 0xfe, 0xc6, 0xbd, 0x35, 0x49, 0xda,
+	0xac, 0x6b, 0xfd, 0x7e, 0xd7, 0xd4, 0xd9, 0x4f, 0xdb, 0xda, 0x6f, 0x5c, 0xdb, 0xda, 0x4a, 0x41,
+	0xd4, 0xcf, 0xa1, 0xf8, 0xe9, 0xbd, 0x4f, 0xc9, 0xa7, 0xb0, 0xa6, 0x52, 0x6f, 0xe0, 0x58, 0xd4,
+	0x68, 0x5d, 0x9c, 0x52, 0xab, 0xe5, 0x9d, 0xd2, 0x96, 0x43, 0x5d, 0x7b, 0xe0, 0xe8, 0xb4, 0x65,
+	0xd8, 0xd4, 0x6d, 0x59, 0xb6, 0xd7, 0xa2, 0x6f, 0x71, 0xd7, 0x24, 0x93, 0x50, 0xfa, 0xdb, 0x82,
+	0x34, 0x75, 0x34, 0xc9, 0x32, 0x56, 0x0f, 0xfe, 0x6f, 0x00, 0x33, 0x2a, 0x7f, 0x78, 0x0b, 0x38,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// TodoServiceClient is the client API for TodoService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TodoServiceClient interface {
+	ReadAll(ctx context.Context, in *ReadAllRequest, opts ...grpc.CallOption) (*ReadAllResponse, error)
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	// renders todos as RFC 5545 VTODO components of text/calendar body. The reminder is the
+	// start of the VTODO with VALARM triggered at it, recurrence is RRULE. It precedes Read,
+	// which would take "export.ics" as the todo id otherwise.
+	ExportCalendar(ctx context.Context, in *ExportCalendarRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// renders todos as text/csv body with a header row, times are RFC 3339 in UTC and labels
+	// are separated by commas. Text starting with =, +, - or @ is prefixed with ' so that
+	// spreadsheets do not evaluate it, ImportCSV removes the prefix.
+	ExportCSV(ctx context.Context, in *ExportCSVRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// renders todos as text/plain body in todo.txt format. Labels are +project tags with
+	// spaces replaced by underscores, the reminder is due tag, recurrence is rrule tag, the
+	// external id is uid tag and status:in-progress marks todo in progress. Words of the title
+	// which would be read as tags or markers are prefixed with backslash. Descriptions are not
+	// written.
+	ExportTodoTxt(ctx context.Context, in *ExportTodoTxtRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Undelete(ctx context.Context, in *UndeleteRequest, opts ...grpc.CallOption) (*UndeleteResponse, error)
+	Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResponse, error)
+	Reopen(ctx context.Context, in *ReopenRequest, opts ...grpc.CallOption) (*ReopenResponse, error)
+	ListLabels(ctx context.Context, in *ListLabelsRequest, opts ...grpc.CallOption) (*ListLabelsResponse, error)
+	RenameLabel(ctx context.Context, in *RenameLabelRequest, opts ...grpc.CallOption) (*RenameLabelResponse, error)
+	BatchCreate(ctx context.Context, in *BatchCreateRequest, opts ...grpc.CallOption) (*BatchCreateResponse, error)
+	BatchUpdate(ctx context.Context, in *BatchUpdateRequest, opts ...grpc.CallOption) (*BatchUpdateResponse, error)
+	BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (TodoService_WatchClient, error)
+	ExportTodos(ctx context.Context, in *ExportTodosRequest, opts ...grpc.CallOption) (TodoService_ExportTodosClient, error)
+	// todos are created in batched transactions, batches committed before a
+	// stream error are kept, so the import should be retried with external ids
+	ImportTodos(ctx context.Context, opts ...grpc.CallOption) (TodoService_ImportTodosClient, error)
+	// creates todos from VTODO components of the calendar, UID is the external id so that the
+	// calendar can be imported again. The reminder is taken from the first VALARM, DUE or
+	// DTSTART. REST clients post the raw .ics file to /v1/todo/import.ics.
+	ImportCalendar(ctx context.Context, in *ImportCalendarRequest, opts ...grpc.CallOption) (*ImportSummary, error)
+	// creates todos from rows of the CSV file, error index 0 is the first row after the header.
+	// REST clients post the raw or multipart file to /v1/todo/import.csv.
+	ImportCSV(ctx context.Context, in *ImportCSVRequest, opts ...grpc.CallOption) (*ImportSummary, error)
+	// creates todos from lines of the todo.txt file as written by ExportTodoTxt, +project and
+	// @context tags are labels. Error index 0 is the first line. REST clients post the raw or
+	// multipart file to /v1/todo/import.txt.
+	ImportTodoTxt(ctx context.Context, in *ImportTodoTxtRequest, opts ...grpc.CallOption) (*ImportSummary, error)
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// restores content, labels and list of the todo to the revision, todo in trash must be
+	// undeleted first. Todo whose list was deleted since the revision is left without list.
+	RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*RestoreRevisionResponse, error)
+	// lists upcoming occurrences of the todo for calendar views
+	ListOccurrences(ctx context.Context, in *ListOccurrencesRequest, opts ...grpc.CallOption) (*ListOccurrencesResponse, error)
+	CreateList(ctx context.Context, in *CreateListRequest, opts ...grpc.CallOption) (*CreateListResponse, error)
+	ListLists(ctx context.Context, in *ListListsRequest, opts ...grpc.CallOption) (*ListListsResponse, error)
+	ReadList(ctx context.Context, in *ReadListRequest, opts ...grpc.CallOption) (*ReadListResponse, error)
+	UpdateList(ctx context.Context, in *UpdateListRequest, opts ...grpc.CallOption) (*UpdateListResponse, error)
+	// todos in trash are detached from the deleted list
+	DeleteList(ctx context.Context, in *DeleteListRequest, opts ...grpc.CallOption) (*DeleteListResponse, error)
+	// comments of todo in trash are hidden until it is restored and removed when it is purged.
+	// The author is the authenticated user, the actor of the API key or of the trusted proxy
+	// header, and comments cannot be added, edited or deleted without it. Only single-tenant
+	// server takes the author from "actor" metadata sent by client, as it does not authenticate.
+	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*AddCommentResponse, error)
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	// comment can be edited by its author only
+	EditComment(ctx context.Context, in *EditCommentRequest, opts ...grpc.CallOption) (*EditCommentResponse, error)
+	// comment can be deleted by its author only
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
+	// uploads file in chunks, REST clients post the file as multipart/form-data or as raw body
+	// with file_name query parameter to /v1/todo/{todo_id}/attachments. Attachments of todo in
+	// trash are hidden until it is restored and removed when it is purged.
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (TodoService_UploadAttachmentClient, error)
+	// downloads file in chunks, the first chunk carries the content type and "file-name-bin"
+	// header metadata carries the file name. REST clients get the raw file from
+	// /v1/todo/{todo_id}/attachments/{id}.
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (TodoService_DownloadAttachmentClient, error)
+	ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+	DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error)
+	// finds todos by words of their title and description, ranked by relevance
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+}
+
+type todoServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTodoServiceClient(cc *grpc.ClientConn) TodoServiceClient {
+	return &todoServiceClient{cc}
+}
+
+func (c *todoServiceClient) ReadAll(ctx context.Context, in *ReadAllRequest, opts ...grpc.CallOption) (*ReadAllResponse, error) {
+	out := new(ReadAllResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ReadAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ExportCalendar(ctx context.Context, in *ExportCalendarRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ExportCalendar", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ExportCSV(ctx context.Context, in *ExportCSVRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ExportCSV", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ExportTodoTxt(ctx context.Context, in *ExportTodoTxtRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ExportTodoTxt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
+	out := new(ReadResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/Read", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) Undelete(ctx context.Context, in *UndeleteRequest, opts ...grpc.CallOption) (*UndeleteResponse, error) {
+	out := new(UndeleteResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/Undelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResponse, error) {
+	out := new(CompleteResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/Complete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) Reopen(ctx context.Context, in *ReopenRequest, opts ...grpc.CallOption) (*ReopenResponse, error) {
+	out := new(ReopenResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/Reopen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListLabels(ctx context.Context, in *ListLabelsRequest, opts ...grpc.CallOption) (*ListLabelsResponse, error) {
+	out := new(ListLabelsResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ListLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) RenameLabel(ctx context.Context, in *RenameLabelRequest, opts ...grpc.CallOption) (*RenameLabelResponse, error) {
+	out := new(RenameLabelResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/RenameLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) BatchCreate(ctx context.Context, in *BatchCreateRequest, opts ...grpc.CallOption) (*BatchCreateResponse, error) {
+	out := new(BatchCreateResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/BatchCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) BatchUpdate(ctx context.Context, in *BatchUpdateRequest, opts ...grpc.CallOption) (*BatchUpdateResponse, error) {
+	out := new(BatchUpdateResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/BatchUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error) {
+	out := new(BatchDeleteResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/BatchDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (TodoService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TodoService_serviceDesc.Streams[0], "/v1.TodoService/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &todoServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TodoService_WatchClient interface {
+	Recv() (*TodoEvent, error)
+	grpc.ClientStream
+}
+
+type todoServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *todoServiceWatchClient) Recv() (*TodoEvent, error) {
+	m := new(TodoEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *todoServiceClient) ExportTodos(ctx context.Context, in *ExportTodosRequest, opts ...grpc.CallOption) (TodoService_ExportTodosClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TodoService_serviceDesc.Streams[1], "/v1.TodoService/ExportTodos", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &todoServiceExportTodosClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TodoService_ExportTodosClient interface {
+	Recv() (*Todo, error)
+	grpc.ClientStream
+}
+
+type todoServiceExportTodosClient struct {
+	grpc.ClientStream
+}
+
+func (x *todoServiceExportTodosClient) Recv() (*Todo, error) {
+	m := new(Todo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *todoServiceClient) ImportTodos(ctx context.Context, opts ...grpc.CallOption) (TodoService_ImportTodosClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TodoService_serviceDesc.Streams[2], "/v1.TodoService/ImportTodos", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &todoServiceImportTodosClient{stream}
+	return x, nil
+}
+
+type TodoService_ImportTodosClient interface {
+	Send(*Todo) error
+	CloseAndRecv() (*ImportSummary, error)
+	grpc.ClientStream
+}
+
+type todoServiceImportTodosClient struct {
+	grpc.ClientStream
+}
+
+func (x *todoServiceImportTodosClient) Send(m *Todo) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *todoServiceImportTodosClient) CloseAndRecv() (*ImportSummary, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportSummary)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *todoServiceClient) ImportCalendar(ctx context.Context, in *ImportCalendarRequest, opts ...grpc.CallOption) (*ImportSummary, error) {
+	out := new(ImportSummary)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ImportCalendar", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ImportCSV(ctx context.Context, in *ImportCSVRequest, opts ...grpc.CallOption) (*ImportSummary, error) {
+	out := new(ImportSummary)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ImportCSV", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ImportTodoTxt(ctx context.Context, in *ImportTodoTxtRequest, opts ...grpc.CallOption) (*ImportSummary, error) {
+	out := new(ImportSummary)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ImportTodoTxt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/GetHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*RestoreRevisionResponse, error) {
+	out := new(RestoreRevisionResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/RestoreRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListOccurrences(ctx context.Context, in *ListOccurrencesRequest, opts ...grpc.CallOption) (*ListOccurrencesResponse, error) {
+	out := new(ListOccurrencesResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ListOccurrences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) CreateList(ctx context.Context, in *CreateListRequest, opts ...grpc.CallOption) (*CreateListResponse, error) {
+	out := new(CreateListResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/CreateList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListLists(ctx context.Context, in *ListListsRequest, opts ...grpc.CallOption) (*ListListsResponse, error) {
+	out := new(ListListsResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ListLists", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ReadList(ctx context.Context, in *ReadListRequest, opts ...grpc.CallOption) (*ReadListResponse, error) {
+	out := new(ReadListResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ReadList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) UpdateList(ctx context.Context, in *UpdateListRequest, opts ...grpc.CallOption) (*UpdateListResponse, error) {
+	out := new(UpdateListResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/UpdateList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) DeleteList(ctx context.Context, in *DeleteListRequest, opts ...grpc.CallOption) (*DeleteListResponse, error) {
+	out := new(DeleteListResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/DeleteList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*AddCommentResponse, error) {
+	out := new(AddCommentResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/AddComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ListComments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) EditComment(ctx context.Context, in *EditCommentRequest, opts ...grpc.CallOption) (*EditCommentResponse, error) {
+	out := new(EditCommentResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/EditComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
+	out := new(DeleteCommentResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/DeleteComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (TodoService_UploadAttachmentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TodoService_serviceDesc.Streams[3], "/v1.TodoService/UploadAttachment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &todoServiceUploadAttachmentClient{stream}
+	return x, nil
+}
+
+type TodoService_UploadAttachmentClient interface {
+	Send(*UploadAttachmentRequest) error
+	CloseAndRecv() (*UploadAttachmentResponse, error)
+	grpc.ClientStream
+}
+
+type todoServiceUploadAttachmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *todoServiceUploadAttachmentClient) Send(m *UploadAttachmentRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *todoServiceUploadAttachmentClient) CloseAndRecv() (*UploadAttachmentResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadAttachmentResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *todoServiceClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (TodoService_DownloadAttachmentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TodoService_serviceDesc.Streams[4], "/v1.TodoService/DownloadAttachment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &todoServiceDownloadAttachmentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TodoService_DownloadAttachmentClient interface {
+	Recv() (*httpbody.HttpBody, error)
+	grpc.ClientStream
+}
+
+type todoServiceDownloadAttachmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *todoServiceDownloadAttachmentClient) Recv() (*httpbody.HttpBody, error) {
+	m := new(httpbody.HttpBody)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *todoServiceClient) ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/ListAttachments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error) {
+	out := new(DeleteAttachmentResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/DeleteAttachment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/v1.TodoService/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TodoServiceServer is the server API for TodoService service.
+type TodoServiceServer interface {
+	ReadAll(context.Context, *ReadAllRequest) (*ReadAllResponse, error)
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	// renders todos as RFC 5545 VTODO components of text/calendar body. The reminder is the
+	// start of the VTODO with VALARM triggered at it, recurrence is RRULE. It precedes Read,
+	// which would take "export.ics" as the todo id otherwise.
+	ExportCalendar(context.Context, *ExportCalendarRequest) (*httpbody.HttpBody, error)
+	// renders todos as text/csv body with a header row, times are RFC 3339 in UTC and labels
+	// are separated by commas. Text starting with =, +, - or @ is prefixed with ' so that
+	// spreadsheets do not evaluate it, ImportCSV removes the prefix.
+	ExportCSV(context.Context, *ExportCSVRequest) (*httpbody.HttpBody, error)
+	// renders todos as text/plain body in todo.txt format. Labels are +project tags with
+	// spaces replaced by underscores, the reminder is due tag, recurrence is rrule tag, the
+	// external id is uid tag and status:in-progress marks todo in progress. Words of the title
+	// which would be read as tags or markers are prefixed with backslash. Descriptions are not
+	// written.
+	ExportTodoTxt(context.Context, *ExportTodoTxtRequest) (*httpbody.HttpBody, error)
+	Read(context.Context, *ReadRequest) (*ReadResponse, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Undelete(context.Context, *UndeleteRequest) (*UndeleteResponse, error)
+	Complete(context.Context, *CompleteRequest) (*CompleteResponse, error)
+	Reopen(context.Context, *ReopenRequest) (*ReopenResponse, error)
+	ListLabels(context.Context, *ListLabelsRequest) (*ListLabelsResponse, error)
+	RenameLabel(context.Context, *RenameLabelRequest) (*RenameLabelResponse, error)
+	BatchCreate(context.Context, *BatchCreateRequest) (*BatchCreateResponse, error)
+	BatchUpdate(context.Context, *BatchUpdateRequest) (*BatchUpdateResponse, error)
+	BatchDelete(context.Context, *BatchDeleteRequest) (*BatchDeleteResponse, error)
+	Watch(*WatchRequest, TodoService_WatchServer) error
+	ExportTodos(*ExportTodosRequest, TodoService_ExportTodosServer) error
+	// todos are created in batched transactions, batches committed before a
+	// stream error are kept, so the import should be retried with external ids
+	ImportTodos(TodoService_ImportTodosServer) error
+	// creates todos from VTODO components of the calendar, UID is the external id so that the
+	// calendar can be imported again. The reminder is taken from the first VALARM, DUE or
+	// DTSTART. REST clients post the raw .ics file to /v1/todo/import.ics.
+	ImportCalendar(context.Context, *ImportCalendarRequest) (*ImportSummary, error)
+	// creates todos from rows of the CSV file, error index 0 is the first row after the header.
+	// REST clients post the raw or multipart file to /v1/todo/import.csv.
+	ImportCSV(context.Context, *ImportCSVRequest) (*ImportSummary, error)
+	// creates todos from lines of the todo.txt file as written by ExportTodoTxt, +project and
+	// @context tags are labels. Error index 0 is the first line. REST clients post the raw or
+	// multipart file to /v1/todo/import.txt.
+	ImportTodoTxt(context.Context, *ImportTodoTxtRequest) (*ImportSummary, error)
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// restores content, labels and list of the todo to the revision, todo in trash must be
+	// undeleted first. Todo whose list was deleted since the revision is left without list.
+	RestoreRevision(context.Context, *RestoreRevisionRequest) (*RestoreRevisionResponse, error)
+	// lists upcoming occurrences of the todo for calendar views
+	ListOccurrences(context.Context, *ListOccurrencesRequest) (*ListOccurrencesResponse, error)
+	CreateList(context.Context, *CreateListRequest) (*CreateListResponse, error)
+	ListLists(context.Context, *ListListsRequest) (*ListListsResponse, error)
+	ReadList(context.Context, *ReadListRequest) (*ReadListResponse, error)
+	UpdateList(context.Context, *UpdateListRequest) (*UpdateListResponse, error)
+	// todos in trash are detached from the deleted list
+	DeleteList(context.Context, *DeleteListRequest) (*DeleteListResponse, error)
+	// comments of todo in trash are hidden until it is restored and removed when it is purged.
+	// The author is the authenticated user, the actor of the API key or of the trusted proxy
+	// header, and comments cannot be added, edited or deleted without it. Only single-tenant
+	// server takes the author from "actor" metadata sent by client, as it does not authenticate.
+	AddComment(context.Context, *AddCommentRequest) (*AddCommentResponse, error)
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	// comment can be edited by its author only
+	EditComment(context.Context, *EditCommentRequest) (*EditCommentResponse, error)
+	// comment can be deleted by its author only
+	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
+	// uploads file in chunks, REST clients post the file as multipart/form-data or as raw body
+	// with file_name query parameter to /v1/todo/{todo_id}/attachments. Attachments of todo in
+	// trash are hidden until it is restored and removed when it is purged.
+	UploadAttachment(TodoService_UploadAttachmentServer) error
+	// downloads file in chunks, the first chunk carries the content type and "file-name-bin"
+	// header metadata carries the file name. REST clients get the raw file from
+	// /v1/todo/{todo_id}/attachments/{id}.
+	DownloadAttachment(*DownloadAttachmentRequest, TodoService_DownloadAttachmentServer) error
+	ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
+	DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error)
+	// finds todos by words of their title and description, ranked by relevance
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+}
+
+// UnimplementedTodoServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedTodoServiceServer struct {
+}
+
+func (*UnimplementedTodoServiceServer) ReadAll(ctx context.Context, req *ReadAllRequest) (*ReadAllResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ReadAll not implemented")
+}
+func (*UnimplementedTodoServiceServer) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (*UnimplementedTodoServiceServer) Update(ctx context.Context, req *UpdateRequest) (*UpdateResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (*UnimplementedTodoServiceServer) ExportCalendar(ctx context.Context, req *ExportCalendarRequest) (*httpbody.HttpBody, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ExportCalendar not implemented")
+}
+func (*UnimplementedTodoServiceServer) ExportCSV(ctx context.Context, req *ExportCSVRequest) (*httpbody.HttpBody, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ExportCSV not implemented")
+}
+func (*UnimplementedTodoServiceServer) ExportTodoTxt(ctx context.Context, req *ExportTodoTxtRequest) (*httpbody.HttpBody, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ExportTodoTxt not implemented")
+}
+func (*UnimplementedTodoServiceServer) Read(ctx context.Context, req *ReadRequest) (*ReadResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Read not implemented")
+}
+func (*UnimplementedTodoServiceServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedTodoServiceServer) Undelete(ctx context.Context, req *UndeleteRequest) (*UndeleteResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Undelete not implemented")
+}
+func (*UnimplementedTodoServiceServer) Complete(ctx context.Context, req *CompleteRequest) (*CompleteResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Complete not implemented")
+}
+func (*UnimplementedTodoServiceServer) Reopen(ctx context.Context, req *ReopenRequest) (*ReopenResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Reopen not implemented")
+}
+func (*UnimplementedTodoServiceServer) ListLabels(ctx context.Context, req *ListLabelsRequest) (*ListLabelsResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ListLabels not implemented")
+}
+func (*UnimplementedTodoServiceServer) RenameLabel(ctx context.Context, req *RenameLabelRequest) (*RenameLabelResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method RenameLabel not implemented")
+}
+func (*UnimplementedTodoServiceServer) BatchCreate(ctx context.Context, req *BatchCreateRequest) (*BatchCreateResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method BatchCreate not implemented")
+}
+func (*UnimplementedTodoServiceServer) BatchUpdate(ctx context.Context, req *BatchUpdateRequest) (*BatchUpdateResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method BatchUpdate not implemented")
+}
+func (*UnimplementedTodoServiceServer) BatchDelete(ctx context.Context, req *BatchDeleteRequest) (*BatchDeleteResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method BatchDelete not implemented")
+}
+func (*UnimplementedTodoServiceServer) Watch(req *WatchRequest, srv TodoService_WatchServer) error {
+	return status1.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (*UnimplementedTodoServiceServer) ExportTodos(req *ExportTodosRequest, srv TodoService_ExportTodosServer) error {
+	return status1.Errorf(codes.Unimplemented, "method ExportTodos not implemented")
+}
+func (*UnimplementedTodoServiceServer) ImportTodos(srv TodoService_ImportTodosServer) error {
+	return status1.Errorf(codes.Unimplemented, "method ImportTodos not implemented")
+}
+func (*UnimplementedTodoServiceServer) ImportCalendar(ctx context.Context, req *ImportCalendarRequest) (*ImportSummary, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ImportCalendar not implemented")
+}
+func (*UnimplementedTodoServiceServer) ImportCSV(ctx context.Context, req *ImportCSVRequest) (*ImportSummary, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ImportCSV not implemented")
+}
+func (*UnimplementedTodoServiceServer) ImportTodoTxt(ctx context.Context, req *ImportTodoTxtRequest) (*ImportSummary, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ImportTodoTxt not implemented")
+}
+func (*UnimplementedTodoServiceServer) GetHistory(ctx context.Context, req *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (*UnimplementedTodoServiceServer) RestoreRevision(ctx context.Context, req *RestoreRevisionRequest) (*RestoreRevisionResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method RestoreRevision not implemented")
+}
+func (*UnimplementedTodoServiceServer) ListOccurrences(ctx context.Context, req *ListOccurrencesRequest) (*ListOccurrencesResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ListOccurrences not implemented")
+}
+func (*UnimplementedTodoServiceServer) CreateList(ctx context.Context, req *CreateListRequest) (*CreateListResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method CreateList not implemented")
+}
+func (*UnimplementedTodoServiceServer) ListLists(ctx context.Context, req *ListListsRequest) (*ListListsResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ListLists not implemented")
+}
+func (*UnimplementedTodoServiceServer) ReadList(ctx context.Context, req *ReadListRequest) (*ReadListResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ReadList not implemented")
+}
+func (*UnimplementedTodoServiceServer) UpdateList(ctx context.Context, req *UpdateListRequest) (*UpdateListResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method UpdateList not implemented")
+}
+func (*UnimplementedTodoServiceServer) DeleteList(ctx context.Context, req *DeleteListRequest) (*DeleteListResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method DeleteList not implemented")
+}
+func (*UnimplementedTodoServiceServer) AddComment(ctx context.Context, req *AddCommentRequest) (*AddCommentResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method AddComment not implemented")
+}
+func (*UnimplementedTodoServiceServer) ListComments(ctx context.Context, req *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ListComments not implemented")
+}
+func (*UnimplementedTodoServiceServer) EditComment(ctx context.Context, req *EditCommentRequest) (*EditCommentResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method EditComment not implemented")
+}
+func (*UnimplementedTodoServiceServer) DeleteComment(ctx context.Context, req *DeleteCommentRequest) (*DeleteCommentResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (*UnimplementedTodoServiceServer) UploadAttachment(srv TodoService_UploadAttachmentServer) error {
+	return status1.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (*UnimplementedTodoServiceServer) DownloadAttachment(req *DownloadAttachmentRequest, srv TodoService_DownloadAttachmentServer) error {
+	return status1.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (*UnimplementedTodoServiceServer) ListAttachments(ctx context.Context, req *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
+}
+func (*UnimplementedTodoServiceServer) DeleteAttachment(ctx context.Context, req *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
+}
+func (*UnimplementedTodoServiceServer) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+
+func RegisterTodoServiceServer(s *grpc.Server, srv TodoServiceServer) {
+	s.RegisterService(&_TodoService_serviceDesc, srv)
+}
+
+func _TodoService_ReadAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ReadAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ReadAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ReadAll(ctx, req.(*ReadAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ExportCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ExportCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ExportCalendar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ExportCalendar(ctx, req.(*ExportCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ExportCSV_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportCSVRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ExportCSV(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ExportCSV",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ExportCSV(ctx, req.(*ExportCSVRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ExportTodoTxt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportTodoTxtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ExportTodoTxt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ExportTodoTxt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ExportTodoTxt(ctx, req.(*ExportTodoTxtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/Read",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).Read(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_Undelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).Undelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/Undelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).Undelete(ctx, req.(*UndeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/Complete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).Complete(ctx, req.(*CompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_Reopen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReopenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).Reopen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/Reopen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).Reopen(ctx, req.(*ReopenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ListLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListLabels(ctx, req.(*ListLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_RenameLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).RenameLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/RenameLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).RenameLabel(ctx, req.(*RenameLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_BatchCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).BatchCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/BatchCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).BatchCreate(ctx, req.(*BatchCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_BatchUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).BatchUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/BatchUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).BatchUpdate(ctx, req.(*BatchUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_BatchDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).BatchDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/BatchDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).BatchDelete(ctx, req.(*BatchDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TodoServiceServer).Watch(m, &todoServiceWatchServer{stream})
+}
+
+type TodoService_WatchServer interface {
+	Send(*TodoEvent) error
+	grpc.ServerStream
+}
+
+type todoServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *todoServiceWatchServer) Send(m *TodoEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TodoService_ExportTodos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTodosRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TodoServiceServer).ExportTodos(m, &todoServiceExportTodosServer{stream})
+}
+
+type TodoService_ExportTodosServer interface {
+	Send(*Todo) error
+	grpc.ServerStream
+}
+
+type todoServiceExportTodosServer struct {
+	grpc.ServerStream
+}
+
+func (x *todoServiceExportTodosServer) Send(m *Todo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TodoService_ImportTodos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TodoServiceServer).ImportTodos(&todoServiceImportTodosServer{stream})
+}
+
+type TodoService_ImportTodosServer interface {
+	SendAndClose(*ImportSummary) error
+	Recv() (*Todo, error)
+	grpc.ServerStream
+}
+
+type todoServiceImportTodosServer struct {
+	grpc.ServerStream
+}
+
+func (x *todoServiceImportTodosServer) SendAndClose(m *ImportSummary) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *todoServiceImportTodosServer) Recv() (*Todo, error) {
+	m := new(Todo)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TodoService_ImportCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ImportCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ImportCalendar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ImportCalendar(ctx, req.(*ImportCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ImportCSV_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportCSVRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ImportCSV(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ImportCSV",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ImportCSV(ctx, req.(*ImportCSVRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ImportTodoTxt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportTodoTxtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ImportTodoTxt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ImportTodoTxt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ImportTodoTxt(ctx, req.(*ImportTodoTxtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/GetHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_RestoreRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).RestoreRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/RestoreRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).RestoreRevision(ctx, req.(*RestoreRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListOccurrences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOccurrencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListOccurrences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ListOccurrences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListOccurrences(ctx, req.(*ListOccurrencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_CreateList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).CreateList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/CreateList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).CreateList(ctx, req.(*CreateListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ListLists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListLists(ctx, req.(*ListListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ReadList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ReadList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ReadList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ReadList(ctx, req.(*ReadListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_UpdateList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).UpdateList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/UpdateList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).UpdateList(ctx, req.(*UpdateListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_DeleteList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).DeleteList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/DeleteList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).DeleteList(ctx, req.(*DeleteListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).AddComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/AddComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).AddComment(ctx, req.(*AddCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ListComments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_EditComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).EditComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/EditComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).EditComment(ctx, req.(*EditCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/DeleteComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (m *ReadAllResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadAllResponse.Merge(m, src)
+
+func _TodoService_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TodoServiceServer).UploadAttachment(&todoServiceUploadAttachmentServer{stream})
 }
-func (m *ReadAllResponse) XXX_Size() int {
-	return xxx_messageInfo_ReadAllResponse.Size(m)
+
+type TodoService_UploadAttachmentServer interface {
+	SendAndClose(*UploadAttachmentResponse) error
+	Recv() (*UploadAttachmentRequest, error)
+	grpc.ServerStream
 }
-func (m *ReadAllResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadAllResponse.DiscardUnknown(m)
+
+type todoServiceUploadAttachmentServer struct {
+	grpc.ServerStream
 }
 
-var xxx_messageInfo_ReadAllResponse proto.InternalMessageInfo
+func (x *todoServiceUploadAttachmentServer) SendAndClose(m *UploadAttachmentResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
 
-func (m *ReadAllResponse) GetApi() string {
-	if m != nil {
-		return m.Api
+func (x *todoServiceUploadAttachmentServer) Recv() (*UploadAttachmentRequest, error) {
+	m := new(UploadAttachmentRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return ""
+	return m, nil
 }
 
-func (m *ReadAllResponse) GetTodos() []*Todo {
-	if m != nil {
-		return m.Todos
+func _TodoService_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadAttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return nil
+	return srv.(TodoServiceServer).DownloadAttachment(m, &todoServiceDownloadAttachmentServer{stream})
 }
 
-func init() {
-	proto.RegisterType((*Todo)(nil), "v1.Todo")
-	proto.RegisterType((*CreateRequest)(nil), "v1.CreateRequest")
-	proto.RegisterType((*CreateResponse)(nil), "v1.CreateResponse")
-	proto.RegisterType((*ReadRequest)(nil), "v1.ReadRequest")
-	proto.RegisterType((*ReadResponse)(nil), "v1.ReadResponse")
-	proto.RegisterType((*UpdateRequest)(nil), "v1.UpdateRequest")
-	proto.RegisterType((*UpdateResponse)(nil), "v1.UpdateResponse")
-	proto.RegisterType((*DeleteRequest)(nil), "v1.DeleteRequest")
-	proto.RegisterType((*DeleteResponse)(nil), "v1.DeleteResponse")
-	proto.RegisterType((*ReadAllRequest)(nil), "v1.ReadAllRequest")
-	proto.RegisterType((*ReadAllResponse)(nil), "v1.ReadAllResponse")
+type TodoService_DownloadAttachmentServer interface {
+	Send(*httpbody.HttpBody) error
+	grpc.ServerStream
 }
 
-func init() { proto.RegisterFile("todo-service.proto", fileDescriptor_80b701c7b1c502fe) }
+type todoServiceDownloadAttachmentServer struct {
+	grpc.ServerStream
+}
 
-var fileDescriptor_80b701c7b1c502fe = []byte{
-	// 678 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xcf, 0x4f, 0x13, 0x4f,
-	0x14, 0xcf, 0x6e, 0x4b, 0x81, 0x57, 0x5a, 0xfa, 0x1d, 0xf8, 0xc6, 0xa6, 0x21, 0xba, 0xd9, 0x83,
-	0x21, 0x8d, 0xdd, 0xa5, 0x95, 0x90, 0x58, 0x8d, 0x80, 0x10, 0xe3, 0xc1, 0xd3, 0x8a, 0x17, 0x6f,
-	0xcb, 0xee, 0x73, 0x3b, 0x64, 0xbb, 0xb3, 0xce, 0x4c, 0x8b, 0x89, 0xe1, 0xe2, 0xc1, 0x83, 0x27,
-	0xa3, 0x37, 0xff, 0x2d, 0xff, 0x05, 0xff, 0x01, 0x0f, 0xde, 0xcd, 0xcc, 0x74, 0x81, 0x05, 0x4a,
-	0x4c, 0xbc, 0xb4, 0x9d, 0x37, 0x9f, 0xf7, 0xf9, 0x31, 0x6f, 0x3a, 0x40, 0x24, 0x8b, 0x59, 0x4f,
-	0x20, 0x9f, 0xd2, 0x08, 0xbd, 0x9c, 0x33, 0xc9, 0x88, 0x3d, 0xed, 0x77, 0xee, 0x25, 0x8c, 0x25,
-	0x29, 0xfa, 0xba, 0x72, 0x3c, 0x79, 0xeb, 0x4b, 0x3a, 0x46, 0x21, 0xc3, 0x71, 0x6e, 0x40, 0x9d,
-	0x8d, 0x19, 0x20, 0xcc, 0xa9, 0x1f, 0x66, 0x19, 0x93, 0xa1, 0xa4, 0x2c, 0x13, 0xb3, 0xdd, 0x07,
-	0xfa, 0x2b, 0xea, 0x25, 0x98, 0xf5, 0xc4, 0x69, 0x98, 0x24, 0xc8, 0x7d, 0x96, 0x6b, 0xc4, 0x75,
-	0xb4, 0xfb, 0xc9, 0x82, 0xea, 0x11, 0x8b, 0x19, 0x69, 0x82, 0x4d, 0xe3, 0xb6, 0xe5, 0x58, 0x9b,
-	0x95, 0xc0, 0xa6, 0x31, 0x59, 0x87, 0x05, 0x49, 0x65, 0x8a, 0x6d, 0xdb, 0xb1, 0x36, 0x97, 0x03,
-	0xb3, 0x20, 0x0e, 0xd4, 0x63, 0x14, 0x11, 0xa7, 0x9a, 0xb0, 0x5d, 0xd1, 0x7b, 0x97, 0x4b, 0x64,
-	0x07, 0x96, 0x38, 0x8e, 0x69, 0x16, 0x23, 0x6f, 0x57, 0x1d, 0x6b, 0xb3, 0x3e, 0xe8, 0x78, 0xc6,
-	0xaf, 0x57, 0x04, 0xf2, 0x8e, 0x8a, 0x40, 0xc1, 0x39, 0xd6, 0xdd, 0x85, 0xc6, 0x01, 0xc7, 0x50,
-	0x62, 0x80, 0xef, 0x26, 0x28, 0x24, 0x69, 0x41, 0x25, 0xcc, 0xa9, 0x76, 0xb4, 0x1c, 0xa8, 0x9f,
-	0x64, 0x03, 0xaa, 0xea, 0xc8, 0xb4, 0xa3, 0xfa, 0x60, 0xc9, 0x9b, 0xf6, 0x3d, 0x65, 0x3d, 0xd0,
-	0x55, 0x77, 0x00, 0xcd, 0x82, 0x40, 0xe4, 0x2c, 0x13, 0x78, 0x03, 0x83, 0x09, 0x69, 0x17, 0x21,
-	0x5d, 0x1f, 0xea, 0x01, 0x86, 0xf1, 0x7c, 0xc9, 0xab, 0x0d, 0x4f, 0x61, 0xc5, 0x34, 0xcc, 0x95,
-	0xb8, 0xdd, 0xe4, 0x2e, 0x34, 0x5e, 0xe7, 0xf1, 0xbf, 0xa5, 0x2c, 0x08, 0xfe, 0x3a, 0x65, 0x1f,
-	0x1a, 0x87, 0x98, 0xe2, 0x6d, 0xa2, 0x57, 0x5b, 0x9e, 0x40, 0xb3, 0x68, 0x99, 0x2b, 0xd3, 0x86,
-	0xc5, 0x58, 0x63, 0x8a, 0xc6, 0x62, 0xe9, 0xba, 0xd0, 0x54, 0xa7, 0xb4, 0x9f, 0xa6, 0x73, 0x15,
-	0xdd, 0x03, 0x58, 0x3d, 0xc7, 0xcc, 0x95, 0xb8, 0x0b, 0x0b, 0x2a, 0xb5, 0x68, 0xdb, 0x4e, 0xa5,
-	0x74, 0x18, 0xa6, 0x3c, 0xf8, 0x52, 0x81, 0xba, 0x5a, 0xbf, 0x32, 0x7f, 0x22, 0xf2, 0x02, 0x16,
-	0x67, 0xa4, 0x84, 0x28, 0x6c, 0xd9, 0x45, 0x67, 0xad, 0x54, 0x33, 0xaa, 0xee, 0xfa, 0xc7, 0x1f,
-	0x3f, 0xbf, 0xd9, 0x4d, 0xb2, 0xe2, 0x4f, 0xfb, 0xbe, 0xa2, 0xf5, 0xc3, 0x34, 0x25, 0x87, 0x50,
-	0x33, 0xb7, 0x89, 0xfc, 0xa7, 0x9a, 0x4a, 0x57, 0xb3, 0x43, 0x2e, 0x97, 0x66, 0x34, 0x6b, 0x9a,
-	0xa6, 0xe1, 0x2e, 0x15, 0x34, 0x43, 0xab, 0x4b, 0x12, 0xa8, 0x99, 0x69, 0x19, 0x96, 0xd2, 0xe8,
-	0x0d, 0x4b, 0x79, 0x98, 0xee, 0x8e, 0x66, 0xd9, 0xea, 0x90, 0x73, 0x33, 0x1f, 0xd4, 0xa7, 0x47,
-	0xe3, 0xb3, 0xa1, 0xd5, 0x7d, 0x73, 0x67, 0x70, 0xf3, 0x06, 0xd9, 0x83, 0xaa, 0xca, 0x45, 0x56,
-	0x8b, 0x84, 0x85, 0x48, 0xeb, 0xa2, 0x30, 0x93, 0xf8, 0x5f, 0x4b, 0xac, 0x92, 0xc6, 0x05, 0x13,
-	0x8d, 0xcf, 0xc8, 0x73, 0xa8, 0x99, 0x89, 0x1b, 0xab, 0xa5, 0x0b, 0x63, 0xac, 0x96, 0x2f, 0x44,
-	0xc1, 0xd3, 0x2d, 0xf3, 0x3c, 0xfb, 0x6d, 0x7d, 0xdd, 0xff, 0x65, 0x91, 0xcf, 0x16, 0xac, 0xa8,
-	0xc9, 0x38, 0xb3, 0xf7, 0xcd, 0x9d, 0xc0, 0xfd, 0x84, 0xf5, 0x12, 0x9e, 0x47, 0xbd, 0x91, 0x94,
-	0x79, 0x8f, 0xa3, 0x90, 0xbd, 0x31, 0x8d, 0x38, 0x9b, 0x21, 0x9c, 0x9c, 0xb3, 0x13, 0x8c, 0x24,
-	0x79, 0xa4, 0xf6, 0xc5, 0xd0, 0xf7, 0x13, 0x2a, 0x47, 0x93, 0x63, 0x2f, 0x62, 0x63, 0xff, 0x25,
-	0x4d, 0xc3, 0x2c, 0x09, 0xfd, 0xdb, 0x29, 0x3a, 0xad, 0xd4, 0xe0, 0xf6, 0x52, 0x3a, 0x45, 0xd5,
-	0x38, 0xa8, 0xf4, 0xbd, 0xad, 0xae, 0x65, 0x0d, 0x5a, 0x61, 0x9e, 0xa7, 0x34, 0xd2, 0x6f, 0x9f,
-	0x7f, 0x22, 0x58, 0x36, 0xbc, 0x56, 0x09, 0x1e, 0x43, 0x65, 0x7b, 0x6b, 0x9b, 0x6c, 0x43, 0x37,
-	0x40, 0x39, 0xe1, 0x19, 0xc6, 0xce, 0xe9, 0x08, 0x33, 0x47, 0x8e, 0xd0, 0xe1, 0x28, 0xd8, 0x84,
-	0x47, 0xe8, 0xc4, 0x0c, 0x85, 0x93, 0x31, 0xe9, 0xe0, 0x7b, 0x2a, 0xa4, 0x47, 0x6a, 0x50, 0xfd,
-	0x6e, 0x5b, 0x8b, 0xc7, 0x35, 0xfd, 0xba, 0x3d, 0xfc, 0x13, 0x00, 0x00, 0xff, 0xff, 0x8c, 0x68,
-	0xca, 0xb0, 0xd6, 0x05, 0x00, 0x00,
+func (x *todoServiceDownloadAttachmentServer) Send(m *httpbody.HttpBody) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func _TodoService_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/ListAttachments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListAttachments(ctx, req.(*ListAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func _TodoService_DeleteAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).DeleteAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/DeleteAttachment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).DeleteAttachment(ctx, req.(*DeleteAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// TodoServiceClient is the client API for TodoService service.
+func _TodoService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TodoService/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TodoService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.TodoService",
+	HandlerType: (*TodoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReadAll",
+			Handler:    _TodoService_ReadAll_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _TodoService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _TodoService_Update_Handler,
+		},
+		{
+			MethodName: "ExportCalendar",
+			Handler:    _TodoService_ExportCalendar_Handler,
+		},
+		{
+			MethodName: "ExportCSV",
+			Handler:    _TodoService_ExportCSV_Handler,
+		},
+		{
+			MethodName: "ExportTodoTxt",
+			Handler:    _TodoService_ExportTodoTxt_Handler,
+		},
+		{
+			MethodName: "Read",
+			Handler:    _TodoService_Read_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _TodoService_Delete_Handler,
+		},
+		{
+			MethodName: "Undelete",
+			Handler:    _TodoService_Undelete_Handler,
+		},
+		{
+			MethodName: "Complete",
+			Handler:    _TodoService_Complete_Handler,
+		},
+		{
+			MethodName: "Reopen",
+			Handler:    _TodoService_Reopen_Handler,
+		},
+		{
+			MethodName: "ListLabels",
+			Handler:    _TodoService_ListLabels_Handler,
+		},
+		{
+			MethodName: "RenameLabel",
+			Handler:    _TodoService_RenameLabel_Handler,
+		},
+		{
+			MethodName: "BatchCreate",
+			Handler:    _TodoService_BatchCreate_Handler,
+		},
+		{
+			MethodName: "BatchUpdate",
+			Handler:    _TodoService_BatchUpdate_Handler,
+		},
+		{
+			MethodName: "BatchDelete",
+			Handler:    _TodoService_BatchDelete_Handler,
+		},
+		{
+			MethodName: "ImportCalendar",
+			Handler:    _TodoService_ImportCalendar_Handler,
+		},
+		{
+			MethodName: "ImportCSV",
+			Handler:    _TodoService_ImportCSV_Handler,
+		},
+		{
+			MethodName: "ImportTodoTxt",
+			Handler:    _TodoService_ImportTodoTxt_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _TodoService_GetHistory_Handler,
+		},
+		{
+			MethodName: "RestoreRevision",
+			Handler:    _TodoService_RestoreRevision_Handler,
+		},
+		{
+			MethodName: "ListOccurrences",
+			Handler:    _TodoService_ListOccurrences_Handler,
+		},
+		{
+			MethodName: "CreateList",
+			Handler:    _TodoService_CreateList_Handler,
+		},
+		{
+			MethodName: "ListLists",
+			Handler:    _TodoService_ListLists_Handler,
+		},
+		{
+			MethodName: "ReadList",
+			Handler:    _TodoService_ReadList_Handler,
+		},
+		{
+			MethodName: "UpdateList",
+			Handler:    _TodoService_UpdateList_Handler,
+		},
+		{
+			MethodName: "DeleteList",
+			Handler:    _TodoService_DeleteList_Handler,
+		},
+		{
+			MethodName: "AddComment",
+			Handler:    _TodoService_AddComment_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _TodoService_ListComments_Handler,
+		},
+		{
+			MethodName: "EditComment",
+			Handler:    _TodoService_EditComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _TodoService_DeleteComment_Handler,
+		},
+		{
+			MethodName: "ListAttachments",
+			Handler:    _TodoService_ListAttachments_Handler,
+		},
+		{
+			MethodName: "DeleteAttachment",
+			Handler:    _TodoService_DeleteAttachment_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _TodoService_Search_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _TodoService_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportTodos",
+			Handler:       _TodoService_ExportTodos_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportTodos",
+			Handler:       _TodoService_ImportTodos_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _TodoService_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _TodoService_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "todo-service.proto",
+}
+
+// WebhookServiceClient is the client API for WebhookService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type TodoServiceClient interface {
-	ReadAll(ctx context.Context, in *ReadAllRequest, opts ...grpc.CallOption) (*ReadAllResponse, error)
-	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
-	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
-	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
-	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+type WebhookServiceClient interface {
+	CreateSubscription(ctx context.Context, in *CreateSubscriptionRequest, opts ...grpc.CallOption) (*CreateSubscriptionResponse, error)
+	ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error)
+	// deletes the subscription together with its pending deliveries and dead letters
+	DeleteSubscription(ctx context.Context, in *DeleteSubscriptionRequest, opts ...grpc.CallOption) (*DeleteSubscriptionResponse, error)
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	// queues the dead letter for delivery again
+	ReplayDeadLetter(ctx context.Context, in *ReplayDeadLetterRequest, opts ...grpc.CallOption) (*ReplayDeadLetterResponse, error)
 }
 
-type todoServiceClient struct {
+type webhookServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewTodoServiceClient(cc *grpc.ClientConn) TodoServiceClient {
-	return &todoServiceClient{cc}
+func NewWebhookServiceClient(cc *grpc.ClientConn) WebhookServiceClient {
+	return &webhookServiceClient{cc}
 }
 
-func (c *todoServiceClient) ReadAll(ctx context.Context, in *ReadAllRequest, opts ...grpc.CallOption) (*ReadAllResponse, error) {
-	out := new(ReadAllResponse)
-	err := c.cc.Invoke(ctx, "/v1.TodoService/ReadAll", in, out, opts...)
+func (c *webhookServiceClient) CreateSubscription(ctx context.Context, in *CreateSubscriptionRequest, opts ...grpc.CallOption) (*CreateSubscriptionResponse, error) {
+	out := new(CreateSubscriptionResponse)
+	err := c.cc.Invoke(ctx, "/v1.WebhookService/CreateSubscription", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *todoServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
-	out := new(CreateResponse)
-	err := c.cc.Invoke(ctx, "/v1.TodoService/Create", in, out, opts...)
+func (c *webhookServiceClient) ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error) {
+	out := new(ListSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, "/v1.WebhookService/ListSubscriptions", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *todoServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
-	out := new(UpdateResponse)
-	err := c.cc.Invoke(ctx, "/v1.TodoService/Update", in, out, opts...)
+func (c *webhookServiceClient) DeleteSubscription(ctx context.Context, in *DeleteSubscriptionRequest, opts ...grpc.CallOption) (*DeleteSubscriptionResponse, error) {
+	out := new(DeleteSubscriptionResponse)
+	err := c.cc.Invoke(ctx, "/v1.WebhookService/DeleteSubscription", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *todoServiceClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
-	out := new(ReadResponse)
-	err := c.cc.Invoke(ctx, "/v1.TodoService/Read", in, out, opts...)
+func (c *webhookServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, "/v1.WebhookService/ListDeadLetters", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *todoServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
-	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, "/v1.TodoService/Delete", in, out, opts...)
+func (c *webhookServiceClient) ReplayDeadLetter(ctx context.Context, in *ReplayDeadLetterRequest, opts ...grpc.CallOption) (*ReplayDeadLetterResponse, error) {
+	out := new(ReplayDeadLetterResponse)
+	err := c.cc.Invoke(ctx, "/v1.WebhookService/ReplayDeadLetter", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// TodoServiceServer is the server API for TodoService service.
-type TodoServiceServer interface {
-	ReadAll(context.Context, *ReadAllRequest) (*ReadAllResponse, error)
-	Create(context.Context, *CreateRequest) (*CreateResponse, error)
-	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
-	Read(context.Context, *ReadRequest) (*ReadResponse, error)
-	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+// WebhookServiceServer is the server API for WebhookService service.
+type WebhookServiceServer interface {
+	CreateSubscription(context.Context, *CreateSubscriptionRequest) (*CreateSubscriptionResponse, error)
+	ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error)
+	// deletes the subscription together with its pending deliveries and dead letters
+	DeleteSubscription(context.Context, *DeleteSubscriptionRequest) (*DeleteSubscriptionResponse, error)
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	// queues the dead letter for delivery again
+	ReplayDeadLetter(context.Context, *ReplayDeadLetterRequest) (*ReplayDeadLetterResponse, error)
 }
 
-// UnimplementedTodoServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedTodoServiceServer struct {
+// UnimplementedWebhookServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWebhookServiceServer struct {
 }
 
-func (*UnimplementedTodoServiceServer) ReadAll(ctx context.Context, req *ReadAllRequest) (*ReadAllResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReadAll not implemented")
+func (*UnimplementedWebhookServiceServer) CreateSubscription(ctx context.Context, req *CreateSubscriptionRequest) (*CreateSubscriptionResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method CreateSubscription not implemented")
 }
-func (*UnimplementedTodoServiceServer) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+func (*UnimplementedWebhookServiceServer) ListSubscriptions(ctx context.Context, req *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ListSubscriptions not implemented")
 }
-func (*UnimplementedTodoServiceServer) Update(ctx context.Context, req *UpdateRequest) (*UpdateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+func (*UnimplementedWebhookServiceServer) DeleteSubscription(ctx context.Context, req *DeleteSubscriptionRequest) (*DeleteSubscriptionResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method DeleteSubscription not implemented")
 }
-func (*UnimplementedTodoServiceServer) Read(ctx context.Context, req *ReadRequest) (*ReadResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Read not implemented")
+func (*UnimplementedWebhookServiceServer) ListDeadLetters(ctx context.Context, req *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
 }
-func (*UnimplementedTodoServiceServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+func (*UnimplementedWebhookServiceServer) ReplayDeadLetter(ctx context.Context, req *ReplayDeadLetterRequest) (*ReplayDeadLetterResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ReplayDeadLetter not implemented")
 }
 
-func RegisterTodoServiceServer(s *grpc.Server, srv TodoServiceServer) {
-	s.RegisterService(&_TodoService_serviceDesc, srv)
+func RegisterWebhookServiceServer(s *grpc.Server, srv WebhookServiceServer) {
+	s.RegisterService(&_WebhookService_serviceDesc, srv)
 }
 
-func _TodoService_ReadAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadAllRequest)
+func _WebhookService_CreateSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSubscriptionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TodoServiceServer).ReadAll(ctx, in)
+		return srv.(WebhookServiceServer).CreateSubscription(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.TodoService/ReadAll",
+		FullMethod: "/v1.WebhookService/CreateSubscription",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TodoServiceServer).ReadAll(ctx, req.(*ReadAllRequest))
+		return srv.(WebhookServiceServer).CreateSubscription(ctx, req.(*CreateSubscriptionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TodoService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRequest)
+func _WebhookService_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubscriptionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TodoServiceServer).Create(ctx, in)
+		return srv.(WebhookServiceServer).ListSubscriptions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.TodoService/Create",
+		FullMethod: "/v1.WebhookService/ListSubscriptions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TodoServiceServer).Create(ctx, req.(*CreateRequest))
+		return srv.(WebhookServiceServer).ListSubscriptions(ctx, req.(*ListSubscriptionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TodoService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRequest)
+func _WebhookService_DeleteSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSubscriptionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TodoServiceServer).Update(ctx, in)
+		return srv.(WebhookServiceServer).DeleteSubscription(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.TodoService/Update",
+		FullMethod: "/v1.WebhookService/DeleteSubscription",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TodoServiceServer).Update(ctx, req.(*UpdateRequest))
+		return srv.(WebhookServiceServer).DeleteSubscription(ctx, req.(*DeleteSubscriptionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TodoService_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadRequest)
+func _WebhookService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TodoServiceServer).Read(ctx, in)
+		return srv.(WebhookServiceServer).ListDeadLetters(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.TodoService/Read",
+		FullMethod: "/v1.WebhookService/ListDeadLetters",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TodoServiceServer).Read(ctx, req.(*ReadRequest))
+		return srv.(WebhookServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TodoService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRequest)
+func _WebhookService_ReplayDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayDeadLetterRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TodoServiceServer).Delete(ctx, in)
+		return srv.(WebhookServiceServer).ReplayDeadLetter(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.TodoService/Delete",
+		FullMethod: "/v1.WebhookService/ReplayDeadLetter",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TodoServiceServer).Delete(ctx, req.(*DeleteRequest))
+		return srv.(WebhookServiceServer).ReplayDeadLetter(ctx, req.(*ReplayDeadLetterRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _TodoService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "v1.TodoService",
-	HandlerType: (*TodoServiceServer)(nil),
+var _WebhookService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "ReadAll",
-			Handler:    _TodoService_ReadAll_Handler,
+			MethodName: "CreateSubscription",
+			Handler:    _WebhookService_CreateSubscription_Handler,
 		},
 		{
-			MethodName: "Create",
-			Handler:    _TodoService_Create_Handler,
+			MethodName: "ListSubscriptions",
+			Handler:    _WebhookService_ListSubscriptions_Handler,
 		},
 		{
-			MethodName: "Update",
-			Handler:    _TodoService_Update_Handler,
+			MethodName: "DeleteSubscription",
+			Handler:    _WebhookService_DeleteSubscription_Handler,
 		},
 		{
-			MethodName: "Read",
-			Handler:    _TodoService_Read_Handler,
+			MethodName: "ListDeadLetters",
+			Handler:    _WebhookService_ListDeadLetters_Handler,
 		},
 		{
-			MethodName: "Delete",
-			Handler:    _TodoService_Delete_Handler,
+			MethodName: "ReplayDeadLetter",
+			Handler:    _WebhookService_ReplayDeadLetter_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
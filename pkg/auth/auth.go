@@ -0,0 +1,147 @@
+// Package auth authenticates requests to the services and sets the tenant
+// they act for.
+//
+// The server is single-tenant unless it is configured with API keys or is
+// told to trust the identity headers set by an authenticating proxy.
+package auth
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/service/v1"
+)
+
+const (
+	// TenantHeader is metadata key the trusted proxy sends the tenant of the
+	// authenticated client in, REST clients send it as Grpc-Metadata-Tenant
+	// header
+	TenantHeader = "tenant"
+
+	// ActorHeader is metadata key the trusted proxy sends the name of the
+	// authenticated user in, or single-tenant clients send their name in.
+	// REST clients send it as Grpc-Metadata-Actor header.
+	ActorHeader = "actor"
+
+	// keyScheme is the authorization scheme API keys are sent with
+	keyScheme = "bearer"
+)
+
+// Identity is the authenticated client
+type Identity struct {
+	// Tenant is the tenant the client acts for, empty for the default tenant
+	Tenant string
+
+	// Actor is name of the user, empty if the client is not a single user.
+	// Changes of the requests without actor are not attributed to anyone.
+	Actor string
+}
+
+// withIdentity returns context of the request authenticated as the identity
+func withIdentity(ctx context.Context, id Identity) context.Context {
+	ctx = v1.WithTenant(ctx, id.Tenant)
+	if len(id.Actor) > 0 {
+		ctx = v1.WithActor(ctx, id.Actor)
+	}
+	return ctx
+}
+
+// LoadKeys reads API keys from the file. Each line is a key followed by the
+// tenant it acts for and the user it acts as, key without tenant acts for the
+// default tenant. Empty lines and lines starting with # are ignored.
+func LoadKeys(path string) (map[string]Identity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys := map[string]Identity{}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) > 3 {
+			return nil, fmt.Errorf("line %d: expected key, tenant and actor", n)
+		}
+		if _, ok := keys[fields[0]]; ok {
+			return nil, fmt.Errorf("line %d: key is repeated", n)
+		}
+		var id Identity
+		if len(fields) > 1 {
+			id.Tenant = fields[1]
+		}
+		if len(fields) > 2 {
+			id.Actor = fields[2]
+		}
+		keys[fields[0]] = id
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("file has no keys")
+	}
+	return keys, nil
+}
+
+// KeyAuth authenticates requests by API key sent as "authorization: Bearer
+// <key>" metadata, REST clients send it as Authorization header
+func KeyAuth(keys map[string]Identity) grpc_auth.AuthFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		key, err := grpc_auth.AuthFromMD(ctx, keyScheme)
+		if err != nil {
+			return nil, err
+		}
+		id, ok := keys[key]
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "API key is invalid")
+		}
+		return withIdentity(ctx, id), nil
+	}
+}
+
+// HeaderAuth trusts the tenant and actor sent in metadata. Use it only behind
+// proxy which authenticates clients and sets the headers, as any client
+// reaching the server directly can act for any tenant.
+func HeaderAuth() grpc_auth.AuthFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		var id Identity
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(TenantHeader); len(values) > 0 {
+				id.Tenant = values[0]
+			}
+			if values := md.Get(ActorHeader); len(values) > 0 {
+				id.Actor = values[0]
+			}
+		}
+		return withIdentity(ctx, id), nil
+	}
+}
+
+// SingleTenant makes every request act for the default tenant, the tenant
+// sent by client is ignored. Clients are not authenticated, so the actor sent
+// by client in metadata is taken as it is.
+func SingleTenant() grpc_auth.AuthFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		var id Identity
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(ActorHeader); len(values) > 0 {
+				id.Actor = values[0]
+			}
+		}
+		return withIdentity(ctx, id), nil
+	}
+}
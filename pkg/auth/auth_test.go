@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/service/v1"
+)
+
+// tenantOf returns tenant the todo service sees for the context
+func tenantOf(t *testing.T, ctx context.Context) string {
+	t.Helper()
+	tenant, ok := v1.TenantFromContext(ctx)
+	if !ok {
+		t.Fatalf("context has no tenant")
+	}
+	return tenant
+}
+
+func TestLoadKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(text string) string {
+		path := filepath.Join(dir, "keys")
+		if err := ioutil.WriteFile(path, []byte(text), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	got, err := LoadKeys(write("# keys\nk1 team-a\n\nk2\nk3 team-a alice\n"))
+	if err != nil {
+		t.Fatalf("LoadKeys() error = %v", err)
+	}
+	want := map[string]Identity{"k1": {Tenant: "team-a"}, "k2": {}, "k3": {Tenant: "team-a", Actor: "alice"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadKeys() = %v, want %v", got, want)
+	}
+
+	for _, text := range []string{"", "k1 team-a\nk1 team-b\n", "k1 team a b\n"} {
+		if _, err := LoadKeys(write(text)); err == nil {
+			t.Errorf("LoadKeys(%q) error = nil, want error", text)
+		}
+	}
+}
+
+func TestKeyAuth(t *testing.T) {
+	authenticate := KeyAuth(map[string]Identity{"k1": {Tenant: "team-a"}, "k3": {Tenant: "team-a", Actor: "alice"}})
+	incoming := func(kv ...string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	}
+
+	ctx, err := authenticate(incoming("authorization", "Bearer k1", TenantHeader, "team-b", ActorHeader, "bob"))
+	if err != nil {
+		t.Fatalf("KeyAuth() error = %v", err)
+	}
+	if tenant := tenantOf(t, ctx); tenant != "team-a" {
+		t.Errorf("KeyAuth() tenant = '%s', want 'team-a'", tenant)
+	}
+	if actor, ok := v1.ActorFromContext(ctx); ok {
+		t.Errorf("KeyAuth() actor = '%s', want none", actor)
+	}
+
+	ctx, err = authenticate(incoming("authorization", "Bearer k3", ActorHeader, "bob"))
+	if err != nil {
+		t.Fatalf("KeyAuth() error = %v", err)
+	}
+	if actor, _ := v1.ActorFromContext(ctx); actor != "alice" {
+		t.Errorf("KeyAuth() actor = '%s', want 'alice'", actor)
+	}
+
+	for _, ctx := range []context.Context{
+		context.Background(),
+		incoming(TenantHeader, "team-a"),
+		incoming("authorization", "Bearer k2"),
+		incoming("authorization", "Basic k1"),
+	} {
+		if _, err := authenticate(ctx); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("KeyAuth() error = %v, wantCode %v", err, codes.Unauthenticated)
+		}
+	}
+}
+
+func TestHeaderAuth(t *testing.T) {
+	ctx, err := HeaderAuth()(metadata.NewIncomingContext(context.Background(), metadata.Pairs(TenantHeader, "team-a", ActorHeader, "alice")))
+	if err != nil {
+		t.Fatalf("HeaderAuth() error = %v", err)
+	}
+	if tenant := tenantOf(t, ctx); tenant != "team-a" {
+		t.Errorf("HeaderAuth() tenant = '%s', want 'team-a'", tenant)
+	}
+	if actor, _ := v1.ActorFromContext(ctx); actor != "alice" {
+		t.Errorf("HeaderAuth() actor = '%s', want 'alice'", actor)
+	}
+}
+
+func TestSingleTenant(t *testing.T) {
+	ctx, err := SingleTenant()(metadata.NewIncomingContext(context.Background(), metadata.Pairs(TenantHeader, "team-a", ActorHeader, "alice")))
+	if err != nil {
+		t.Fatalf("SingleTenant() error = %v", err)
+	}
+	if tenant := tenantOf(t, ctx); tenant != "" {
+		t.Errorf("SingleTenant() tenant = '%s', want default tenant", tenant)
+	}
+	if actor, _ := v1.ActorFromContext(ctx); actor != "alice" {
+		t.Errorf("SingleTenant() actor = '%s', want 'alice'", actor)
+	}
+}
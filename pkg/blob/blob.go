@@ -0,0 +1,116 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotFound is returned when there is no content stored under the key
+var ErrNotFound = errors.New("blob is not found")
+
+// Store keeps contents of attached files, keys are generated by the service
+type Store interface {
+	// Put stores content read from r under the key, it is not visible to
+	// Get until the reader is read to the end
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Get opens content stored under the key, ErrNotFound if there is none
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes content stored under the key, missing key is not an
+	// error
+	Delete(ctx context.Context, key string) error
+}
+
+// FileStore keeps contents as files in the local directory
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates store keeping files in the directory, the directory
+// is created if it does not exist
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %v", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// path returns file name of the key, files are spread over subdirectories
+// named by the first two characters of the key
+func (s *FileStore) path(key string) (string, error) {
+	if len(key) < 3 {
+		return "", fmt.Errorf("blob key '%s' is too short", key)
+	}
+	for _, c := range key {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return "", fmt.Errorf("blob key '%s' has invalid character", key)
+		}
+	}
+	return filepath.Join(s.dir, key[:2], key), nil
+}
+
+// Put writes content to temporary file and renames it when it is complete
+func (s *FileStore) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %v", err)
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+key+"-")
+	if err != nil {
+		return fmt.Errorf("failed to create blob file: %v", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write blob file: %v", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to rename blob file: %v", err)
+	}
+	return nil
+}
+
+// Get opens file of the key
+func (s *FileStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob file: %v", err)
+	}
+	return f, nil
+}
+
+// Delete removes file of the key
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove blob file: %v", err)
+	}
+	return nil
+}
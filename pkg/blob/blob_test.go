@@ -0,0 +1,67 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "blob")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	if err := s.Put(ctx, "abc123", strings.NewReader("content")); err != nil {
+		t.Fatalf("FileStore.Put() error = %v", err)
+	}
+
+	r, err := s.Get(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("FileStore.Get() error = %v", err)
+	}
+	got, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil || string(got) != "content" {
+		t.Errorf("FileStore.Get() = '%s', %v, want 'content'", got, err)
+	}
+
+	// content read with error is not stored
+	failing := ioutil.NopCloser(errReader{})
+	if err := s.Put(ctx, "def456", failing); err == nil {
+		t.Errorf("FileStore.Put() error = nil, want read error")
+	}
+	if _, err := s.Get(ctx, "def456"); err != ErrNotFound {
+		t.Errorf("FileStore.Get() error = %v, want %v", err, ErrNotFound)
+	}
+
+	if err := s.Delete(ctx, "abc123"); err != nil {
+		t.Fatalf("FileStore.Delete() error = %v", err)
+	}
+	if _, err := s.Get(ctx, "abc123"); err != ErrNotFound {
+		t.Errorf("FileStore.Get() error = %v, want %v", err, ErrNotFound)
+	}
+	if err := s.Delete(ctx, "abc123"); err != nil {
+		t.Errorf("FileStore.Delete() of missing key error = %v", err)
+	}
+
+	if err := s.Put(ctx, "../etc", strings.NewReader("content")); err == nil {
+		t.Errorf("FileStore.Put() error = nil, want invalid key error")
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read failed")
+}
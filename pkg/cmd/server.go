@@ -5,16 +5,29 @@ import (
 	"database/sql"
 	"flag"
 	"fmt"
+	"strings"
+	"time"
+
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 
 	// mysql driver
 	_ "github.com/go-sql-driver/mysql"
 
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/auth"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/blob"
 	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/logger"
 	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/protocol/grpc"
 	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/protocol/rest"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/purge"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/reminder"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/search"
 	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/service/v1"
 )
 
+// defaultIdempotencyWindow is how long responses are kept for retries if the
+// window is not set
+const defaultIdempotencyWindow = 24 * time.Hour
+
 // Config is configuration for Server
 type Config struct {
 	// gRPC server start parameters section
@@ -42,6 +55,157 @@ type Config struct {
 
 	// LogTimeFormat is log printing time format for the logger
 	LogTimeFormat string
+
+	// PageTokenSecret is secret to sign pagination tokens, must be same for all replicas
+	PageTokenSecret string
+
+	// Trash parameters section
+	// TrashRetention is how long deleted todos are kept before purging, zero disables purging
+	TrashRetention time.Duration
+	// PurgeInterval is how often the trash is purged
+	PurgeInterval time.Duration
+
+	// Watch parameters section
+	// EventRetention is how long todo change events are kept to resume Watch, zero keeps them forever
+	EventRetention time.Duration
+	// WatchInterval is how often Watch polls for changes made by other replicas
+	WatchInterval time.Duration
+
+	// IdempotencyWindow is how long responses are returned on retries with the same idempotency key
+	IdempotencyWindow time.Duration
+
+	// Reminder parameters section
+	// ReminderInterval is how often due reminders are dispatched, zero disables dispatching
+	ReminderInterval time.Duration
+	// ReminderWebhookURL is URL reminders are posted to, they are logged if it is empty
+	ReminderWebhookURL string
+
+	// WebhookInterval is how often todo events are posted to webhook subscriptions, zero disables posting
+	WebhookInterval time.Duration
+
+	// Attachment parameters section
+	// AttachmentDir is directory attached files are kept in, attachments are disabled if it is empty
+	AttachmentDir string
+	// AttachmentMaxSize is the maximum size of attached file in bytes
+	AttachmentMaxSize int64
+	// AttachmentTypes is comma separated list of content types accepted for attachments
+	AttachmentTypes string
+
+	// SearchIndex is the backend todos are searched by, "mysql" or "memory"
+	SearchIndex string
+
+	// Authentication parameters section
+	// APIKeysFile is file of API keys with the tenants they act for
+	APIKeysFile string
+	// TrustIdentityHeaders makes the server take the tenant from metadata set by authenticating proxy
+	TrustIdentityHeaders bool
+}
+
+// openBlobStore opens store of the attached files, nil if attachments are
+// disabled
+func openBlobStore(cfg Config) (blob.Store, error) {
+	if len(cfg.AttachmentDir) == 0 {
+		logger.Log.Warn("attachment directory is not set - attachments are disabled")
+		return nil, nil
+	}
+	return blob.NewFileStore(cfg.AttachmentDir)
+}
+
+// attachmentTypes splits comma separated list of content types
+func attachmentTypes(cfg Config) []string {
+	var list []string
+	for _, t := range strings.Split(cfg.AttachmentTypes, ",") {
+		if t = strings.TrimSpace(t); len(t) > 0 {
+			list = append(list, t)
+		}
+	}
+	return list
+}
+
+// idempotencyWindow returns how long responses are kept for retries, the
+// service returns them and the purger removes them after the same window
+func idempotencyWindow(cfg Config) time.Duration {
+	if cfg.IdempotencyWindow <= 0 {
+		return defaultIdempotencyWindow
+	}
+	return cfg.IdempotencyWindow
+}
+
+// openSearchIndex returns the index todos are searched by
+func openSearchIndex(db *sql.DB, cfg Config) (search.Index, error) {
+	switch cfg.SearchIndex {
+	case "", "mysql":
+		return search.NewMySQLIndex(db), nil
+	case "memory":
+		return search.NewMemoryIndex(), nil
+	}
+	return nil, fmt.Errorf("unknown search index '%s'", cfg.SearchIndex)
+}
+
+// authFunc returns authentication of the requests, the server is
+// single-tenant if neither API keys nor trusted identity headers are set
+func authFunc(cfg Config) (grpc_auth.AuthFunc, error) {
+	switch {
+	case len(cfg.APIKeysFile) > 0 && cfg.TrustIdentityHeaders:
+		return nil, fmt.Errorf("API keys and trusted identity headers must not be used together")
+	case len(cfg.APIKeysFile) > 0:
+		keys, err := auth.LoadKeys(cfg.APIKeysFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load API keys: %v", err)
+		}
+		return auth.KeyAuth(keys), nil
+	case cfg.TrustIdentityHeaders:
+		logger.Log.Warn("identity headers are trusted - the server must be reachable through authenticating proxy only")
+		return auth.HeaderAuth(), nil
+	}
+	logger.Log.Warn("authentication is not set - the server is single-tenant")
+	return auth.SingleTenant(), nil
+}
+
+// startPurger runs background purging of the trash, old todo events and
+// expired idempotency keys
+func startPurger(ctx context.Context, db *sql.DB, blobs blob.Store, cfg Config) {
+	if cfg.TrashRetention <= 0 {
+		logger.Log.Warn("trash retention is not set - deleted todos are never purged")
+	}
+	if cfg.EventRetention <= 0 {
+		logger.Log.Warn("event retention is not set - todo events are never purged")
+	}
+
+	opts := []purge.Option{
+		purge.WithEventRetention(cfg.EventRetention),
+		purge.WithIdempotencyRetention(idempotencyWindow(cfg)),
+	}
+	if blobs != nil {
+		opts = append(opts, purge.WithBlobStore(blobs))
+	}
+	go purge.New(db, cfg.TrashRetention, cfg.PurgeInterval, opts...).Run(ctx)
+}
+
+// startDispatcher runs background dispatching of due reminders
+func startDispatcher(ctx context.Context, db *sql.DB, cfg Config) {
+	if cfg.ReminderInterval <= 0 {
+		logger.Log.Warn("reminder interval is not set - reminders are never dispatched")
+		return
+	}
+
+	var notifier reminder.Notifier = reminder.LogNotifier{}
+	if len(cfg.ReminderWebhookURL) > 0 {
+		notifier = reminder.NewWebhookNotifier(cfg.ReminderWebhookURL, 10*time.Second)
+	}
+
+	go reminder.New(db, notifier, cfg.ReminderInterval).Run(ctx)
+}
+
+// startWebhookDeliverer runs background posting of todo events to webhook
+// subscriptions
+func startWebhookDeliverer(ctx context.Context, db *sql.DB, cfg Config) {
+	if cfg.WebhookInterval <= 0 {
+		logger.Log.Warn("webhook interval is not set - todo events are never posted to webhooks")
+		return
+	}
+
+	go v1.NewWebhookDeliverer(db, cfg.WebhookInterval).Run(ctx)
 }
 
 // RunServer runs gRPC server and HTTP gateway
@@ -56,6 +220,21 @@ func RunServer() error {
 	flag.StringVar(&cfg.DatastoreDBUser, "db-user", "", "Database user")
 	flag.StringVar(&cfg.DatastoreDBPassword, "db-password", "", "Database password")
 	flag.StringVar(&cfg.DatastoreDBSchema, "db-schema", "", "Database schema")
+	flag.StringVar(&cfg.PageTokenSecret, "page-token-secret", "", "Secret to sign pagination tokens")
+	flag.DurationVar(&cfg.TrashRetention, "trash-retention", 30*24*time.Hour, "How long deleted todos are kept before purging, 0 to keep forever")
+	flag.DurationVar(&cfg.PurgeInterval, "purge-interval", time.Hour, "How often deleted todos are purged")
+	flag.DurationVar(&cfg.EventRetention, "event-retention", 24*time.Hour, "How long todo events are kept to resume watching, 0 to keep forever")
+	flag.DurationVar(&cfg.WatchInterval, "watch-interval", time.Second, "How often watchers poll for changes made by other replicas")
+	flag.DurationVar(&cfg.IdempotencyWindow, "idempotency-window", defaultIdempotencyWindow, "How long responses are returned on retries with the same idempotency key, 0 for the default of 24h")
+	flag.DurationVar(&cfg.ReminderInterval, "reminder-interval", 10*time.Second, "How often due reminders are dispatched, 0 to disable")
+	flag.StringVar(&cfg.ReminderWebhookURL, "reminder-webhook-url", "", "URL due reminders are posted to, they are logged if not set")
+	flag.DurationVar(&cfg.WebhookInterval, "webhook-interval", 5*time.Second, "How often todo events are posted to webhook subscriptions, 0 to disable")
+	flag.StringVar(&cfg.AttachmentDir, "attachment-dir", "", "Directory attached files are kept in, attachments are disabled if not set")
+	flag.Int64Var(&cfg.AttachmentMaxSize, "attachment-max-size", 10<<20, "Maximum size of attached file in bytes")
+	flag.StringVar(&cfg.AttachmentTypes, "attachment-types", "", "Comma separated content types accepted for attachments, images, PDF and plain text if not set")
+	flag.StringVar(&cfg.SearchIndex, "search-index", "mysql", "Backend todos are searched by, mysql for the FULLTEXT index or memory for in-process index")
+	flag.StringVar(&cfg.APIKeysFile, "api-keys-file", "", "File of API keys, each line is a key with the tenant it acts for and the user it acts as")
+	flag.BoolVar(&cfg.TrustIdentityHeaders, "trust-identity-headers", false, "Take the tenant and actor from headers set by authenticating proxy, the server is single-tenant if neither this nor API keys are set")
 	flag.IntVar(&cfg.LogLevel, "log-level", 0, "Global log level")
 	flag.StringVar(&cfg.LogTimeFormat, "log-time-format", "",
 		"Print time format for logger e.g. 2006-01-02T15:04:05Z07:00")
@@ -72,9 +251,9 @@ func RunServer() error {
 	if err := logger.Init(cfg.LogLevel, cfg.LogTimeFormat); err != nil {
 		return fmt.Errorf("faild to initialize the logger: %v", err)
 	}
-	// add MySQL driver specific parameter to parse date/time
-	// Drop it for another database
-	param := "parseTime=true"
+	// add MySQL driver specific parameter to parse date/time, DATETIME
+	// values are UTC. Drop it for another database
+	param := "parseTime=true&loc=UTC"
 
 	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?%s",
 		cfg.DatastoreDBUser,
@@ -88,13 +267,43 @@ func RunServer() error {
 	}
 	defer db.Close()
 
-	v1API := v1.NewTodoServiceServer(db)
+	if len(cfg.PageTokenSecret) == 0 {
+		logger.Log.Warn("page token secret is not provided - use random secret, page tokens will not work across replicas")
+	}
+
+	blobs, err := openBlobStore(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open attachment store: %v", err)
+	}
+
+	index, err := openSearchIndex(db, cfg)
+	if err != nil {
+		return err
+	}
+
+	authenticate, err := authFunc(cfg)
+	if err != nil {
+		return err
+	}
+
+	startPurger(ctx, db, blobs, cfg)
+	startDispatcher(ctx, db, cfg)
+	startWebhookDeliverer(ctx, db, cfg)
+
+	v1API := v1.NewTodoServiceServer(db,
+		v1.WithPageTokenKey([]byte(cfg.PageTokenSecret)),
+		v1.WithWatchInterval(cfg.WatchInterval),
+		v1.WithIdempotencyWindow(idempotencyWindow(cfg)),
+		v1.WithBlobStore(blobs),
+		v1.WithAttachmentLimits(cfg.AttachmentMaxSize, attachmentTypes(cfg)),
+		v1.WithSearchIndex(index))
+	webhookAPI := v1.NewWebhookServiceServer(db)
 
 	go func() {
 		_ = rest.RunServer(ctx, "localhost", cfg.GRPCPort, cfg.HTTPPort)
 	}()
 
-	return grpc.RunServer(ctx, v1API, cfg.GRPCPort)
+	return grpc.RunServer(ctx, v1API, webhookAPI, authenticate, cfg.GRPCPort)
 }
 
 // RunGRPCServer will start a GRPC server with the given parameters
@@ -108,6 +317,21 @@ func RunGRPCServer() error {
 	flag.StringVar(&cfg.DatastoreDBUser, "db-user", "", "Database user")
 	flag.StringVar(&cfg.DatastoreDBPassword, "db-password", "", "Database password")
 	flag.StringVar(&cfg.DatastoreDBSchema, "db-schema", "", "Database schema")
+	flag.StringVar(&cfg.PageTokenSecret, "page-token-secret", "", "Secret to sign pagination tokens")
+	flag.DurationVar(&cfg.TrashRetention, "trash-retention", 30*24*time.Hour, "How long deleted todos are kept before purging, 0 to keep forever")
+	flag.DurationVar(&cfg.PurgeInterval, "purge-interval", time.Hour, "How often deleted todos are purged")
+	flag.DurationVar(&cfg.EventRetention, "event-retention", 24*time.Hour, "How long todo events are kept to resume watching, 0 to keep forever")
+	flag.DurationVar(&cfg.WatchInterval, "watch-interval", time.Second, "How often watchers poll for changes made by other replicas")
+	flag.DurationVar(&cfg.IdempotencyWindow, "idempotency-window", defaultIdempotencyWindow, "How long responses are returned on retries with the same idempotency key, 0 for the default of 24h")
+	flag.DurationVar(&cfg.ReminderInterval, "reminder-interval", 10*time.Second, "How often due reminders are dispatched, 0 to disable")
+	flag.StringVar(&cfg.ReminderWebhookURL, "reminder-webhook-url", "", "URL due reminders are posted to, they are logged if not set")
+	flag.DurationVar(&cfg.WebhookInterval, "webhook-interval", 5*time.Second, "How often todo events are posted to webhook subscriptions, 0 to disable")
+	flag.StringVar(&cfg.AttachmentDir, "attachment-dir", "", "Directory attached files are kept in, attachments are disabled if not set")
+	flag.Int64Var(&cfg.AttachmentMaxSize, "attachment-max-size", 10<<20, "Maximum size of attached file in bytes")
+	flag.StringVar(&cfg.AttachmentTypes, "attachment-types", "", "Comma separated content types accepted for attachments, images, PDF and plain text if not set")
+	flag.StringVar(&cfg.SearchIndex, "search-index", "mysql", "Backend todos are searched by, mysql for the FULLTEXT index or memory for in-process index")
+	flag.StringVar(&cfg.APIKeysFile, "api-keys-file", "", "File of API keys, each line is a key with the tenant it acts for and the user it acts as")
+	flag.BoolVar(&cfg.TrustIdentityHeaders, "trust-identity-headers", false, "Take the tenant and actor from headers set by authenticating proxy, the server is single-tenant if neither this nor API keys are set")
 	flag.IntVar(&cfg.LogLevel, "log-level", 0, "Global log level")
 	flag.StringVar(&cfg.LogTimeFormat, "log-time-format", "2006-01-02T15:04:05.999999999Z07:00",
 		"Print time format for logger e.g. 2006-01-02T15:04:05Z07:00")
@@ -121,9 +345,9 @@ func RunGRPCServer() error {
 		return fmt.Errorf("faild to initialize the logger: %v", err)
 	}
 
-	// add MySQL driver specific parameter to parse date/time
-	// Drop it for another database
-	param := "parseTime=true"
+	// add MySQL driver specific parameter to parse date/time, DATETIME
+	// values are UTC. Drop it for another database
+	param := "parseTime=true&loc=UTC"
 
 	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?%s",
 		cfg.DatastoreDBUser,
@@ -137,9 +361,39 @@ func RunGRPCServer() error {
 	}
 	defer db.Close()
 
-	v1API := v1.NewTodoServiceServer(db)
+	if len(cfg.PageTokenSecret) == 0 {
+		logger.Log.Warn("page token secret is not provided - use random secret, page tokens will not work across replicas")
+	}
+
+	blobs, err := openBlobStore(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open attachment store: %v", err)
+	}
+
+	index, err := openSearchIndex(db, cfg)
+	if err != nil {
+		return err
+	}
+
+	authenticate, err := authFunc(cfg)
+	if err != nil {
+		return err
+	}
+
+	startPurger(ctx, db, blobs, cfg)
+	startDispatcher(ctx, db, cfg)
+	startWebhookDeliverer(ctx, db, cfg)
+
+	v1API := v1.NewTodoServiceServer(db,
+		v1.WithPageTokenKey([]byte(cfg.PageTokenSecret)),
+		v1.WithWatchInterval(cfg.WatchInterval),
+		v1.WithIdempotencyWindow(idempotencyWindow(cfg)),
+		v1.WithBlobStore(blobs),
+		v1.WithAttachmentLimits(cfg.AttachmentMaxSize, attachmentTypes(cfg)),
+		v1.WithSearchIndex(index))
+	webhookAPI := v1.NewWebhookServiceServer(db)
 
-	return grpc.RunServer(ctx, v1API, cfg.GRPCPort)
+	return grpc.RunServer(ctx, v1API, webhookAPI, authenticate, cfg.GRPCPort)
 }
 
 // RunHTTPServer will start a server to serve HTTP rest service
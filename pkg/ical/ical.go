@@ -0,0 +1,449 @@
+// Package ical reads and writes iCalendar objects defined by RFC 5545.
+//
+// An object is a tree of components holding properties. Property values are
+// kept as they appear in the content line, TEXT values are escaped by
+// EscapeText and unescaped by Property.Text.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxLineLength is the length in octets content lines are folded at
+	maxLineLength = 75
+
+	// maxLogicalLength is the maximum length of unfolded content line
+	// accepted by Decode
+	maxLogicalLength = 1 << 20
+
+	dateTimeFormat = "20060102T150405"
+	dateFormat     = "20060102"
+)
+
+// Property is a content line of the component
+type Property struct {
+	// Name is the property name in upper case
+	Name string
+
+	// Params are property parameters by name in upper case, quotes are
+	// removed from the values
+	Params map[string]string
+
+	// Value is the property value as it appears in the content line
+	Value string
+}
+
+// Param returns value of the parameter, empty if it is not set
+func (p *Property) Param(name string) string {
+	return p.Params[name]
+}
+
+// Text returns the TEXT value unescaped
+func (p *Property) Text() string {
+	return unescapeText(p.Value)
+}
+
+// TextList returns the list of TEXT values separated by commas unescaped,
+// as in CATEGORIES property
+func (p *Property) TextList() []string {
+	var list []string
+	start := 0
+	for i := 0; i < len(p.Value); i++ {
+		switch p.Value[i] {
+		case '\\':
+			i++
+		case ',':
+			list = append(list, unescapeText(p.Value[start:i]))
+			start = i + 1
+		}
+	}
+	return append(list, unescapeText(p.Value[start:]))
+}
+
+// TimeZone returns the TZID parameter of the property without the leading
+// / marking globally unique time zone ID, empty if it is not set
+func (p *Property) TimeZone() string {
+	return strings.TrimPrefix(p.Param("TZID"), "/")
+}
+
+// Time parses DATE-TIME or DATE value of the property. Times with TZID
+// parameter are in the named IANA time zone, UTC times end with Z, floating
+// times and dates are taken as UTC.
+func (p *Property) Time() (time.Time, error) {
+	if p.Param("VALUE") == "DATE" || len(p.Value) == len(dateFormat) {
+		return time.Parse(dateFormat, p.Value)
+	}
+
+	if strings.HasSuffix(p.Value, "Z") {
+		return time.Parse(dateTimeFormat, strings.TrimSuffix(p.Value, "Z"))
+	}
+
+	loc := time.UTC
+	if tzid := p.TimeZone(); len(tzid) > 0 {
+		var err error
+		if loc, err = time.LoadLocation(tzid); err != nil {
+			return time.Time{}, fmt.Errorf("unknown time zone '%s'", tzid)
+		}
+	}
+	return time.ParseInLocation(dateTimeFormat, p.Value, loc)
+}
+
+// Component is a calendar component such as VCALENDAR, VTODO or VALARM
+type Component struct {
+	// Name is the component name in upper case
+	Name string
+
+	Properties []*Property
+
+	// Components are nested components
+	Components []*Component
+}
+
+// NewComponent creates empty component
+func NewComponent(name string) *Component {
+	return &Component{Name: name}
+}
+
+// Add appends property with the value, params are pairs of parameter names
+// and values
+func (c *Component) Add(name, value string, params ...string) {
+	p := &Property{Name: name, Value: value}
+	if len(params) > 0 {
+		p.Params = make(map[string]string, len(params)/2)
+		for i := 0; i+1 < len(params); i += 2 {
+			p.Params[params[i]] = params[i+1]
+		}
+	}
+	c.Properties = append(c.Properties, p)
+}
+
+// AddText appends property with the TEXT value escaped
+func (c *Component) AddText(name, text string) {
+	c.Add(name, EscapeText(text))
+}
+
+// Get returns the first property with the name, nil if there is none
+func (c *Component) Get(name string) *Property {
+	for _, p := range c.Properties {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// All returns all properties with the name
+func (c *Component) All(name string) []*Property {
+	var list []*Property
+	for _, p := range c.Properties {
+		if p.Name == name {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// Children returns nested components with the name
+func (c *Component) Children(name string) []*Component {
+	var list []*Component
+	for _, sub := range c.Components {
+		if sub.Name == name {
+			list = append(list, sub)
+		}
+	}
+	return list
+}
+
+// EscapeText escapes backslashes, semicolons, commas and line breaks of the
+// TEXT value
+func EscapeText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch r {
+		case '\\', ';', ',':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// unescapeText reverts EscapeText
+func unescapeText(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '\\' && i+1 < len(value) {
+			i++
+			c = value[i]
+			if c == 'n' || c == 'N' {
+				c = '\n'
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// FormatTime formats the time as DATE-TIME value in UTC
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat) + "Z"
+}
+
+// FormatLocalTime formats the time as DATE-TIME value in its location, the
+// property needs TZID parameter naming the location
+func FormatLocalTime(t time.Time) string {
+	return t.Format(dateTimeFormat)
+}
+
+// ParseDuration parses DURATION value such as -PT15M or P1DT12H
+func ParseDuration(value string) (time.Duration, error) {
+	s := value
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+		s = s[1:]
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+	if !strings.HasPrefix(s, "P") || len(s) == 1 {
+		return 0, fmt.Errorf("invalid duration '%s'", value)
+	}
+	s = s[1:]
+
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	var d time.Duration
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+			s = s[1:]
+			continue
+		}
+		i := 0
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(s) || units[s[i]] == 0 {
+			return 0, fmt.Errorf("invalid duration '%s'", value)
+		}
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s'", value)
+		}
+		d += time.Duration(n) * units[s[i]]
+		s = s[i+1:]
+	}
+	return sign * d, nil
+}
+
+// Encode writes the component with nested components as content lines
+// folded at 75 octets
+func Encode(w io.Writer, c *Component) error {
+	bw := bufio.NewWriter(w)
+	encode(bw, c)
+	return bw.Flush()
+}
+
+func encode(w *bufio.Writer, c *Component) {
+	writeLine(w, "BEGIN:"+c.Name)
+	for _, p := range c.Properties {
+		var b strings.Builder
+		b.WriteString(p.Name)
+		for _, name := range sortedKeys(p.Params) {
+			b.WriteString(";" + name + "=" + quoteParam(p.Params[name]))
+		}
+		b.WriteString(":" + p.Value)
+		writeLine(w, b.String())
+	}
+	for _, sub := range c.Components {
+		encode(w, sub)
+	}
+	writeLine(w, "END:"+c.Name)
+}
+
+// writeLine writes the content line folded at maxLineLength octets, not in
+// the middle of a character
+func writeLine(w *bufio.Writer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		n := limit
+		for n > 0 && !utf8.RuneStart(line[n]) {
+			n--
+		}
+		_, _ = w.WriteString(line[:n])
+		_, _ = w.WriteString("\r\n ")
+		line = line[n:]
+		// the leading space of the continuation line counts
+		limit = maxLineLength - 1
+	}
+	_, _ = w.WriteString(line)
+	_, _ = w.WriteString("\r\n")
+}
+
+// quoteParam quotes parameter value having characters which are not
+// allowed in unquoted values, double quotes can not be escaped and are
+// dropped
+func quoteParam(value string) string {
+	value = strings.Replace(value, `"`, "", -1)
+	if strings.ContainsAny(value, ":;,") {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// sortedKeys returns keys of the map in order, so that output is stable
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Decode reads the first component of the iCalendar object, usually
+// VCALENDAR. Folded lines are joined and lines may end with CRLF or LF.
+func Decode(r io.Reader) (*Component, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 4096), maxLogicalLength)
+
+	var root *Component
+	var stack []*Component
+	var logical string
+	start, n := 0, 0
+
+	// flush parses the logical line read so far
+	flush := func() error {
+		if len(logical) == 0 {
+			return nil
+		}
+		line := logical
+		logical = ""
+
+		p, err := parseLine(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", start, err)
+		}
+
+		switch p.Name {
+		case "BEGIN":
+			if root != nil && len(stack) == 0 {
+				return fmt.Errorf("line %d: content after the end of %s", start, root.Name)
+			}
+			c := NewComponent(strings.ToUpper(p.Value))
+			if len(stack) == 0 {
+				root = c
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, c)
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(p.Value) {
+				return fmt.Errorf("line %d: unexpected END:%s", start, p.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return fmt.Errorf("line %d: property %s is out of component", start, p.Name)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, p)
+		}
+		return nil
+	}
+
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			if len(logical)+len(line) > maxLogicalLength {
+				return nil, fmt.Errorf("line %d: content line is too long", start)
+			}
+			logical += line[1:]
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		logical, start = line, n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if root == nil {
+		return nil, errors.New("no component found")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("END:%s is missing", stack[len(stack)-1].Name)
+	}
+	return root, nil
+}
+
+// parseLine parses the unfolded content line
+func parseLine(line string) (*Property, error) {
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return nil, errors.New("property name is missing")
+	}
+	p := &Property{Name: strings.ToUpper(line[:i])}
+	rest := line[i:]
+
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return nil, fmt.Errorf("parameter of %s is invalid", p.Name)
+		}
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		// values may be quoted to have colons, semicolons and commas
+		var value strings.Builder
+		for len(rest) > 0 && rest[0] != ';' && rest[0] != ':' {
+			if rest[0] == '"' {
+				end := strings.IndexByte(rest[1:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("parameter %s of %s has unterminated quote", name, p.Name)
+				}
+				value.WriteString(rest[1 : end+1])
+				rest = rest[end+2:]
+				continue
+			}
+			value.WriteByte(rest[0])
+			rest = rest[1:]
+		}
+
+		if p.Params == nil {
+			p.Params = map[string]string{}
+		}
+		p.Params[name] = value.String()
+	}
+
+	if !strings.HasPrefix(rest, ":") {
+		return nil, fmt.Errorf("value of %s is missing", p.Name)
+	}
+	p.Value = rest[1:]
+	return p, nil
+}
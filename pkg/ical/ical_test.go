@@ -0,0 +1,128 @@
+package ical
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	cal := NewComponent("VCALENDAR")
+	cal.Add("VERSION", "2.0")
+	todo := NewComponent("VTODO")
+	todo.AddText("SUMMARY", "Buy milk, bread; eggs")
+	todo.AddText("DESCRIPTION", strings.Repeat("Grocery list ", 8)+"\nsee fridge \\ door")
+	todo.Add("CATEGORIES", EscapeText("home")+","+EscapeText("a,b"))
+	todo.Add("DTSTART", FormatLocalTime(time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)), "TZID", "/Europe/Berlin")
+	cal.Components = append(cal.Components, todo)
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > maxLineLength {
+			t.Errorf("Encode() line '%s' is longer than %d octets", line, maxLineLength)
+		}
+	}
+
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(got.Children("VTODO")) != 1 {
+		t.Fatalf("Decode() = %v, want one VTODO", got)
+	}
+	td := got.Children("VTODO")[0]
+	if s := td.Get("SUMMARY").Text(); s != "Buy milk, bread; eggs" {
+		t.Errorf("SUMMARY = '%s'", s)
+	}
+	if s := td.Get("DESCRIPTION").Text(); s != strings.Repeat("Grocery list ", 8)+"\nsee fridge \\ door" {
+		t.Errorf("DESCRIPTION = '%s'", s)
+	}
+	if list := td.Get("CATEGORIES").TextList(); !reflect.DeepEqual(list, []string{"home", "a,b"}) {
+		t.Errorf("CATEGORIES = %v", list)
+	}
+	if tz := td.Get("DTSTART").TimeZone(); tz != "Europe/Berlin" {
+		t.Errorf("DTSTART time zone = '%s', want 'Europe/Berlin'", tz)
+	}
+	start, err := td.Get("DTSTART").Time()
+	if err != nil {
+		t.Fatalf("DTSTART error = %v", err)
+	}
+	if want := time.Date(2026, 10, 18, 7, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("DTSTART = %v, want %v", start, want)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{
+			name: "Folded with LF and quoted parameter",
+			text: "BEGIN:VCALENDAR\nBEGIN:VTODO\nSUMMARY;ALTREP=\"cid:a;b\":Long\n\t summary\nEND:VTODO\nEND:VCALENDAR\n",
+		},
+		{
+			name:    "Unbalanced",
+			text:    "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VCALENDAR\r\n",
+			wantErr: true,
+		},
+		{
+			name:    "Missing END",
+			text:    "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+			wantErr: true,
+		},
+		{
+			name:    "No value",
+			text:    "BEGIN:VCALENDAR\r\nVERSION\r\nEND:VCALENDAR\r\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(strings.NewReader(tt.text))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			p := got.Children("VTODO")[0].Get("SUMMARY")
+			if p.Text() != "Long summary" || p.Param("ALTREP") != "cid:a;b" {
+				t.Errorf("Decode() SUMMARY = %v", p)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "PT0S", want: 0},
+		{value: "-PT15M", want: -15 * time.Minute},
+		{value: "P1DT12H", want: 36 * time.Hour},
+		{value: "+P2W", want: 14 * 24 * time.Hour},
+		{value: "P", wantErr: true},
+		{value: "PT5", wantErr: true},
+		{value: "15M", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseDuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	"github.com/grpc-ecosystem/go-grpc-middleware/tags"
 	"go.uber.org/zap"
@@ -18,8 +19,9 @@ func codeToLevel(code codes.Code) zapcore.Level {
 	return grpc_zap.DefaultCodeToLevel(code)
 }
 
-// AddLogging return grpc.Server config option that turn on logging
-func AddLogging(logger *zap.Logger, opts []grpc.ServerOption) []grpc.ServerOption{
+// AddInterceptors return grpc.Server config option that turn on logging and
+// authentication, requests rejected by authentication are logged too
+func AddInterceptors(logger *zap.Logger, authFunc grpc_auth.AuthFunc, opts []grpc.ServerOption) []grpc.ServerOption{
 	o := []grpc_zap.Option{
 		grpc_zap.WithLevels(codeToLevel),
 	}
@@ -30,12 +32,14 @@ func AddLogging(logger *zap.Logger, opts []grpc.ServerOption) []grpc.ServerOptio
 	opts = append(opts, grpc_middleware.WithUnaryServerChain(
 		grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
 		grpc_zap.UnaryServerInterceptor(logger, o...),
+		grpc_auth.UnaryServerInterceptor(authFunc),
 	))
 
-	// Add stream interceptor (added as an example here)
+	// Add stream interceptor
 	opts = append(opts, grpc_middleware.WithStreamServerChain(
 		grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
 		grpc_zap.StreamServerInterceptor(logger, o...),
+		grpc_auth.StreamServerInterceptor(authFunc),
 	))
 
 	return opts
@@ -9,22 +9,25 @@ import (
 	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
 	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/logger"
 	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/protocol/grpc/middleware"
+	"github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"google.golang.org/grpc"
 )
 
-// RunServer runs gRPC service to publish Todo service
-func RunServer(ctx context.Context, v1API v1.TodoServiceServer, port string) error {
+// RunServer runs gRPC service to publish Todo and Webhook services, authFunc
+// authenticates every request
+func RunServer(ctx context.Context, v1API v1.TodoServiceServer, webhookAPI v1.WebhookServiceServer, authFunc grpc_auth.AuthFunc, port string) error {
 	listen, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return err
 	}
 
 	opts := []grpc.ServerOption{}
-	opts = middleware.AddLogging(logger.Log, opts)
+	opts = middleware.AddInterceptors(logger.Log, authFunc, opts)
 
 	// register service
 	server := grpc.NewServer(opts...)
 	v1.RegisterTodoServiceServer(server, v1API)
+	v1.RegisterWebhookServiceServer(server, webhookAPI)
 
 	// graceful shutdown
 	c := make(chan os.Signal, 1)
@@ -0,0 +1,212 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// uploadChunkSize is size of the chunks request body is sent to gRPC
+	// service in
+	uploadChunkSize = 64 << 10
+
+	// fileNameHeader is header metadata key the file name is received in
+	fileNameHeader = "file-name-bin"
+)
+
+var (
+	// uploadPath matches path files attached to the todo are posted to
+	uploadPath = regexp.MustCompile(`^/v1/todo/(\d+)/attachments$`)
+
+	// downloadPath matches path of the attached file
+	downloadPath = regexp.MustCompile(`^/v1/todo/(\d+)/attachments/(\d+)$`)
+)
+
+// rawBodyHandler serves requests with raw bodies, which the gateway does not
+// support: uploads and downloads of the attached files and file imports.
+// Other requests are passed to the gateway.
+func rawBodyHandler(mux *runtime.ServeMux, client v1.TodoServiceClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			if imp, ok := importPaths[r.URL.Path]; ok {
+				importFile(mux, client, imp, w, r)
+				return
+			}
+			if m := uploadPath.FindStringSubmatch(r.URL.Path); m != nil {
+				todoID, _ := strconv.ParseInt(m[1], 10, 64)
+				uploadAttachment(mux, client, w, r, todoID)
+				return
+			}
+		case http.MethodGet:
+			if m := downloadPath.FindStringSubmatch(r.URL.Path); m != nil {
+				todoID, _ := strconv.ParseInt(m[1], 10, 64)
+				id, _ := strconv.ParseInt(m[2], 10, 64)
+				downloadAttachment(mux, client, w, r, todoID, id)
+				return
+			}
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// outgoingContext forwards request headers to gRPC service as metadata the
+// same way the gateway does, Authorization header is forwarded unprefixed
+func outgoingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for key, values := range r.Header {
+		if key == "Authorization" {
+			md.Append("authorization", values...)
+		}
+		if k, ok := incomingHeaderMatcher(key); ok {
+			md.Append(k, values...)
+		}
+	}
+	return metadata.NewOutgoingContext(r.Context(), md)
+}
+
+// uploadBody returns the uploaded file with its name and content type, the
+// file is the first file part of multipart/form-data body or the raw body
+// named by file_name query parameter or Content-Disposition header
+func uploadBody(r *http.Request) (io.Reader, string, string, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		mr, err := r.MultipartReader()
+		if err != nil {
+			return nil, "", "", err
+		}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return nil, "", "", errors.New("multipart body has no file")
+			}
+			if err != nil {
+				return nil, "", "", err
+			}
+			if len(part.FileName()) > 0 {
+				return part, part.FileName(), part.Header.Get("Content-Type"), nil
+			}
+		}
+	}
+
+	name := r.URL.Query().Get("file_name")
+	if len(name) == 0 {
+		if _, params, err := mime.ParseMediaType(r.Header.Get("Content-Disposition")); err == nil {
+			name = params["filename"]
+		}
+	}
+	return r.Body, name, r.Header.Get("Content-Type"), nil
+}
+
+// uploadAttachment streams the request body to UploadAttachment
+func uploadAttachment(mux *runtime.ServeMux, client v1.TodoServiceClient, w http.ResponseWriter, r *http.Request, todoID int64) {
+	ctx, cancel := context.WithCancel(outgoingContext(r))
+	defer cancel()
+	_, outbound := runtime.MarshalerForRequest(mux, r)
+
+	body, fileName, contentType, err := uploadBody(r)
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outbound, w, r, status.Error(codes.InvalidArgument, "request body is invalid-> "+err.Error()))
+		return
+	}
+
+	stream, err := client.UploadAttachment(ctx)
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outbound, w, r, err)
+		return
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for first := true; ; first = false {
+		n, err := io.ReadFull(body, buf)
+		if n > 0 || first {
+			req := &v1.UploadAttachmentRequest{Chunk: &httpbody.HttpBody{Data: buf[:n]}}
+			if first {
+				req.TodoId = todoID
+				req.FileName = fileName
+				req.Chunk.ContentType = contentType
+			}
+			if err := stream.Send(req); err == io.EOF {
+				// service failed the upload, its error is returned by CloseAndRecv
+				break
+			} else if err != nil {
+				runtime.HTTPError(ctx, mux, outbound, w, r, err)
+				return
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, status.Error(codes.InvalidArgument, "failed to read request body-> "+err.Error()))
+			return
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outbound, w, r, err)
+		return
+	}
+
+	buf, err = outbound.Marshal(resp)
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outbound, w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", outbound.ContentType())
+	_, _ = w.Write(buf)
+}
+
+// downloadAttachment writes chunks of DownloadAttachment as raw response body
+func downloadAttachment(mux *runtime.ServeMux, client v1.TodoServiceClient, w http.ResponseWriter, r *http.Request, todoID, id int64) {
+	ctx, cancel := context.WithCancel(outgoingContext(r))
+	defer cancel()
+	_, outbound := runtime.MarshalerForRequest(mux, r)
+
+	stream, err := client.DownloadAttachment(ctx, &v1.DownloadAttachmentRequest{TodoId: todoID, Id: id})
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outbound, w, r, err)
+		return
+	}
+
+	// the first chunk is always sent, errors are returned before it
+	chunk, err := stream.Recv()
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outbound, w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", chunk.ContentType)
+	if md, err := stream.Header(); err == nil {
+		if names := md.Get(fileNameHeader); len(names) > 0 {
+			w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": names[0]}))
+		}
+	}
+
+	for {
+		if _, err := w.Write(chunk.Data); err != nil {
+			return
+		}
+		if chunk, err = stream.Recv(); err == io.EOF {
+			return
+		} else if err != nil {
+			// status is sent already, the response is aborted so that client
+			// does not take the truncated file as complete
+			panic(http.ErrAbortHandler)
+		}
+	}
+}
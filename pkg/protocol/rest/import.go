@@ -0,0 +1,72 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// maxImportSize is the maximum size of imported file, gRPC rejects messages
+// over 4 MiB by default
+const maxImportSize = 4<<20 - 1<<10
+
+// importer sends the imported file to the import method of the service
+type importer func(ctx context.Context, client v1.TodoServiceClient, file *httpbody.HttpBody) (*v1.ImportSummary, error)
+
+// importPaths are importers by path the files are posted to
+var importPaths = map[string]importer{
+	"/v1/todo/import.ics": func(ctx context.Context, client v1.TodoServiceClient, file *httpbody.HttpBody) (*v1.ImportSummary, error) {
+		return client.ImportCalendar(ctx, &v1.ImportCalendarRequest{Calendar: file})
+	},
+	"/v1/todo/import.csv": func(ctx context.Context, client v1.TodoServiceClient, file *httpbody.HttpBody) (*v1.ImportSummary, error) {
+		return client.ImportCSV(ctx, &v1.ImportCSVRequest{File: file})
+	},
+	"/v1/todo/import.txt": func(ctx context.Context, client v1.TodoServiceClient, file *httpbody.HttpBody) (*v1.ImportSummary, error) {
+		return client.ImportTodoTxt(ctx, &v1.ImportTodoTxtRequest{File: file})
+	},
+}
+
+// importFile sends the posted file to the importer, the file is the raw body
+// or the first file part of multipart/form-data body
+func importFile(mux *runtime.ServeMux, client v1.TodoServiceClient, imp importer, w http.ResponseWriter, r *http.Request) {
+	ctx := outgoingContext(r)
+	_, outbound := runtime.MarshalerForRequest(mux, r)
+
+	body, _, contentType, err := uploadBody(r)
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outbound, w, r, status.Error(codes.InvalidArgument, "request body is invalid-> "+err.Error()))
+		return
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(body, maxImportSize+1))
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outbound, w, r, status.Error(codes.InvalidArgument, "failed to read request body-> "+err.Error()))
+		return
+	}
+	if len(data) > maxImportSize {
+		runtime.HTTPError(ctx, mux, outbound, w, r, status.Errorf(codes.InvalidArgument, "file is larger than %d bytes", maxImportSize))
+		return
+	}
+
+	resp, err := imp(ctx, client, &httpbody.HttpBody{ContentType: contentType, Data: data})
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outbound, w, r, err)
+		return
+	}
+
+	buf, err := outbound.Marshal(resp)
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outbound, w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", outbound.ContentType())
+	_, _ = w.Write(buf)
+}
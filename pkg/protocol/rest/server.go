@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
@@ -21,6 +22,25 @@ const (
 	relativePath = "."
 )
 
+// incomingHeaderMatcher forwards If-Match and Idempotency-Key headers to gRPC
+// service as lower case metadata, other headers are handled by the default
+// matcher
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, "If-Match") || strings.EqualFold(key, "Idempotency-Key") {
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher returns etag metadata of gRPC response as ETag header,
+// other metadata is prefixed like the gateway does by default
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == "etag" {
+		return "ETag", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
 func serveSwagger(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, relativePath+"/todo-service.swagger.json")
 }
@@ -30,17 +50,34 @@ func RunServer(ctx context.Context, grpcHost, grpcPort, httpPort string) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	)
+	// google.api.HttpBody responses are written as raw bodies
+	runtime.SetHTTPBodyMarshaler(mux)
+
 	opts := []grpc.DialOption{grpc.WithInsecure()}
 
 	if err := v1.RegisterTodoServiceHandlerFromEndpoint(ctx, mux, grpcHost+":"+grpcPort, opts); err != nil {
 		logger.Log.Fatal("failed to start http gateway", zap.String("reason", err.Error()))
 	}
+	if err := v1.RegisterWebhookServiceHandlerFromEndpoint(ctx, mux, grpcHost+":"+grpcPort, opts); err != nil {
+		logger.Log.Fatal("failed to start http gateway", zap.String("reason", err.Error()))
+	}
+
+	// attached files and calendar imports are handled by own handler using
+	// the client connection
+	conn, err := grpc.DialContext(ctx, grpcHost+":"+grpcPort, opts...)
+	if err != nil {
+		logger.Log.Fatal("failed to start http gateway", zap.String("reason", err.Error()))
+	}
+	defer conn.Close()
 
 	// Serve the swagger-ui and swagger file
 	// need to add swagger middleware to serve the files like logger
 	smux := http.NewServeMux()
-	smux.Handle("/", mux)
+	smux.Handle("/", rawBodyHandler(mux, v1.NewTodoServiceClient(conn)))
 	smux.HandleFunc("/swagger.json", serveSwagger)
 	fs := http.FileServer(http.Dir(relativePath + "/"))
 	smux.Handle("/swagger-ui/", http.StripPrefix("/swagger-ui", fs))
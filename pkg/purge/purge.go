@@ -0,0 +1,273 @@
+package purge
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/blob"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/logger"
+)
+
+const (
+	// batchSize is maximum number of rows removed by a single statement,
+	// keeps the delete transactions short
+	batchSize = 1000
+)
+
+// Purger permanently removes todos which stay in trash longer than retention
+// period, old events of the todo change log and expired idempotency keys
+type Purger struct {
+	db        *sql.DB
+	retention time.Duration
+	interval  time.Duration
+
+	// eventRetention is how long todo change events are kept for Watch resuming
+	eventRetention time.Duration
+
+	// idempotencyRetention is how long responses stored for retries are kept
+	idempotencyRetention time.Duration
+
+	// blobs keeps files attached to todos, they are removed with the todos
+	blobs blob.Store
+
+	// now returns current time, replaced in tests
+	now func() time.Time
+}
+
+// Option configures optional parameters of the purger
+type Option func(*Purger)
+
+// WithEventRetention sets how long todo change events are kept, they are never
+// removed by default
+func WithEventRetention(d time.Duration) Option {
+	return func(p *Purger) {
+		p.eventRetention = d
+	}
+}
+
+// WithIdempotencyRetention sets how long responses stored for retries of
+// requests with idempotency key are kept, they are never removed by default
+func WithIdempotencyRetention(d time.Duration) Option {
+	return func(p *Purger) {
+		p.idempotencyRetention = d
+	}
+}
+
+// WithBlobStore sets store of the files attached to todos, files of purged
+// todos are removed from it
+func WithBlobStore(store blob.Store) Option {
+	return func(p *Purger) {
+		p.blobs = store
+	}
+}
+
+// New creates purger removing todos deleted more than retention ago, every
+// interval. Zero retention keeps deleted todos forever.
+func New(db *sql.DB, retention, interval time.Duration, opts ...Option) *Purger {
+	p := &Purger{
+		db:        db,
+		retention: retention,
+		interval:  interval,
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Run purges the trash periodically until the context is done
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if p.retention > 0 {
+			n, err := p.Purge(ctx)
+			if err != nil {
+				logger.Log.Error("failed to purge deleted todos", zap.String("reason", err.Error()))
+			} else if n > 0 {
+				logger.Log.Info("purged deleted todos", zap.Int64("count", n))
+			}
+		}
+
+		if p.eventRetention > 0 {
+			n, err := p.PurgeEvents(ctx)
+			if err != nil {
+				logger.Log.Error("failed to purge todo events", zap.String("reason", err.Error()))
+			} else if n > 0 {
+				logger.Log.Info("purged todo events", zap.Int64("count", n))
+			}
+		}
+
+		if p.idempotencyRetention > 0 {
+			n, err := p.PurgeIdempotencyKeys(ctx)
+			if err != nil {
+				logger.Log.Error("failed to purge idempotency keys", zap.String("reason", err.Error()))
+			} else if n > 0 {
+				logger.Log.Info("purged idempotency keys", zap.Int64("count", n))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge removes todos deleted before the retention period and returns their number
+func (p *Purger) Purge(ctx context.Context) (int64, error) {
+	before := p.now().UTC().Add(-p.retention)
+	if p.blobs != nil {
+		return p.purgeWithBlobs(ctx, before)
+	}
+
+	// attachment rows and comments are removed by the foreign keys
+	return p.deleteBatches(ctx, "DELETE FROM ToDo WHERE `DeletedAt` IS NOT NULL AND `DeletedAt`<? LIMIT ?",
+		before)
+}
+
+// purgeWithBlobs removes todos deleted before the time together with their
+// attached files in batches and returns number of removed todos
+func (p *Purger) purgeWithBlobs(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	for {
+		n, err := p.purgeBatch(ctx, before)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// purgeBatch removes a batch of todos deleted before the time with their
+// files. The todos are locked until they are removed, so they cannot be
+// restored from trash after their files are gone.
+func (p *Purger) purgeBatch(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, "SELECT `ID` FROM ToDo WHERE `DeletedAt` IS NOT NULL AND `DeletedAt`<? LIMIT ? FOR UPDATE",
+		before, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var placeholders []string
+	var args []interface{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return 0, err
+		}
+		placeholders = append(placeholders, "?")
+		args = append(args, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	rows.Close()
+
+	if len(args) == 0 {
+		return 0, nil
+	}
+	in := "(" + strings.Join(placeholders, ", ") + ")"
+
+	keys, err := blobKeys(ctx, tx, in, args)
+	if err != nil {
+		return 0, err
+	}
+
+	// attachment rows and comments are removed by the foreign keys, the rows
+	// are removed first so that files are kept if it fails
+	res, err := tx.ExecContext(ctx, "DELETE FROM ToDo WHERE `ID` IN "+in, args...)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range keys {
+		if err := p.blobs.Delete(ctx, key); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// blobKeys returns keys of the files attached to the todos, in is the list
+// of placeholders of their IDs
+func blobKeys(ctx context.Context, tx *sql.Tx, in string, ids []interface{}) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT `BlobKey` FROM ToDoAttachment WHERE `ToDoID` IN "+in, ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// PurgeEvents removes todo events older than the event retention period and
+// returns their number
+func (p *Purger) PurgeEvents(ctx context.Context) (int64, error) {
+	return p.deleteBatches(ctx, "DELETE FROM ToDoEvent WHERE `CreatedAt`<? LIMIT ?",
+		p.now().UTC().Add(-p.eventRetention))
+}
+
+// PurgeIdempotencyKeys removes responses stored for retries longer than the
+// idempotency retention period and returns their number
+func (p *Purger) PurgeIdempotencyKeys(ctx context.Context) (int64, error) {
+	return p.deleteBatches(ctx, "DELETE FROM IdempotencyKey WHERE `CreatedAt`<? LIMIT ?",
+		p.now().UTC().Add(-p.idempotencyRetention))
+}
+
+// deleteBatches runs the delete statement until it removes less rows than the
+// batch size and returns total number of removed rows
+func (p *Purger) deleteBatches(ctx context.Context, query string, before time.Time) (int64, error) {
+	var total int64
+	for {
+		res, err := p.db.ExecContext(ctx, query, before, batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += rows
+
+		if rows < batchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestPurger_Purge(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	p := New(db, 24*time.Hour, time.Hour)
+	p.now = func() time.Time { return tm }
+	before := tm.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "Single batch",
+			mock: func() {
+				mock.ExpectExec("DELETE FROM ToDo WHERE `DeletedAt` IS NOT NULL").WithArgs(before, batchSize).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			want: 3,
+		},
+		{
+			name: "Multiple batches",
+			mock: func() {
+				mock.ExpectExec("DELETE FROM ToDo").WithArgs(before, batchSize).
+					WillReturnResult(sqlmock.NewResult(0, batchSize))
+				mock.ExpectExec("DELETE FROM ToDo").WithArgs(before, batchSize).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: batchSize + 1,
+		},
+		{
+			name: "DELETE failed",
+			mock: func() {
+				mock.ExpectExec("DELETE FROM ToDo").WithArgs(before, batchSize).
+					WillReturnError(errors.New("DELETE failed"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := p.Purge(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Purger.Purge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Purger.Purge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// deletedBlobs records keys of the deleted blobs
+type deletedBlobs []string
+
+func (d *deletedBlobs) Put(ctx context.Context, key string, r io.Reader) error { return nil }
+
+func (d *deletedBlobs) Get(ctx context.Context, key string) (io.ReadCloser, error) { return nil, nil }
+
+func (d *deletedBlobs) Delete(ctx context.Context, key string) error {
+	*d = append(*d, key)
+	return nil
+}
+
+func TestPurger_PurgeBlobs(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	blobs := &deletedBlobs{}
+	p := New(db, 24*time.Hour, time.Hour, WithBlobStore(blobs))
+	p.now = func() time.Time { return tm }
+	before := tm.Add(-24 * time.Hour)
+
+	// todos stay locked until their rows and files are removed
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `DeletedAt` IS NOT NULL AND `DeletedAt`<\\? LIMIT \\? FOR UPDATE").WithArgs(before, batchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(1).AddRow(2))
+	mock.ExpectQuery("SELECT `BlobKey` FROM ToDoAttachment WHERE `ToDoID` IN \\(\\?, \\?\\)").WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"BlobKey"}).AddRow("key1").AddRow("key2"))
+	mock.ExpectExec("DELETE FROM ToDo WHERE `ID` IN \\(\\?, \\?\\)").WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	got, err := p.Purge(ctx)
+	if err != nil {
+		t.Fatalf("Purger.Purge() error = %v", err)
+	}
+	if got != 2 {
+		t.Errorf("Purger.Purge() = %v, want %v", got, 2)
+	}
+	if len(*blobs) != 2 || (*blobs)[0] != "key1" || (*blobs)[1] != "key2" {
+		t.Errorf("Purger.Purge() deleted blobs %v, want [key1 key2]", *blobs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// failingBlobs fails to delete blobs
+type failingBlobs struct {
+	deletedBlobs
+}
+
+func (f *failingBlobs) Delete(ctx context.Context, key string) error {
+	return errors.New("delete failed")
+}
+
+func TestPurger_PurgeBlobs_failed(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	p := New(db, 24*time.Hour, time.Hour, WithBlobStore(&failingBlobs{}))
+	p.now = func() time.Time { return tm }
+	before := tm.Add(-24 * time.Hour)
+
+	// todos are kept when their files are not removed
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT `ID` FROM ToDo").WithArgs(before, batchSize).WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(1))
+	mock.ExpectQuery("SELECT `BlobKey` FROM ToDoAttachment").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"BlobKey"}).AddRow("key1"))
+	mock.ExpectExec("DELETE FROM ToDo WHERE `ID` IN").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	if _, err := p.Purge(ctx); err == nil {
+		t.Error("Purger.Purge() error = nil, want error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPurger_PurgeEvents(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	p := New(db, 0, time.Hour, WithEventRetention(time.Hour))
+	p.now = func() time.Time { return tm }
+
+	mock.ExpectExec("DELETE FROM ToDoEvent WHERE `CreatedAt`<\\?").WithArgs(tm.Add(-time.Hour), batchSize).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	got, err := p.PurgeEvents(ctx)
+	if err != nil {
+		t.Fatalf("Purger.PurgeEvents() error = %v", err)
+	}
+	if got != 5 {
+		t.Errorf("Purger.PurgeEvents() = %v, want %v", got, 5)
+	}
+}
+
+func TestPurger_PurgeIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	p := New(db, 0, time.Hour, WithIdempotencyRetention(24*time.Hour))
+	p.now = func() time.Time { return tm }
+
+	mock.ExpectExec("DELETE FROM IdempotencyKey WHERE `CreatedAt`<\\?").WithArgs(tm.Add(-24*time.Hour), batchSize).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	got, err := p.PurgeIdempotencyKeys(ctx)
+	if err != nil {
+		t.Fatalf("Purger.PurgeIdempotencyKeys() error = %v", err)
+	}
+	if got != 2 {
+		t.Errorf("Purger.PurgeIdempotencyKeys() = %v, want %v", got, 2)
+	}
+}
@@ -0,0 +1,73 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/logger"
+)
+
+// LogNotifier writes reminders to the log
+type LogNotifier struct{}
+
+// Notify logs the reminder
+func (LogNotifier) Notify(ctx context.Context, n *Notification) error {
+	logger.Log.Info("todo reminder",
+		zap.Int64("id", n.TodoID),
+		zap.String("owner", n.Owner),
+		zap.String("title", n.Title),
+		zap.Time("reminder", n.Reminder),
+		zap.String("time_zone", n.TimeZone))
+	return nil
+}
+
+// WebhookNotifier posts reminders as JSON to the URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates notifier posting reminders to the URL, request
+// not answered within timeout fails
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Notify posts the reminder, any response status other than 2xx is failure
+func (w *WebhookNotifier) Notify(ctx context.Context, n *Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to encode reminder: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// drain the body to reuse the connection
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	n := &Notification{
+		TodoID:      1,
+		Owner:       "team-a",
+		Title:       "title",
+		Description: "description",
+		Reminder:    time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		TimeZone:    "Europe/Berlin",
+	}
+
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{
+			name:   "OK",
+			status: http.StatusNoContent,
+		},
+		{
+			name:    "Server error",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Notification
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("unexpected request %s with content type '%s'", r.Method, r.Header.Get("Content-Type"))
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("failed to decode request body: %v", err)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			err := NewWebhookNotifier(srv.URL, time.Second).Notify(context.Background(), n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WebhookNotifier.Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(&got, n) {
+				t.Errorf("WebhookNotifier.Notify() posted %v, want %v", got, *n)
+			}
+		})
+	}
+}
+
+func TestWebhookNotifier_Notify_unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	if err := NewWebhookNotifier(srv.URL, time.Second).Notify(context.Background(), &Notification{TodoID: 1}); err == nil {
+		t.Error("WebhookNotifier.Notify() error = nil, want error")
+	}
+}
@@ -0,0 +1,206 @@
+// Package reminder sends notifications about todos whose reminder is due.
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/logger"
+)
+
+const (
+	// defaultBatchSize is maximum number of reminders claimed at once
+	defaultBatchSize = 50
+
+	// defaultLease is how long claimed reminders are reserved for the
+	// dispatcher, they are claimed again after it if not sent
+	defaultLease = 10 * time.Minute
+)
+
+// Notification is reminder of a todo sent by notifier
+type Notification struct {
+	TodoID int64 `json:"todo_id"`
+
+	// Owner is the tenant the todo belongs to, empty for the default tenant
+	Owner       string    `json:"owner"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Reminder    time.Time `json:"reminder"`
+
+	// TimeZone is IANA time zone the reminder was set in, empty for UTC
+	TimeZone string `json:"time_zone"`
+}
+
+// Notifier delivers reminders. The same reminder can be delivered more than
+// once, e.g. if the dispatcher stops before it records the delivery.
+type Notifier interface {
+	Notify(ctx context.Context, n *Notification) error
+}
+
+// Dispatcher periodically sends due reminders through notifier. Reminder is
+// due when its time passed and it was not notified since, so reminder moved
+// to the future fires again. Replicas claim reminders before sending them to
+// avoid sending the same reminder twice.
+type Dispatcher struct {
+	db       *sql.DB
+	notifier Notifier
+	interval time.Duration
+
+	// batchSize is maximum number of reminders claimed at once
+	batchSize int
+
+	// lease is how long claimed reminders are reserved for this dispatcher
+	lease time.Duration
+
+	// now returns current time, replaced in tests
+	now func() time.Time
+}
+
+// Option configures optional parameters of the dispatcher
+type Option func(*Dispatcher)
+
+// WithBatchSize sets maximum number of reminders claimed at once
+func WithBatchSize(n int) Option {
+	return func(d *Dispatcher) {
+		if n > 0 {
+			d.batchSize = n
+		}
+	}
+}
+
+// WithLease sets how long claimed reminders are reserved before they are
+// claimed again, it must be longer than sending a batch takes
+func WithLease(lease time.Duration) Option {
+	return func(d *Dispatcher) {
+		if lease > 0 {
+			d.lease = lease
+		}
+	}
+}
+
+// New creates dispatcher sending due reminders through notifier every
+// interval
+func New(db *sql.DB, notifier Notifier, interval time.Duration, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		db:        db,
+		notifier:  notifier,
+		interval:  interval,
+		batchSize: defaultBatchSize,
+		lease:     defaultLease,
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Run sends due reminders periodically until the context is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := d.Dispatch(ctx)
+		if err != nil {
+			logger.Log.Error("failed to dispatch reminders", zap.String("reason", err.Error()))
+		} else if n > 0 {
+			logger.Log.Info("dispatched reminders", zap.Int("count", n))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch claims a batch of due reminders, sends them and returns number of
+// sent reminders. Reminder which failed to send is retried after the lease.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	list, err := d.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, n := range list {
+		if err := d.notifier.Notify(ctx, n); err != nil {
+			logger.Log.Warn("failed to send reminder", zap.Int64("id", n.TodoID), zap.String("reason", err.Error()))
+			continue
+		}
+
+		// notification is recorded after sending, so it is sent at least once.
+		// The sent reminder is recorded, so reminder moved while it was sent
+		// stays due.
+		if _, err := d.db.ExecContext(ctx, "UPDATE ToDo SET `NotifiedAt`=IF(`Reminder`=?, `Reminder`, `NotifiedAt`), `ReminderLeaseUntil`=NULL WHERE `ID`=?",
+			n.Reminder, n.TodoID); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// claim reserves due reminders for the lease period and returns them
+func (d *Dispatcher) claim(ctx context.Context) ([]*Notification, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := d.now().UTC()
+	rows, err := tx.QueryContext(ctx, "SELECT `ID`, `Owner`, `Title`, `Description`, `Reminder`, `TimeZone` FROM ToDo"+
+		" WHERE `Reminder`<=? AND `Status`<>? AND `DeletedAt` IS NULL"+
+		" AND (`NotifiedAt` IS NULL OR `NotifiedAt`<`Reminder`)"+
+		" AND (`ReminderLeaseUntil` IS NULL OR `ReminderLeaseUntil`<=?)"+
+		" ORDER BY `Reminder` LIMIT ? FOR UPDATE",
+		now, v1.Todo_DONE, now, d.batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*Notification
+	for rows.Next() {
+		var n Notification
+		if err := rows.Scan(&n.TodoID, &n.Owner, &n.Title, &n.Description, &n.Reminder, &n.TimeZone); err != nil {
+			return nil, err
+		}
+		list = append(list, &n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(list))
+	args := make([]interface{}, 0, len(list)+1)
+	args = append(args, now.Add(d.lease))
+	for i, n := range list {
+		placeholders[i] = "?"
+		args = append(args, n.TodoID)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE ToDo SET `ReminderLeaseUntil`=? WHERE `ID` IN ("+strings.Join(placeholders, ", ")+")",
+		args...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,187 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/logger"
+)
+
+// fakeNotifier records notifications and fails for the todos in fail
+type fakeNotifier struct {
+	sent []int64
+	fail map[int64]bool
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, n *Notification) error {
+	if f.fail[n.TodoID] {
+		return errors.New("notifier failed")
+	}
+	f.sent = append(f.sent, n.TodoID)
+	return nil
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	logger.Log = zap.NewNop()
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	dueRows := func(ids ...int64) *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"ID", "Owner", "Title", "Description", "Reminder", "TimeZone"})
+		for _, id := range ids {
+			rows.AddRow(id, "team-a", "title", "description", tm.Add(-time.Minute), "Europe/Berlin")
+		}
+		return rows
+	}
+
+	tests := []struct {
+		name     string
+		fail     map[int64]bool
+		mock     func()
+		want     int
+		wantSent []int64
+		wantErr  bool
+	}{
+		{
+			name: "Sent",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Reminder`<=\\?(.+) FOR UPDATE").
+					WithArgs(tm, v1.Todo_DONE, tm, 2).WillReturnRows(dueRows(1, 2))
+				mock.ExpectExec("UPDATE ToDo SET `ReminderLeaseUntil`=\\? WHERE `ID` IN \\(\\?, \\?\\)").
+					WithArgs(tm.Add(defaultLease), 1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+				mock.ExpectExec("UPDATE ToDo SET `NotifiedAt`=IF\\(`Reminder`=\\?, `Reminder`, `NotifiedAt`\\), `ReminderLeaseUntil`=NULL WHERE `ID`=\\?").
+					WithArgs(tm.Add(-time.Minute), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE ToDo SET `NotifiedAt`=").
+					WithArgs(tm.Add(-time.Minute), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:     2,
+			wantSent: []int64{1, 2},
+		},
+		{
+			name: "Nothing due",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WillReturnRows(dueRows())
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "Failed reminder keeps the lease",
+			fail: map[int64]bool{1: true},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WillReturnRows(dueRows(1, 2))
+				mock.ExpectExec("UPDATE ToDo SET `ReminderLeaseUntil`=\\?").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+				mock.ExpectExec("UPDATE ToDo SET `NotifiedAt`=").
+					WithArgs(tm.Add(-time.Minute), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:     1,
+			wantSent: []int64{2},
+		},
+		{
+			name: "SELECT failed",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WillReturnError(errors.New("SELECT failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Claim failed",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WillReturnRows(dueRows(1))
+				mock.ExpectExec("UPDATE ToDo SET `ReminderLeaseUntil`=\\?").WillReturnError(errors.New("UPDATE failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &fakeNotifier{fail: tt.fail}
+			d := New(db, n, time.Minute, WithBatchSize(2))
+			d.now = func() time.Time { return tm }
+
+			tt.mock()
+			got, err := d.Dispatch(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Dispatcher.Dispatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Dispatcher.Dispatch() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(n.sent, tt.wantSent) {
+				t.Errorf("Dispatcher.Dispatch() sent = %v, want %v", n.sent, tt.wantSent)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDispatcher_Dispatch_reminderMoved(t *testing.T) {
+	logger.Log = zap.NewNop()
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	claimed := tm.Add(-time.Minute)
+	moved := tm.Add(time.Minute)
+	n := &fakeNotifier{}
+	d := New(db, n, time.Minute)
+	d.now = func() time.Time { return tm }
+	columns := []string{"ID", "Owner", "Title", "Description", "Reminder", "TimeZone"}
+
+	// reminder is moved while it is sent, the claimed reminder is recorded
+	// as sent, so the update keeps NotifiedAt before the moved one
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM ToDo").WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "", "title", "", claimed, ""))
+	mock.ExpectExec("UPDATE ToDo SET `ReminderLeaseUntil`=\\?").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("UPDATE ToDo SET `NotifiedAt`=IF\\(`Reminder`=\\?").WithArgs(claimed, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	if _, err := d.Dispatch(ctx); err != nil {
+		t.Fatalf("Dispatcher.Dispatch() error = %v", err)
+	}
+
+	// the moved reminder fires when it is due
+	tm = moved
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(moved, v1.Todo_DONE, moved, defaultBatchSize).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "", "title", "", moved, ""))
+	mock.ExpectExec("UPDATE ToDo SET `ReminderLeaseUntil`=\\?").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("UPDATE ToDo SET `NotifiedAt`=IF\\(`Reminder`=\\?").WithArgs(moved, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	if _, err := d.Dispatch(ctx); err != nil {
+		t.Fatalf("Dispatcher.Dispatch() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(n.sent, []int64{1, 1}) {
+		t.Errorf("Dispatcher.Dispatch() sent = %v, want [1 1]", n.sent)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
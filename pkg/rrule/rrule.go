@@ -0,0 +1,635 @@
+// Package rrule parses and expands RFC 5545 recurrence rules.
+//
+// Rules with DAILY, WEEKLY, MONTHLY and YEARLY frequency are supported
+// together with INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH, BYSETPOS
+// and WKST parts. Occurrences keep the time of day of the first occurrence.
+package rrule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ part of the rule
+type Frequency int
+
+// Supported frequencies
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+func (f Frequency) String() string {
+	return frequencyNames[f]
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+// WeekdayNum is a BYDAY value. N is the ordinal of the weekday within the
+// month or year, negative from its end, zero means every such weekday.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayNames[w.Weekday]
+	}
+	return strconv.Itoa(w.N) + weekdayNames[w.Weekday]
+}
+
+// Rule is a parsed recurrence rule
+type Rule struct {
+	Freq Frequency
+
+	// Interval is number of periods between occurrences, at least 1
+	Interval int
+
+	// Count is number of occurrences including the first one, zero means
+	// unlimited
+	Count int
+
+	// Until is time of the last possible occurrence, zero means unlimited
+	Until time.Time
+
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	BySetPos   []int
+
+	// WeekStart is the first day of the week, Monday by default
+	WeekStart time.Weekday
+}
+
+// unsupportedParts are valid RFC 5545 rule parts this package does not
+// implement
+var unsupportedParts = map[string]bool{
+	"BYSECOND":  true,
+	"BYMINUTE":  true,
+	"BYHOUR":    true,
+	"BYYEARDAY": true,
+	"BYWEEKNO":  true,
+}
+
+// Parse parses recurrence rule such as "FREQ=WEEKLY;BYDAY=MO", optionally
+// prefixed with "RRULE:"
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= len("RRULE:") && strings.EqualFold(s[:len("RRULE:")], "RRULE:") {
+		s = s[len("RRULE:"):]
+	}
+	if len(s) == 0 {
+		return nil, fmt.Errorf("rule is empty")
+	}
+
+	r := &Rule{Interval: 1, WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || len(kv[1]) == 0 {
+			return nil, fmt.Errorf("rule part '%s' is not in NAME=VALUE form", part)
+		}
+		name, value := strings.ToUpper(strings.TrimSpace(kv[0])), strings.ToUpper(strings.TrimSpace(kv[1]))
+		if seen[name] {
+			return nil, fmt.Errorf("rule part %s is repeated", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			err = r.parseFreq(value)
+		case "INTERVAL":
+			r.Interval, err = parsePositive(value)
+		case "COUNT":
+			r.Count, err = parsePositive(value)
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(value, 1, 31)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(value, 1, 12)
+			for _, m := range months {
+				if m < 0 {
+					return nil, fmt.Errorf("BYMONTH value '%d' is invalid", m)
+				}
+				r.ByMonth = append(r.ByMonth, time.Month(m))
+			}
+		case "BYSETPOS":
+			r.BySetPos, err = parseInts(value, 1, 366)
+		case "WKST":
+			r.WeekStart, err = parseWeekday(value)
+		default:
+			if unsupportedParts[name] {
+				return nil, fmt.Errorf("rule part %s is not supported", name)
+			}
+			return nil, fmt.Errorf("unknown rule part %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s value is invalid-> %v", name, err)
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// validate checks the combination of rule parts
+func (r *Rule) validate() error {
+	if r.Freq == 0 {
+		return fmt.Errorf("FREQ is required")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("COUNT and UNTIL must not be used together")
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("BYMONTHDAY must not be used with WEEKLY frequency")
+	}
+	if r.Freq != Monthly && r.Freq != Yearly {
+		for _, d := range r.ByDay {
+			if d.N != 0 {
+				return fmt.Errorf("BYDAY ordinal must not be used with %s frequency", r.Freq)
+			}
+		}
+	}
+	if r.Freq == Yearly && len(r.ByMonth) > 0 {
+		for _, d := range r.ByDay {
+			if d.N > 5 || d.N < -5 {
+				return fmt.Errorf("BYDAY ordinal '%d' is invalid within a month", d.N)
+			}
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByMonth) == 0 {
+		return fmt.Errorf("BYSETPOS must be used with another BYxxx part")
+	}
+	return nil
+}
+
+func (r *Rule) parseFreq(value string) error {
+	for f, name := range frequencyNames {
+		if name == value {
+			r.Freq = f
+			return nil
+		}
+	}
+	switch value {
+	case "SECONDLY", "MINUTELY", "HOURLY":
+		return fmt.Errorf("frequency '%s' is not supported", value)
+	}
+	return fmt.Errorf("unknown frequency '%s'", value)
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("'%s' is not a positive number", value)
+	}
+	return n, nil
+}
+
+// parseInts parses comma separated list of numbers with absolute value
+// between min and max
+func parseInts(value string, min, max int) ([]int, error) {
+	var list []int
+	for _, v := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a number", v)
+		}
+		if abs(n) < min || abs(n) > max {
+			return nil, fmt.Errorf("'%d' is out of range", n)
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	for d, name := range weekdayNames {
+		if name == value {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday '%s'", value)
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var list []WeekdayNum
+	for _, v := range strings.Split(value, ",") {
+		if len(v) < 2 {
+			return nil, fmt.Errorf("unknown weekday '%s'", v)
+		}
+		d, err := parseWeekday(v[len(v)-2:])
+		if err != nil {
+			return nil, err
+		}
+
+		w := WeekdayNum{Weekday: d}
+		if ordinal := v[:len(v)-2]; len(ordinal) > 0 {
+			w.N, err = strconv.Atoi(ordinal)
+			if err != nil || w.N == 0 || abs(w.N) > 53 {
+				return nil, fmt.Errorf("weekday ordinal '%s' is invalid", ordinal)
+			}
+		}
+		list = append(list, w)
+	}
+	return list, nil
+}
+
+// parseUntil parses UTC or floating date-time or date, floating time is
+// treated as UTC and date as the end of the day
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return time.Time{}, fmt.Errorf("'%s' is not a date or date-time", value)
+}
+
+// String returns the rule in canonical form
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]int, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = int(m)
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(list []int) string {
+	s := make([]string, len(list))
+	for i, n := range list {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ",")
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// maxEmptyYears is time without occurrence after which the rule is treated
+// as ended, e.g. for BYMONTH=2;BYMONTHDAY=30. Leap days are up to 8 years
+// apart, around years divisible by 100 but not by 400.
+const maxEmptyYears = 8
+
+// periodsPerYear is the maximum number of periods of the frequency in a year
+var periodsPerYear = map[Frequency]int{
+	Daily:   366,
+	Weekly:  53,
+	Monthly: 12,
+	Yearly:  1,
+}
+
+// maxEmptyPeriods returns number of consecutive periods without occurrence
+// after which the rule is treated as ended
+func (r *Rule) maxEmptyPeriods() int {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	return maxEmptyYears*periodsPerYear[r.Freq]/interval + 1
+}
+
+// Iterate calls fn for occurrences of the rule in chronological order until
+// fn returns false or the rule ends. start is the first occurrence, as
+// RFC 5545 counts DTSTART even if it does not match the rule.
+func (r *Rule) Iterate(start time.Time, fn func(time.Time) bool) {
+	r.iterate(start, 0, fn)
+}
+
+// iterate calls fn for occurrences of the rule starting from the first
+// period, start is emitted only from the period 0. Skipping periods is
+// valid only for rules without COUNT, which counts every occurrence.
+func (r *Rule) iterate(start time.Time, first int, fn func(time.Time) bool) {
+	n := 0
+	emit := func(t time.Time) bool {
+		if !r.Until.IsZero() && t.After(r.Until) {
+			return false
+		}
+		n++
+		return fn(t) && (r.Count == 0 || n < r.Count)
+	}
+	if first == 0 && !emit(start) {
+		return
+	}
+
+	e := r.withDefaults(start)
+	hour, min, sec := start.Clock()
+	empty := 0
+	maxEmpty := r.maxEmptyPeriods()
+	for p := first; empty < maxEmpty; p++ {
+		days, ok := e.periodDays(start, p)
+		if !ok {
+			return
+		}
+
+		found := false
+		for _, d := range days {
+			t := time.Date(d.Year(), d.Month(), d.Day(), hour, min, sec, start.Nanosecond(), start.Location())
+			if !t.After(start) {
+				continue
+			}
+			found = true
+			if !emit(t) {
+				return
+			}
+		}
+
+		if found {
+			empty = 0
+		} else {
+			empty++
+		}
+	}
+}
+
+// Between returns occurrences within [from, to), at most limit of them. Rule
+// without COUNT skips the periods before from.
+func (r *Rule) Between(start, from, to time.Time, limit int) []time.Time {
+	first := 0
+	if r.Count == 0 && from.After(start) {
+		first = r.periodIndex(start, from)
+	}
+
+	var list []time.Time
+	r.iterate(start, first, func(t time.Time) bool {
+		if !t.Before(to) || len(list) >= limit {
+			return false
+		}
+		if !t.Before(from) {
+			list = append(list, t)
+		}
+		return true
+	})
+	return list
+}
+
+// Advance returns the second occurrence of the rule and the rule of the
+// occurrences remaining after start, which has the second occurrence as its
+// first one. It returns false if start is the only remaining occurrence.
+func (r *Rule) Advance(start time.Time) (time.Time, *Rule, bool) {
+	var next time.Time
+	n := 0
+	r.Iterate(start, func(t time.Time) bool {
+		n++
+		next = t
+		return n < 2
+	})
+	if n < 2 {
+		return time.Time{}, nil, false
+	}
+
+	rest := *r
+	if rest.Count > 0 {
+		rest.Count--
+	}
+	return next, &rest, true
+}
+
+// periodIndex returns index of the period containing t, periods are counted
+// from the period of start
+func (r *Rule) periodIndex(start, t time.Time) int {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	// periods are in wall time of start
+	t = t.In(start.Location())
+	sy, sm, sd := start.Date()
+	ty, tm, td := t.Date()
+	days := int((time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC).Unix() - time.Date(sy, sm, sd, 0, 0, 0, 0, time.UTC).Unix()) / (24 * 60 * 60))
+
+	var n int
+	switch r.Freq {
+	case Daily:
+		n = days
+	case Weekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		n = (days + offset) / 7
+	case Monthly:
+		n = (ty-sy)*12 + int(tm-sm)
+	case Yearly:
+		n = ty - sy
+	}
+	if n < 0 {
+		return 0
+	}
+	return n / interval
+}
+
+// withDefaults returns copy of the rule with the parts implied by the first
+// occurrence, e.g. MONTHLY rule repeats on the day of month of start
+func (r *Rule) withDefaults(start time.Time) *Rule {
+	e := *r
+	switch e.Freq {
+	case Weekly:
+		if len(e.ByDay) == 0 {
+			e.ByDay = []WeekdayNum{{Weekday: start.Weekday()}}
+		}
+	case Monthly:
+		if len(e.ByDay) == 0 && len(e.ByMonthDay) == 0 {
+			e.ByMonthDay = []int{start.Day()}
+		}
+	case Yearly:
+		if len(e.ByDay) == 0 && len(e.ByMonthDay) == 0 {
+			if len(e.ByMonth) == 0 {
+				e.ByMonth = []time.Month{start.Month()}
+			}
+			e.ByMonthDay = []int{start.Day()}
+		}
+	}
+	return &e
+}
+
+// periodDays returns days of the p-th period of the rule matching it, in
+// UTC midnight form. It returns false if the period is out of time range.
+func (r *Rule) periodDays(start time.Time, p int) ([]time.Time, bool) {
+	y, m, d := start.Date()
+	step := p * r.Interval
+
+	var first, end time.Time
+	switch r.Freq {
+	case Daily:
+		first = time.Date(y, m, d+step, 0, 0, 0, 0, time.UTC)
+		end = first.AddDate(0, 0, 1)
+	case Weekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		first = time.Date(y, m, d-offset+7*step, 0, 0, 0, 0, time.UTC)
+		end = first.AddDate(0, 0, 7)
+	case Monthly:
+		first = time.Date(y, m+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		end = first.AddDate(0, 1, 0)
+	case Yearly:
+		first = time.Date(y+step, time.January, 1, 0, 0, 0, 0, time.UTC)
+		end = first.AddDate(1, 0, 0)
+	}
+	if first.Year() > 9999 {
+		return nil, false
+	}
+
+	var days []time.Time
+	for day := first; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if r.matches(day) {
+			days = append(days, day)
+		}
+	}
+	return r.setPositions(days), true
+}
+
+// matches checks the day against BYMONTH, BYMONTHDAY and BYDAY parts
+func (r *Rule) matches(day time.Time) bool {
+	if len(r.ByMonth) > 0 {
+		found := false
+		for _, m := range r.ByMonth {
+			if m == day.Month() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		// negative day counts from the end of the month
+		dim := daysIn(day.Year(), day.Month())
+		found := false
+		for _, md := range r.ByMonthDay {
+			if md == day.Day() || md == day.Day()-dim-1 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(r.ByDay) > 0 {
+		nth, nthLast := r.weekdayOrdinals(day)
+		found := false
+		for _, wd := range r.ByDay {
+			if wd.Weekday == day.Weekday() && (wd.N == 0 || wd.N == nth || wd.N == nthLast) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// weekdayOrdinals returns ordinal of the day among the same weekdays of the
+// month or year, counted from the start and from the end
+func (r *Rule) weekdayOrdinals(day time.Time) (int, int) {
+	// ordinals are within month for MONTHLY rules and YEARLY rules limited
+	// by BYMONTH, within year otherwise
+	if r.Freq == Monthly || (r.Freq == Yearly && len(r.ByMonth) > 0) {
+		dim := daysIn(day.Year(), day.Month())
+		return (day.Day()-1)/7 + 1, -((dim-day.Day())/7 + 1)
+	}
+
+	diy := time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	return (day.YearDay()-1)/7 + 1, -((diy-day.YearDay())/7 + 1)
+}
+
+// setPositions applies BYSETPOS part to the sorted days of a period
+func (r *Rule) setPositions(days []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 {
+		return days
+	}
+
+	selected := map[int]bool{}
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			selected[i] = true
+		}
+	}
+
+	indexes := make([]int, 0, len(selected))
+	for i := range selected {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	list := make([]time.Time, len(indexes))
+	for j, i := range indexes {
+		list[j] = days[i]
+	}
+	return list
+}
+
+// daysIn returns number of days in the month
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,644 @@
+package rrule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// dt parses date-time in the compact RFC 5545 form as UTC
+func dt(t *testing.T, s string) time.Time {
+	t.Helper()
+	tm, err := time.Parse("20060102T150405", s)
+	if err != nil {
+		t.Fatalf("invalid test time '%s': %v", s, err)
+	}
+	return tm
+}
+
+func dts(t *testing.T, list ...string) []time.Time {
+	t.Helper()
+	times := make([]time.Time, len(list))
+	for i, s := range list {
+		times[i] = dt(t, s)
+	}
+	return times
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		want    *Rule
+		wantErr bool
+	}{
+		{
+			name: "Weekly on Monday",
+			rule: "FREQ=WEEKLY;BYDAY=MO",
+			want: &Rule{Freq: Weekly, Interval: 1, ByDay: []WeekdayNum{{Weekday: time.Monday}}, WeekStart: time.Monday},
+		},
+		{
+			name: "RRULE prefix and lower case",
+			rule: " rrule:freq=daily;interval=2 ",
+			want: &Rule{Freq: Daily, Interval: 2, WeekStart: time.Monday},
+		},
+		{
+			name: "All parts",
+			rule: "FREQ=YEARLY;COUNT=3;BYMONTH=1;BYMONTHDAY=-1;BYDAY=1MO,-2FR;BYSETPOS=1;WKST=SU",
+			want: &Rule{
+				Freq:       Yearly,
+				Interval:   1,
+				Count:      3,
+				ByMonth:    []time.Month{time.January},
+				ByMonthDay: []int{-1},
+				ByDay:      []WeekdayNum{{Weekday: time.Monday, N: 1}, {Weekday: time.Friday, N: -2}},
+				BySetPos:   []int{1},
+				WeekStart:  time.Sunday,
+			},
+		},
+		{
+			name: "Monthly on the first Friday",
+			rule: "FREQ=MONTHLY;COUNT=10;BYDAY=1FR",
+			want: &Rule{Freq: Monthly, Interval: 1, Count: 10, ByDay: []WeekdayNum{{Weekday: time.Friday, N: 1}}, WeekStart: time.Monday},
+		},
+		{
+			name: "UTC until",
+			rule: "FREQ=DAILY;UNTIL=19971224T000000Z",
+			want: &Rule{Freq: Daily, Interval: 1, Until: time.Date(1997, 12, 24, 0, 0, 0, 0, time.UTC), WeekStart: time.Monday},
+		},
+		{
+			name: "Date until",
+			rule: "FREQ=DAILY;UNTIL=19971224",
+			want: &Rule{Freq: Daily, Interval: 1, Until: time.Date(1997, 12, 25, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond), WeekStart: time.Monday},
+		},
+		{
+			name:    "Empty",
+			rule:    "",
+			wantErr: true,
+		},
+		{
+			name:    "Missing FREQ",
+			rule:    "BYDAY=MO",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown frequency",
+			rule:    "FREQ=FORTNIGHTLY",
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported frequency",
+			rule:    "FREQ=HOURLY",
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported part",
+			rule:    "FREQ=DAILY;BYHOUR=9",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown part",
+			rule:    "FREQ=DAILY;FOO=1",
+			wantErr: true,
+		},
+		{
+			name:    "Not NAME=VALUE",
+			rule:    "FREQ=DAILY;COUNT",
+			wantErr: true,
+		},
+		{
+			name:    "Repeated part",
+			rule:    "FREQ=DAILY;FREQ=WEEKLY",
+			wantErr: true,
+		},
+		{
+			name:    "Zero interval",
+			rule:    "FREQ=DAILY;INTERVAL=0",
+			wantErr: true,
+		},
+		{
+			name:    "COUNT with UNTIL",
+			rule:    "FREQ=DAILY;COUNT=2;UNTIL=19971224T000000Z",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid UNTIL",
+			rule:    "FREQ=DAILY;UNTIL=tomorrow",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown weekday",
+			rule:    "FREQ=WEEKLY;BYDAY=XX",
+			wantErr: true,
+		},
+		{
+			name:    "Weekday ordinal out of range",
+			rule:    "FREQ=YEARLY;BYDAY=54MO",
+			wantErr: true,
+		},
+		{
+			name:    "Weekday ordinal with WEEKLY",
+			rule:    "FREQ=WEEKLY;BYDAY=1MO",
+			wantErr: true,
+		},
+		{
+			name:    "Weekday ordinal beyond month",
+			rule:    "FREQ=YEARLY;BYMONTH=3;BYDAY=6MO",
+			wantErr: true,
+		},
+		{
+			name:    "Month day out of range",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=32",
+			wantErr: true,
+		},
+		{
+			name:    "Month day with WEEKLY",
+			rule:    "FREQ=WEEKLY;BYMONTHDAY=1",
+			wantErr: true,
+		},
+		{
+			name:    "Negative month",
+			rule:    "FREQ=YEARLY;BYMONTH=-1",
+			wantErr: true,
+		},
+		{
+			name:    "BYSETPOS alone",
+			rule:    "FREQ=MONTHLY;BYSETPOS=1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_String(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		want string
+	}{
+		{
+			name: "Defaults are omitted",
+			rule: "freq=weekly;interval=1;wkst=mo;byday=mo",
+			want: "FREQ=WEEKLY;BYDAY=MO",
+		},
+		{
+			name: "Canonical part order",
+			rule: "BYSETPOS=-1;BYDAY=MO,TU,WE,TH,FR;FREQ=MONTHLY;INTERVAL=2;COUNT=6;WKST=SU",
+			want: "FREQ=MONTHLY;INTERVAL=2;COUNT=6;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;WKST=SU",
+		},
+		{
+			name: "Until and month parts",
+			rule: "FREQ=YEARLY;UNTIL=20301231T000000Z;BYMONTH=1,7;BYMONTHDAY=1,-1;BYDAY=-1SU",
+			want: "FREQ=YEARLY;UNTIL=20301231T000000Z;BYMONTH=1,7;BYMONTHDAY=1,-1;BYDAY=-1SU",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+
+			// canonical form parses back to the same rule
+			again, err := Parse(r.String())
+			if err != nil {
+				t.Fatalf("Parse() of canonical form error = %v", err)
+			}
+			if !reflect.DeepEqual(again, r) {
+				t.Errorf("Parse() of canonical form = %v, want %v", again, r)
+			}
+		})
+	}
+}
+
+func TestRule_Iterate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "Daily for 10 occurrences",
+			rule:  "FREQ=DAILY;COUNT=10",
+			start: "19970902T090000",
+			want: []string{"19970902T090000", "19970903T090000", "19970904T090000", "19970905T090000", "19970906T090000",
+				"19970907T090000", "19970908T090000", "19970909T090000", "19970910T090000", "19970911T090000"},
+		},
+		{
+			name:  "Every 10 days, 5 occurrences",
+			rule:  "FREQ=DAILY;INTERVAL=10;COUNT=5",
+			start: "19970902T090000",
+			want:  []string{"19970902T090000", "19970912T090000", "19970922T090000", "19971002T090000", "19971012T090000"},
+		},
+		{
+			name:  "Daily until",
+			rule:  "FREQ=DAILY;UNTIL=19970905T090000Z",
+			start: "19970902T090000",
+			want:  []string{"19970902T090000", "19970903T090000", "19970904T090000", "19970905T090000"},
+		},
+		{
+			name:  "Every day in January",
+			rule:  "FREQ=DAILY;BYMONTH=1",
+			start: "19980130T090000",
+			limit: 4,
+			want:  []string{"19980130T090000", "19980131T090000", "19990101T090000", "19990102T090000"},
+		},
+		{
+			name:  "Weekly on Monday",
+			rule:  "FREQ=WEEKLY;BYDAY=MO",
+			start: "20261019T080000",
+			limit: 3,
+			want:  []string{"20261019T080000", "20261026T080000", "20261102T080000"},
+		},
+		{
+			name:  "Weekly on weekday of start",
+			rule:  "FREQ=WEEKLY;COUNT=3",
+			start: "20261021T080000",
+			want:  []string{"20261021T080000", "20261028T080000", "20261104T080000"},
+		},
+		{
+			name:  "Start not matching the rule counts as first occurrence",
+			rule:  "FREQ=WEEKLY;BYDAY=MO;COUNT=3",
+			start: "20261020T080000",
+			want:  []string{"20261020T080000", "20261026T080000", "20261102T080000"},
+		},
+		{
+			name:  "Every other week on Tuesday and Thursday for 8 occurrences",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;WKST=SU;BYDAY=TU,TH;COUNT=8",
+			start: "19970902T090000",
+			want: []string{"19970902T090000", "19970904T090000", "19970916T090000", "19970918T090000",
+				"19970930T090000", "19971002T090000", "19971014T090000", "19971016T090000"},
+		},
+		{
+			name:  "Week start changes biweekly periods",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			start: "19970805T090000",
+			want:  []string{"19970805T090000", "19970810T090000", "19970819T090000", "19970824T090000"},
+		},
+		{
+			name:  "Biweekly periods starting on Sunday",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			start: "19970805T090000",
+			want:  []string{"19970805T090000", "19970817T090000", "19970819T090000", "19970831T090000"},
+		},
+		{
+			name:  "Monthly on the first Friday for 10 occurrences",
+			rule:  "FREQ=MONTHLY;COUNT=10;BYDAY=1FR",
+			start: "19970905T090000",
+			want: []string{"19970905T090000", "19971003T090000", "19971107T090000", "19971205T090000", "19980102T090000",
+				"19980206T090000", "19980306T090000", "19980403T090000", "19980501T090000", "19980605T090000"},
+		},
+		{
+			name:  "Every other month on the first and last Sunday",
+			rule:  "FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYDAY=1SU,-1SU",
+			start: "19970907T090000",
+			want: []string{"19970907T090000", "19970928T090000", "19971102T090000", "19971130T090000", "19980104T090000",
+				"19980125T090000", "19980301T090000", "19980329T090000", "19980503T090000", "19980531T090000"},
+		},
+		{
+			name:  "Monthly on the second to last Monday",
+			rule:  "FREQ=MONTHLY;COUNT=6;BYDAY=-2MO",
+			start: "19970922T090000",
+			want: []string{"19970922T090000", "19971020T090000", "19971117T090000", "19971222T090000",
+				"19980119T090000", "19980216T090000"},
+		},
+		{
+			name:  "Monthly on the third to last day",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-3",
+			start: "19970928T090000",
+			limit: 6,
+			want: []string{"19970928T090000", "19971029T090000", "19971128T090000", "19971229T090000",
+				"19980129T090000", "19980226T090000"},
+		},
+		{
+			name:  "Monthly on the 2nd and 15th",
+			rule:  "FREQ=MONTHLY;COUNT=10;BYMONTHDAY=2,15",
+			start: "19970902T090000",
+			want: []string{"19970902T090000", "19970915T090000", "19971002T090000", "19971015T090000", "19971102T090000",
+				"19971115T090000", "19971202T090000", "19971215T090000", "19980102T090000", "19980115T090000"},
+		},
+		{
+			name:  "Monthly on the 31st skips shorter months",
+			rule:  "FREQ=MONTHLY;COUNT=4",
+			start: "20260131T100000",
+			want:  []string{"20260131T100000", "20260331T100000", "20260531T100000", "20260731T100000"},
+		},
+		{
+			name:  "Every Friday the 13th",
+			rule:  "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			start: "19970902T090000",
+			limit: 5,
+			want:  []string{"19970902T090000", "19980213T090000", "19980313T090000", "19981113T090000", "19990813T090000"},
+		},
+		{
+			name:  "Last work day of the month",
+			rule:  "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			start: "19970930T090000",
+			limit: 6,
+			want: []string{"19970930T090000", "19971031T090000", "19971128T090000", "19971231T090000",
+				"19980130T090000", "19980227T090000"},
+		},
+		{
+			name:  "Third instance of Tuesday, Wednesday or Thursday",
+			rule:  "FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3",
+			start: "19970904T090000",
+			want:  []string{"19970904T090000", "19971007T090000", "19971106T090000"},
+		},
+		{
+			name:  "Yearly in June and July for 10 occurrences",
+			rule:  "FREQ=YEARLY;COUNT=10;BYMONTH=6,7",
+			start: "19970610T090000",
+			want: []string{"19970610T090000", "19970710T090000", "19980610T090000", "19980710T090000", "19990610T090000",
+				"19990710T090000", "20000610T090000", "20000710T090000", "20010610T090000", "20010710T090000"},
+		},
+		{
+			name:  "Yearly on leap day",
+			rule:  "FREQ=YEARLY;COUNT=3",
+			start: "20240229T070000",
+			want:  []string{"20240229T070000", "20280229T070000", "20320229T070000"},
+		},
+		{
+			name:  "Twentieth Monday of the year",
+			rule:  "FREQ=YEARLY;BYDAY=20MO",
+			start: "19970519T090000",
+			limit: 3,
+			want:  []string{"19970519T090000", "19980518T090000", "19990517T090000"},
+		},
+		{
+			name:  "Every Thursday in March",
+			rule:  "FREQ=YEARLY;BYMONTH=3;BYDAY=TH",
+			start: "19970313T090000",
+			limit: 5,
+			want:  []string{"19970313T090000", "19970320T090000", "19970327T090000", "19980305T090000", "19980312T090000"},
+		},
+		{
+			name:  "US Thanksgiving",
+			rule:  "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			start: "20261126T120000",
+			limit: 3,
+			want:  []string{"20261126T120000", "20271125T120000", "20281123T120000"},
+		},
+		{
+			name:  "Rule without further occurrences",
+			rule:  "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			start: "20260101T090000",
+			limit: 3,
+			want:  []string{"20260101T090000"},
+		},
+		{
+			name:  "Leap day years apart",
+			rule:  "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29",
+			start: "20240229T090000",
+			limit: 3,
+			want:  []string{"20240229T090000", "20280229T090000", "20320229T090000"},
+		},
+		{
+			name:  "Leap day across century",
+			rule:  "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29",
+			start: "20960229T090000",
+			limit: 2,
+			want:  []string{"20960229T090000", "21040229T090000"},
+		},
+		{
+			name:  "Single occurrence",
+			rule:  "FREQ=DAILY;COUNT=1",
+			start: "20260101T090000",
+			want:  []string{"20260101T090000"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			var got []time.Time
+			r.Iterate(dt(t, tt.start), func(tm time.Time) bool {
+				got = append(got, tm)
+				return tt.limit == 0 || len(got) < tt.limit
+			})
+			if want := dts(t, tt.want...); !reflect.DeepEqual(got, want) {
+				t.Errorf("Iterate() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRule_Iterate_location(t *testing.T) {
+	// occurrences keep local time of day across daylight saving change
+	loc := time.FixedZone("", 0)
+	if l, err := time.LoadLocation("Europe/Berlin"); err == nil {
+		loc = l
+	}
+	r, err := Parse("FREQ=DAILY;COUNT=3")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var got []time.Time
+	r.Iterate(time.Date(2026, 10, 24, 9, 30, 0, 0, loc), func(tm time.Time) bool {
+		got = append(got, tm)
+		return true
+	})
+	want := []time.Time{
+		time.Date(2026, 10, 24, 9, 30, 0, 0, loc),
+		time.Date(2026, 10, 25, 9, 30, 0, 0, loc),
+		time.Date(2026, 10, 26, 9, 30, 0, 0, loc),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterate() = %v, want %v", got, want)
+	}
+}
+
+func TestRule_Between(t *testing.T) {
+	r, err := Parse("FREQ=WEEKLY;BYDAY=MO,TH")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	start := dt(t, "20261001T090000")
+
+	tests := []struct {
+		name  string
+		from  string
+		to    string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "Range includes from and excludes to",
+			from:  "20261012T090000",
+			to:    "20261019T090000",
+			limit: 10,
+			want:  []string{"20261012T090000", "20261015T090000"},
+		},
+		{
+			name:  "Range includes start",
+			from:  "20260901T000000",
+			to:    "20261006T000000",
+			limit: 10,
+			want:  []string{"20261001T090000", "20261005T090000"},
+		},
+		{
+			name:  "Limit",
+			from:  "20261001T000000",
+			to:    "20270101T000000",
+			limit: 3,
+			want:  []string{"20261001T090000", "20261005T090000", "20261008T090000"},
+		},
+		{
+			name:  "Empty range",
+			from:  "20261013T000000",
+			to:    "20261015T000000",
+			limit: 10,
+		},
+		{
+			name:  "Range far from start",
+			from:  "30261012T090000",
+			to:    "30261019T090000",
+			limit: 10,
+			want:  []string{"30261012T090000", "30261016T090000"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.Between(start, dt(t, tt.from), dt(t, tt.to), tt.limit)
+			var want []time.Time
+			if len(tt.want) > 0 {
+				want = dts(t, tt.want...)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Between() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRule_Between_skip(t *testing.T) {
+	// periods skipped before from must not change the occurrences
+	tests := []struct {
+		rule  string
+		start string
+	}{
+		{rule: "FREQ=DAILY;INTERVAL=3", start: "20260131T090000"},
+		{rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU;WKST=SU", start: "20260131T090000"},
+		{rule: "FREQ=MONTHLY;INTERVAL=5;BYMONTHDAY=-1", start: "20260131T090000"},
+		{rule: "FREQ=YEARLY;INTERVAL=3;BYMONTH=2;BYDAY=-1FR", start: "20260131T090000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			start := dt(t, tt.start)
+			from, to := dt(t, "20310317T120000"), dt(t, "20340101T000000")
+
+			var want []time.Time
+			r.Iterate(start, func(tm time.Time) bool {
+				if !tm.Before(to) {
+					return false
+				}
+				if !tm.Before(from) {
+					want = append(want, tm)
+				}
+				return true
+			})
+			if got := r.Between(start, from, to, 1000); !reflect.DeepEqual(got, want) {
+				t.Errorf("Between() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRule_Advance(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		start    string
+		wantNext string
+		wantRule string
+		wantOK   bool
+	}{
+		{
+			name:     "Unlimited",
+			rule:     "FREQ=WEEKLY;BYDAY=MO",
+			start:    "20261019T080000",
+			wantNext: "20261026T080000",
+			wantRule: "FREQ=WEEKLY;BYDAY=MO",
+			wantOK:   true,
+		},
+		{
+			name:     "Count decreases",
+			rule:     "FREQ=DAILY;COUNT=3",
+			start:    "20261019T080000",
+			wantNext: "20261020T080000",
+			wantRule: "FREQ=DAILY;COUNT=2",
+			wantOK:   true,
+		},
+		{
+			name:  "Last by count",
+			rule:  "FREQ=DAILY;COUNT=1",
+			start: "20261019T080000",
+		},
+		{
+			name:  "Last by until",
+			rule:  "FREQ=DAILY;UNTIL=20261019T235959Z",
+			start: "20261019T080000",
+		},
+		{
+			name:     "Leap day",
+			rule:     "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29",
+			start:    "20240229T080000",
+			wantNext: "20280229T080000",
+			wantRule: "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29",
+			wantOK:   true,
+		},
+		{
+			name:     "Interval alignment is kept",
+			rule:     "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=1,15",
+			start:    "20260115T080000",
+			wantNext: "20260301T080000",
+			wantRule: "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=1,15",
+			wantOK:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			next, rest, ok := r.Advance(dt(t, tt.start))
+			if ok != tt.wantOK {
+				t.Fatalf("Advance() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if want := dt(t, tt.wantNext); !next.Equal(want) {
+				t.Errorf("Advance() next = %v, want %v", next, want)
+			}
+			if rest.String() != tt.wantRule {
+				t.Errorf("Advance() rule = %v, want %v", rest, tt.wantRule)
+			}
+		})
+	}
+}
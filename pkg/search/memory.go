@@ -0,0 +1,173 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	// bm25K1 and bm25B are parameters of the BM25 ranking, k1 limits effect
+	// of repeated words and b normalizes by document length
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// titleWeight is how many times words of the title count more than
+	// words of the description
+	titleWeight = 2
+)
+
+// memoryDoc is indexed document
+type memoryDoc struct {
+	owner string
+
+	// terms is number of occurrences of the words in the document
+	terms map[string]int
+
+	// length is weighted number of words of the document
+	length int
+}
+
+// MemoryIndex is inverted index kept in memory, documents are ranked by
+// BM25. It is used when todos are not stored in MySQL and in tests.
+type MemoryIndex struct {
+	mu sync.RWMutex
+
+	docs map[int64]*memoryDoc
+
+	// postings are IDs of the documents having the word
+	postings map[string]map[int64]bool
+
+	// totalLength is sum of lengths of all documents
+	totalLength int
+}
+
+// NewMemoryIndex creates empty index
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		docs:     map[int64]*memoryDoc{},
+		postings: map[string]map[int64]bool{},
+	}
+}
+
+// Put adds the documents to the index
+func (m *MemoryIndex) Put(ctx context.Context, docs ...Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, d := range docs {
+		m.remove(d.ID)
+
+		doc := &memoryDoc{owner: d.Owner, terms: map[string]int{}}
+		for _, t := range Tokenize(d.Title) {
+			doc.terms[t] += titleWeight
+			doc.length += titleWeight
+		}
+		for _, t := range Tokenize(d.Description) {
+			doc.terms[t]++
+			doc.length++
+		}
+
+		for t := range doc.terms {
+			if m.postings[t] == nil {
+				m.postings[t] = map[int64]bool{}
+			}
+			m.postings[t][d.ID] = true
+		}
+		m.docs[d.ID] = doc
+		m.totalLength += doc.length
+	}
+	return nil
+}
+
+// Remove removes the documents from the index
+func (m *MemoryIndex) Remove(ctx context.Context, ids ...int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range ids {
+		m.remove(id)
+	}
+	return nil
+}
+
+// Clear removes all documents from the index
+func (m *MemoryIndex) Clear(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.docs = map[int64]*memoryDoc{}
+	m.postings = map[string]map[int64]bool{}
+	m.totalLength = 0
+	return nil
+}
+
+// remove removes the document, lock must be held
+func (m *MemoryIndex) remove(id int64) {
+	doc, ok := m.docs[id]
+	if !ok {
+		return
+	}
+	for t := range doc.terms {
+		delete(m.postings[t], id)
+		if len(m.postings[t]) == 0 {
+			delete(m.postings, t)
+		}
+	}
+	m.totalLength -= doc.length
+	delete(m.docs, id)
+}
+
+// Search ranks documents of the owner having any word of the query
+func (m *MemoryIndex) Search(ctx context.Context, owner, query string, offset, limit int) ([]Hit, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.docs) == 0 {
+		return nil, nil
+	}
+	n := float64(len(m.docs))
+	avgLength := float64(m.totalLength) / n
+
+	scores := map[int64]float64{}
+	seen := map[string]bool{}
+	for _, t := range Tokenize(query) {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+
+		ids := m.postings[t]
+		df := float64(len(ids))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id := range ids {
+			doc := m.docs[id]
+			if doc.owner != owner {
+				continue
+			}
+			tf := float64(doc.terms[t])
+			scores[id] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*float64(doc.length)/avgLength))
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+
+	if offset >= len(hits) {
+		return nil, nil
+	}
+	hits = hits[offset:]
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
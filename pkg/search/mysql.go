@@ -0,0 +1,39 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+)
+
+// MySQLIndex searches todos by FULLTEXT index of the ToDo table, MySQL keeps
+// the index in sync with the todos, so it is not a Writer
+type MySQLIndex struct {
+	db *sql.DB
+}
+
+// NewMySQLIndex creates index searching the ToDo table of the database
+func NewMySQLIndex(db *sql.DB) *MySQLIndex {
+	return &MySQLIndex{db: db}
+}
+
+// Search ranks todos of the owner not in trash by natural language search
+func (m *MySQLIndex) Search(ctx context.Context, owner, query string, offset, limit int) ([]Hit, error) {
+	rows, err := m.db.QueryContext(ctx, "SELECT `ID`, MATCH(`Title`, `Description`) AGAINST(? IN NATURAL LANGUAGE MODE) AS `Score` FROM ToDo "+
+		"WHERE `Owner`=? AND `DeletedAt` IS NULL AND MATCH(`Title`, `Description`) AGAINST(? IN NATURAL LANGUAGE MODE) "+
+		"ORDER BY `Score` DESC, `ID` LIMIT ? OFFSET ?",
+		query, owner, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []Hit
+	for rows.Next() {
+		var h Hit
+		if err := rows.Scan(&h.ID, &h.Score); err != nil {
+			return nil, err
+		}
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
@@ -0,0 +1,172 @@
+package search
+
+import (
+	"context"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// highlightStart and highlightEnd wrap matching words in snippets
+	highlightStart = "<em>"
+	highlightEnd   = "</em>"
+
+	// ellipsis marks text cut off the snippet
+	ellipsis = "…"
+)
+
+// Document is the text of the todo which is searched
+type Document struct {
+	// Owner is the tenant owning the todo, todos are searched within the tenant
+	Owner       string
+	ID          int64
+	Title       string
+	Description string
+}
+
+// Hit is the todo matching the query
+type Hit struct {
+	ID int64
+
+	// Score is relevance of the todo, greater is better, scores of different
+	// indexes or queries are not comparable
+	Score float64
+}
+
+// Index finds todos by words of their title and description
+type Index interface {
+	// Search returns todos of the owner matching words of the query, the best
+	// matching first, skipping offset of them
+	Search(ctx context.Context, owner, query string, offset, limit int) ([]Hit, error)
+}
+
+// Writer is index keeping own copy of the documents, it is told about
+// changes of the todos. Index reading the todos where they are stored does
+// not need it.
+type Writer interface {
+	Index
+
+	// Put adds the documents, documents with the same ID are replaced
+	Put(ctx context.Context, docs ...Document) error
+
+	// Remove removes documents by ID, missing ones are skipped
+	Remove(ctx context.Context, ids ...int64) error
+
+	// Clear removes all documents
+	Clear(ctx context.Context) error
+}
+
+// span is a word of the text
+type span struct {
+	// start and end are byte offsets of the word in the text
+	start, end int
+
+	// term is the word in lower case
+	term string
+}
+
+// spans splits text into words, which are sequences of letters and digits
+func spans(text string) []span {
+	var list []span
+	start := -1
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if word && start < 0 {
+			start = i
+		} else if !word && start >= 0 {
+			list = append(list, span{start: start, end: i, term: strings.ToLower(text[start:i])})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		list = append(list, span{start: start, end: len(text), term: strings.ToLower(text[start:])})
+	}
+	return list
+}
+
+// Tokenize returns words of the text in lower case
+func Tokenize(text string) []string {
+	list := spans(text)
+	terms := make([]string, len(list))
+	for i, s := range list {
+		terms[i] = s.term
+	}
+	return terms
+}
+
+// Snippet returns fragment of the text of about width characters around the
+// first word of the terms, with the words wrapped in <em></em>. The text is
+// HTML escaped. It returns empty string if no word of the text matches.
+func Snippet(text string, terms []string, width int) string {
+	want := make(map[string]bool, len(terms))
+	for _, t := range terms {
+		want[t] = true
+	}
+
+	words := spans(text)
+	first := -1
+	for i, w := range words {
+		if want[w.term] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	// keep up to quarter of the width before the first match as context
+	start := words[first].start
+	for i := first - 1; i >= -1; i-- {
+		from := 0
+		if i >= 0 {
+			from = words[i].start
+		}
+		if utf8.RuneCountInString(text[from:words[first].start]) > width/4 {
+			break
+		}
+		start = from
+	}
+
+	// cut the text after width characters, not in the middle of a word
+	end := len(text)
+	n := 0
+	for i := range text[start:] {
+		if n == width {
+			end = start + i
+			break
+		}
+		n++
+	}
+	for _, w := range words {
+		if w.start < end && w.end > end && w.start > words[first].start {
+			end = w.start
+		}
+	}
+	if end < words[first].end {
+		end = words[first].end
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString(ellipsis)
+	}
+	pos := start
+	for _, w := range words {
+		if w.start < start || w.end > end || !want[w.term] {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:w.start]))
+		b.WriteString(highlightStart)
+		b.WriteString(html.EscapeString(text[w.start:w.end]))
+		b.WriteString(highlightEnd)
+		pos = w.end
+	}
+	b.WriteString(html.EscapeString(strings.TrimRightFunc(text[pos:end], unicode.IsSpace)))
+	if end < len(text) {
+		b.WriteString(ellipsis)
+	}
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package search
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Fix the log-in bug #42, café!")
+	want := []string{"fix", "the", "log", "in", "bug", "42", "café"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %v, want %v", got, want)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		width int
+		want  string
+	}{
+		{
+			name:  "Whole text",
+			text:  "Buy milk and bread",
+			terms: []string{"milk", "bread"},
+			width: 50,
+			want:  "Buy <em>milk</em> and <em>bread</em>",
+		},
+		{
+			name:  "Cut",
+			text:  "Call the plumber about the leaking kitchen sink before the weekend",
+			terms: []string{"kitchen"},
+			width: 32,
+			want:  "…leaking <em>kitchen</em> sink before the…",
+		},
+		{
+			name:  "Escaped",
+			text:  "Check <script> in the page",
+			terms: []string{"script"},
+			width: 50,
+			want:  "Check &lt;<em>script</em>&gt; in the page",
+		},
+		{
+			name:  "No match",
+			text:  "Buy milk",
+			terms: []string{"bread"},
+			width: 50,
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Snippet(tt.text, tt.terms, tt.width); got != tt.want {
+				t.Errorf("Snippet() = '%v', want '%v'", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryIndex(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryIndex()
+	_ = m.Put(ctx,
+		Document{ID: 1, Title: "Fix printer", Description: "The office printer is jammed"},
+		Document{ID: 2, Title: "Buy paper", Description: "Paper for the printer"},
+		Document{ID: 3, Title: "Water plants"},
+		Document{ID: 4, Owner: "team-b", Title: "Fix printer"},
+	)
+
+	ids := func(hits []Hit) []int64 {
+		var list []int64
+		for _, h := range hits {
+			list = append(list, h.ID)
+		}
+		return list
+	}
+
+	// title words rank higher and other tenants are not returned
+	hits, _ := m.Search(ctx, "", "printer", 0, 10)
+	if got := ids(hits); !reflect.DeepEqual(got, []int64{1, 2}) {
+		t.Errorf("MemoryIndex.Search() = %v, want [1 2]", got)
+	}
+
+	hits, _ = m.Search(ctx, "", "printer", 1, 10)
+	if got := ids(hits); !reflect.DeepEqual(got, []int64{2}) {
+		t.Errorf("MemoryIndex.Search() with offset = %v, want [2]", got)
+	}
+
+	// replaced document is found by the new words only
+	_ = m.Put(ctx, Document{ID: 1, Title: "Fix scanner"})
+	hits, _ = m.Search(ctx, "", "printer scanner", 0, 10)
+	if got := ids(hits); !reflect.DeepEqual(got, []int64{1, 2}) {
+		t.Errorf("MemoryIndex.Search() after Put = %v, want [1 2]", got)
+	}
+
+	_ = m.Remove(ctx, 1, 5)
+	hits, _ = m.Search(ctx, "", "scanner", 0, 10)
+	if len(hits) != 0 {
+		t.Errorf("MemoryIndex.Search() after Remove = %v, want none", ids(hits))
+	}
+
+	_ = m.Clear(ctx)
+	hits, _ = m.Search(ctx, "", "printer", 0, 10)
+	if len(hits) != 0 {
+		t.Errorf("MemoryIndex.Search() after Clear = %v, want none", ids(hits))
+	}
+}
+
+func TestMySQLIndex(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT `ID`, MATCH\\(`Title`, `Description`\\) AGAINST\\(\\? IN NATURAL LANGUAGE MODE\\) AS `Score` FROM ToDo "+
+		"WHERE `Owner`=\\? AND `DeletedAt` IS NULL").
+		WithArgs("printer", "team-a", "printer", 2, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Score"}).AddRow(3, 1.5).AddRow(1, 0.5))
+
+	hits, err := NewMySQLIndex(db).Search(context.Background(), "team-a", "printer", 4, 2)
+	if err != nil {
+		t.Fatalf("MySQLIndex.Search() error = %v", err)
+	}
+	if want := []Hit{{ID: 3, Score: 1.5}, {ID: 1, Score: 0.5}}; !reflect.DeepEqual(hits, want) {
+		t.Errorf("MySQLIndex.Search() = %v, want %v", hits, want)
+	}
+}
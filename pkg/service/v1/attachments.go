@@ -0,0 +1,436 @@
+package v1
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/blob"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/logger"
+)
+
+const (
+	// defaultMaxAttachmentSize is used when maximum size of attached file is
+	// not configured
+	defaultMaxAttachmentSize = 10 << 20
+
+	// maxFileNameLength is the maximum length of the attached file name
+	maxFileNameLength = 255
+
+	// attachmentChunkSize is size of the chunks attached file is downloaded in
+	attachmentChunkSize = 64 << 10
+
+	// fileNameHeader is header metadata key DownloadAttachment sends the file
+	// name in, binary header allows any characters in the name
+	fileNameHeader = "file-name-bin"
+)
+
+// defaultAttachmentTypes are content types of the files accepted when they
+// are not configured, screenshots and documents
+var defaultAttachmentTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"application/pdf",
+	"text/plain",
+}
+
+// WithBlobStore sets store of the attached files, attachments are not
+// supported without it
+func WithBlobStore(store blob.Store) Option {
+	return func(s *todoServiceServer) {
+		s.blobs = store
+	}
+}
+
+// WithAttachmentLimits sets maximum size of the attached file in bytes and
+// accepted content types, zero size and empty list of types keep defaults
+func WithAttachmentLimits(maxSize int64, contentTypes []string) Option {
+	return func(s *todoServiceServer) {
+		if maxSize > 0 {
+			s.maxAttachmentSize = maxSize
+		}
+		if len(contentTypes) > 0 {
+			s.attachmentTypes = attachmentTypeSet(contentTypes)
+		}
+	}
+}
+
+// attachmentTypeSet returns set of the content types without parameters
+func attachmentTypeSet(contentTypes []string) map[string]bool {
+	set := make(map[string]bool, len(contentTypes))
+	for _, t := range contentTypes {
+		if mediaType, _, err := mime.ParseMediaType(t); err == nil {
+			set[mediaType] = true
+		}
+	}
+	return set
+}
+
+// normalizeFileName strips directories from the file name sent by client
+// and checks it
+func normalizeFileName(name string) (string, error) {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || name == "." || name == ".." {
+		return "", status.Error(codes.InvalidArgument, "file_name field must not be empty")
+	}
+	if utf8.RuneCountInString(name) > maxFileNameLength {
+		return "", status.Errorf(codes.InvalidArgument, "file_name field is longer than %d characters", maxFileNameLength)
+	}
+	if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return "", status.Error(codes.InvalidArgument, "file_name field has control characters")
+	}
+	return name, nil
+}
+
+// checkContentType checks the content type of the uploaded file is accepted
+// and returns it normalized
+func (s *todoServiceServer) checkContentType(contentType string) (string, error) {
+	if len(contentType) == 0 {
+		return "", status.Error(codes.InvalidArgument, "content_type field of the first chunk must not be empty")
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "content_type field is invalid-> "+err.Error())
+	}
+	if !s.attachmentTypes[mediaType] {
+		return "", status.Errorf(codes.InvalidArgument, "content type '%s' is not accepted for attachments", mediaType)
+	}
+	return mime.FormatMediaType(mediaType, params), nil
+}
+
+// newBlobKey generates random key the attached file is stored under
+func newBlobKey() (string, error) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return "", status.Error(codes.Unknown, "failed to generate blob key-> "+err.Error())
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// uploadReader reads the uploaded file from the stream of chunks and fails
+// when it is larger than the limit
+type uploadReader struct {
+	stream  v1.TodoService_UploadAttachmentServer
+	data    []byte
+	size    int64
+	maxSize int64
+
+	// err is error of the stream or the limit, it is returned to client as is
+	err error
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		req, err := r.stream.Recv()
+		if err == io.EOF {
+			return 0, io.EOF
+		}
+		if err != nil {
+			r.err = err
+			return 0, err
+		}
+		r.data = req.GetChunk().GetData()
+	}
+
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	r.size += int64(n)
+	if r.size > r.maxSize {
+		r.err = status.Errorf(codes.InvalidArgument, "file is larger than %d bytes", r.maxSize)
+		return 0, r.err
+	}
+	return n, nil
+}
+
+// attachmentColumns is list of ToDoAttachment columns in the order
+// scanAttachment reads them
+const attachmentColumns = "`ID`, `ToDoID`, `FileName`, `ContentType`, `Size`, `CreatedAt`, `BlobKey`"
+
+// scanAttachment reads attachment and its blob key from the current row of
+// the result set
+func scanAttachment(rows *sql.Rows) (*v1.Attachment, string, error) {
+	var a v1.Attachment
+	var createdAt time.Time
+	var key string
+	if err := rows.Scan(&a.Id, &a.TodoId, &a.FileName, &a.ContentType, &a.Size, &createdAt, &key); err != nil {
+		return nil, "", status.Error(codes.Unknown, "failed to retrieve field values from ToDoAttachment row-> "+err.Error())
+	}
+
+	var err error
+	if a.CreateTime, err = ptypes.TimestampProto(createdAt); err != nil {
+		return nil, "", status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+	}
+	return &a, key, nil
+}
+
+// readAttachment checks the ToDo and returns its attachment with the blob
+// key, lock locks the attachment until the end of the transaction
+func readAttachment(ctx context.Context, q querier, todoID, id int64, lock bool) (*v1.Attachment, string, error) {
+	if err := checkTodo(ctx, q, todoID); err != nil {
+		return nil, "", err
+	}
+
+	query := "SELECT " + attachmentColumns + " FROM ToDoAttachment WHERE `ID`=? AND `ToDoID`=?"
+	if lock {
+		query += " FOR UPDATE"
+	}
+	rows, err := q.QueryContext(ctx, query, id, todoID)
+	if err != nil {
+		return nil, "", status.Error(codes.Unknown, "failed to select from ToDoAttachment-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, "", status.Error(codes.Unknown, "failed to retrieve data from ToDoAttachment-> "+err.Error())
+		}
+		return nil, "", status.Error(codes.NotFound, fmt.Sprintf("Attachment with ID='%d' is not found",
+			id))
+	}
+	return scanAttachment(rows)
+}
+
+// UploadAttachment stores file streamed in chunks and attaches it to todo task
+func (s *todoServiceServer) UploadAttachment(stream v1.TodoService_UploadAttachmentServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "no chunk of the file is received")
+	}
+	if err != nil {
+		return err
+	}
+
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(first.Api); err != nil {
+		return err
+	}
+
+	if s.blobs == nil {
+		return status.Error(codes.Unimplemented, "attachments are not enabled on this server")
+	}
+
+	fileName, err := normalizeFileName(first.FileName)
+	if err != nil {
+		return err
+	}
+	contentType, err := s.checkContentType(first.GetChunk().GetContentType())
+	if err != nil {
+		return err
+	}
+
+	// connection is taken from the pool for each query only, as the upload
+	// may take long
+	if err := checkTodo(ctx, s.db, first.TodoId); err != nil {
+		return err
+	}
+
+	key, err := newBlobKey()
+	if err != nil {
+		return err
+	}
+
+	r := &uploadReader{stream: stream, data: first.GetChunk().GetData(), maxSize: s.maxAttachmentSize}
+	if err := s.blobs.Put(ctx, key, r); err != nil {
+		if r.err != nil {
+			return r.err
+		}
+		return status.Error(codes.Unknown, "failed to store attachment-> "+err.Error())
+	}
+
+	now := s.now().UTC()
+	res, err := s.db.ExecContext(ctx, "INSERT INTO ToDoAttachment(`ToDoID`, `FileName`, `ContentType`, `Size`, `BlobKey`, `CreatedAt`) VALUES(?, ?, ?, ?, ?, ?)",
+		first.TodoId, fileName, contentType, r.size, key, now)
+	if err != nil {
+		_ = s.blobs.Delete(ctx, key)
+		return status.Error(codes.Unknown, "failed to insert into ToDoAttachment-> "+err.Error())
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to retrieve id for created ToDoAttachment-> "+err.Error())
+	}
+
+	createTime, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+	}
+
+	return stream.SendAndClose(&v1.UploadAttachmentResponse{
+		Api: apiVersion,
+		Attachment: &v1.Attachment{
+			Id:          id,
+			TodoId:      first.TodoId,
+			FileName:    fileName,
+			ContentType: contentType,
+			Size:        r.size,
+			CreateTime:  createTime,
+		},
+	})
+}
+
+// DownloadAttachment streams attached file in chunks
+func (s *todoServiceServer) DownloadAttachment(req *v1.DownloadAttachmentRequest, stream v1.TodoService_DownloadAttachmentServer) error {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return err
+	}
+
+	if s.blobs == nil {
+		return status.Error(codes.Unimplemented, "attachments are not enabled on this server")
+	}
+
+	ctx := stream.Context()
+	a, key, err := readAttachment(ctx, s.db, req.TodoId, req.Id, false)
+	if err != nil {
+		return err
+	}
+
+	r, err := s.blobs.Get(ctx, key)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to open attachment-> "+err.Error())
+	}
+	defer r.Close()
+
+	if err := stream.SendHeader(metadata.Pairs(fileNameHeader, a.FileName)); err != nil {
+		return err
+	}
+
+	// content type is sent with the first chunk, which is sent for empty file too
+	buf := make([]byte, attachmentChunkSize)
+	for first := true; ; first = false {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 || first {
+			chunk := &httpbody.HttpBody{Data: buf[:n]}
+			if first {
+				chunk.ContentType = a.ContentType
+			}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to read attachment-> "+err.Error())
+		}
+	}
+}
+
+// ListAttachments returns files attached to todo task from the oldest one
+func (s *todoServiceServer) ListAttachments(ctx context.Context, req *v1.ListAttachmentsRequest) (*v1.ListAttachmentsResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := checkTodo(ctx, c, req.TodoId); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.QueryContext(ctx, "SELECT "+attachmentColumns+" FROM ToDoAttachment WHERE `ToDoID`=? ORDER BY `ID`",
+		req.TodoId)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoAttachment-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.Attachment{}
+	for rows.Next() {
+		a, _, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoAttachment-> "+err.Error())
+	}
+
+	return &v1.ListAttachmentsResponse{
+		Api:         apiVersion,
+		Attachments: list,
+	}, nil
+}
+
+// DeleteAttachment removes file attached to todo task
+func (s *todoServiceServer) DeleteAttachment(ctx context.Context, req *v1.DeleteAttachmentRequest) (*v1.DeleteAttachmentResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if s.blobs == nil {
+		return nil, status.Error(codes.Unimplemented, "attachments are not enabled on this server")
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	tx, err := s.begin(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	a, key, err := readAttachment(ctx, tx, req.TodoId, req.Id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM ToDoAttachment WHERE `ID`=?", a.Id); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to delete ToDoAttachment-> "+err.Error())
+	}
+
+	if err := s.commit(tx); err != nil {
+		return nil, err
+	}
+
+	// content is removed after commit, so that the attachment is never listed
+	// without it. If it fails the content is orphaned, it is logged to be
+	// removed later.
+	if err := s.blobs.Delete(ctx, key); err != nil {
+		logger.Log.Error("failed to remove content of deleted attachment, it is orphaned",
+			zap.String("key", key), zap.String("reason", err.Error()))
+	}
+
+	return &v1.DeleteAttachmentResponse{
+		Api:     apiVersion,
+		Deleted: 1,
+	}, nil
+}
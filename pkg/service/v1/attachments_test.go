@@ -0,0 +1,283 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/blob"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/logger"
+)
+
+// memBlobs keeps blobs in memory
+type memBlobs map[string][]byte
+
+func (m memBlobs) Put(ctx context.Context, key string, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	m[key] = data
+	return nil
+}
+
+func (m memBlobs) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := m[key]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m memBlobs) Delete(ctx context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+// brokenBlobs fails to remove blobs
+type brokenBlobs struct {
+	memBlobs
+}
+
+func (b brokenBlobs) Delete(ctx context.Context, key string) error {
+	return errors.New("disk is read-only")
+}
+
+// uploadStream feeds chunks to UploadAttachment and collects the response
+type uploadStream struct {
+	grpc.ServerStream
+	chunks []*v1.UploadAttachmentRequest
+	resp   *v1.UploadAttachmentResponse
+}
+
+func (s *uploadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *uploadStream) Recv() (*v1.UploadAttachmentRequest, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	req := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return req, nil
+}
+
+func (s *uploadStream) SendAndClose(resp *v1.UploadAttachmentResponse) error {
+	s.resp = resp
+	return nil
+}
+
+// downloadStream collects chunks sent by DownloadAttachment
+type downloadStream struct {
+	grpc.ServerStream
+	header metadata.MD
+	chunks []*httpbody.HttpBody
+}
+
+func (s *downloadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *downloadStream) SendHeader(md metadata.MD) error {
+	s.header = md
+	return nil
+}
+
+func (s *downloadStream) Send(chunk *httpbody.HttpBody) error {
+	s.chunks = append(s.chunks, &httpbody.HttpBody{ContentType: chunk.ContentType, Data: append([]byte(nil), chunk.Data...)})
+	return nil
+}
+
+func Test_toDoServiceServer_UploadAttachment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	blobs := memBlobs{}
+	s := NewTodoServiceServer(db, WithBlobStore(blobs), WithAttachmentLimits(8, []string{"image/png", "text/plain"}))
+
+	tests := []struct {
+		name     string
+		chunks   []*v1.UploadAttachmentRequest
+		mock     func()
+		wantCode codes.Code
+	}{
+		{
+			name: "OK",
+			chunks: []*v1.UploadAttachmentRequest{
+				{TodoId: 1, FileName: `C:\shots\screen.png`, Chunk: &httpbody.HttpBody{ContentType: "image/png", Data: []byte("abc")}},
+				{Chunk: &httpbody.HttpBody{Data: []byte("def")}},
+			},
+			mock: func() {
+				expectCheckTodo(mock, 1, true)
+				mock.ExpectExec("INSERT INTO ToDoAttachment").
+					WithArgs(1, "screen.png", "image/png", 6, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(4, 1))
+			},
+		},
+		{
+			name: "Content type not accepted",
+			chunks: []*v1.UploadAttachmentRequest{
+				{TodoId: 1, FileName: "run.exe", Chunk: &httpbody.HttpBody{ContentType: "application/octet-stream", Data: []byte("abc")}},
+			},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Too large",
+			chunks: []*v1.UploadAttachmentRequest{
+				{TodoId: 1, FileName: "notes.txt", Chunk: &httpbody.HttpBody{ContentType: "text/plain", Data: []byte("abcdef")}},
+				{Chunk: &httpbody.HttpBody{Data: []byte("ghi")}},
+			},
+			mock: func() {
+				expectCheckTodo(mock, 1, true)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "ToDo not found",
+			chunks: []*v1.UploadAttachmentRequest{
+				{TodoId: 2, FileName: "notes.txt", Chunk: &httpbody.HttpBody{ContentType: "text/plain; charset=utf-8"}},
+			},
+			mock: func() {
+				expectCheckTodo(mock, 2, false)
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			stream := &uploadStream{chunks: tt.chunks}
+			err := s.UploadAttachment(stream)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("toDoServiceServer.UploadAttachment() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			a := stream.resp.Attachment
+			if a.Id != 4 || a.FileName != "screen.png" || a.Size != 6 {
+				t.Errorf("toDoServiceServer.UploadAttachment() = %v", a)
+			}
+		})
+	}
+
+	// only the uploaded file is stored
+	if len(blobs) != 1 {
+		t.Errorf("toDoServiceServer.UploadAttachment() stored %d blobs, want 1", len(blobs))
+	}
+	for _, data := range blobs {
+		if string(data) != "abcdef" {
+			t.Errorf("toDoServiceServer.UploadAttachment() stored '%s', want 'abcdef'", data)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_DownloadAttachment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	content := strings.Repeat("x", attachmentChunkSize+10)
+	s := NewTodoServiceServer(db, WithBlobStore(memBlobs{"key1": []byte(content)}))
+
+	expectCheckTodo(mock, 1, true)
+	mock.ExpectQuery("SELECT (.+) FROM ToDoAttachment WHERE `ID`=\\? AND `ToDoID`=\\?$").WithArgs(4, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "ToDoID", "FileName", "ContentType", "Size", "CreatedAt", "BlobKey"}).
+			AddRow(4, 1, "notes.txt", "text/plain", len(content), time.Now(), "key1"))
+
+	stream := &downloadStream{}
+	if err := s.DownloadAttachment(&v1.DownloadAttachmentRequest{Api: "v1", TodoId: 1, Id: 4}, stream); err != nil {
+		t.Fatalf("toDoServiceServer.DownloadAttachment() error = %v", err)
+	}
+
+	if names := stream.header.Get(fileNameHeader); len(names) != 1 || names[0] != "notes.txt" {
+		t.Errorf("toDoServiceServer.DownloadAttachment() file name = %v, want [notes.txt]", names)
+	}
+	if len(stream.chunks) != 2 || stream.chunks[0].ContentType != "text/plain" || stream.chunks[1].ContentType != "" {
+		t.Fatalf("toDoServiceServer.DownloadAttachment() sent %d chunks, want 2 with content type in the first", len(stream.chunks))
+	}
+	if got := string(stream.chunks[0].Data) + string(stream.chunks[1].Data); got != content {
+		t.Errorf("toDoServiceServer.DownloadAttachment() sent %d bytes, want %d", len(got), len(content))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_DeleteAttachment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	blobs := memBlobs{"key1": []byte("content")}
+	s := NewTodoServiceServer(db, WithBlobStore(blobs))
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	expectCheckTodo(mock, 1, true)
+	mock.ExpectQuery("SELECT (.+) FROM ToDoAttachment WHERE `ID`=\\? AND `ToDoID`=\\? FOR UPDATE").WithArgs(4, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "ToDoID", "FileName", "ContentType", "Size", "CreatedAt", "BlobKey"}).
+			AddRow(4, 1, "notes.txt", "text/plain", 7, time.Now(), "key1"))
+	mock.ExpectExec("DELETE FROM ToDoAttachment WHERE `ID`=\\?").WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	got, err := s.DeleteAttachment(ctx, &v1.DeleteAttachmentRequest{Api: "v1", TodoId: 1, Id: 4})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.DeleteAttachment() error = %v", err)
+	}
+	if got.Deleted != 1 {
+		t.Errorf("toDoServiceServer.DeleteAttachment() deleted = %v, want %v", got.Deleted, 1)
+	}
+	if _, ok := blobs["key1"]; ok {
+		t.Errorf("toDoServiceServer.DeleteAttachment() did not remove the blob")
+	}
+
+	// attachment is deleted even if its content is left orphaned
+	logger.Log = zap.NewNop()
+	s = NewTodoServiceServer(db, WithBlobStore(brokenBlobs{memBlobs{"key1": []byte("content")}}))
+	mock.ExpectBegin()
+	expectCheckTodo(mock, 1, true)
+	mock.ExpectQuery("SELECT (.+) FROM ToDoAttachment WHERE `ID`=\\? AND `ToDoID`=\\? FOR UPDATE").WithArgs(4, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "ToDoID", "FileName", "ContentType", "Size", "CreatedAt", "BlobKey"}).
+			AddRow(4, 1, "notes.txt", "text/plain", 7, time.Now(), "key1"))
+	mock.ExpectExec("DELETE FROM ToDoAttachment WHERE `ID`=\\?").WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	if got, err := s.DeleteAttachment(ctx, &v1.DeleteAttachmentRequest{Api: "v1", TodoId: 1, Id: 4}); err != nil || got.Deleted != 1 {
+		t.Errorf("toDoServiceServer.DeleteAttachment() = %v, %v, want 1 deleted", got, err)
+	}
+
+	// attachments are disabled without blob store
+	s = NewTodoServiceServer(db)
+	if _, err := s.DeleteAttachment(ctx, &v1.DeleteAttachmentRequest{Api: "v1", TodoId: 1, Id: 4}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("toDoServiceServer.DeleteAttachment() error = %v, wantCode %v", err, codes.Unimplemented)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,269 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// maxBatchSize is the maximum number of items in the batch request
+	maxBatchSize = 1000
+)
+
+// batchItem is validated item of the batch request
+type batchItem struct {
+	// err is validation error of the item, run is not set when it is present
+	err error
+
+	// run applies the item in the batch transaction
+	run func(ctx context.Context, q querier) (*v1.BatchResult, error)
+}
+
+// batchError returns error of the batch failed because of the item at index i
+func batchError(i int, err error) error {
+	st := status.Convert(err)
+	return status.Error(st.Code(), fmt.Sprintf("requests[%d]-> %s", i, st.Message()))
+}
+
+// runBatch applies items in a single transaction and sets results, which
+// belong to resp. In all-or-nothing mode the first failed item rolls back
+// whole batch and its error is returned. In best-effort mode changes of the
+// failed item are rolled back to savepoint and its status is reported in the
+// result. If the request has idempotency key, resp is stored for retries and
+// stored one is decoded into it on retry.
+func (s *todoServiceServer) runBatch(ctx context.Context, idem *idempotencyKey, bestEffort bool, items []batchItem,
+	resp proto.Message, results *[]*v1.BatchResult) error {
+	if !bestEffort {
+		// nothing is written if any item is invalid
+		for i, item := range items {
+			if item.err != nil {
+				return batchError(i, item.err)
+			}
+		}
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	tx, err := s.begin(ctx, c)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if replayed, err := s.replay(ctx, tx, idem, resp); err != nil || replayed {
+		return err
+	}
+
+	list := make([]*v1.BatchResult, len(items))
+	for i, item := range items {
+		if item.err != nil {
+			list[i] = &v1.BatchResult{Status: status.Convert(item.err).Proto()}
+			continue
+		}
+
+		if !bestEffort {
+			res, err := item.run(ctx, tx)
+			if err != nil {
+				return batchError(i, err)
+			}
+			res.Status = status.New(codes.OK, "").Proto()
+			list[i] = res
+			continue
+		}
+
+		// savepoint with the same name replaces the previous one
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT batch_item"); err != nil {
+			return status.Error(codes.Unknown, "failed to create savepoint-> "+err.Error())
+		}
+
+		res, err := item.run(ctx, tx)
+		if err != nil {
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT batch_item"); err != nil {
+				return status.Error(codes.Unknown, "failed to rollback to savepoint-> "+err.Error())
+			}
+			list[i] = &v1.BatchResult{Status: status.Convert(err).Proto()}
+			continue
+		}
+		res.Status = status.New(codes.OK, "").Proto()
+		list[i] = res
+	}
+
+	*results = list
+	if err := s.storeResponse(ctx, tx, idem, resp); err != nil {
+		return err
+	}
+
+	return s.commit(tx)
+}
+
+// checkBatchSize validates number of items in the batch request
+func checkBatchSize(n int) error {
+	if n > maxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "batch contains %d requests, maximum is %d", n, maxBatchSize)
+	}
+	return nil
+}
+
+// BatchCreate creates todo tasks in a single transaction
+func (s *todoServiceServer) BatchCreate(ctx context.Context, req *v1.BatchCreateRequest) (*v1.BatchCreateResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if err := checkBatchSize(len(req.Requests)); err != nil {
+		return nil, err
+	}
+
+	payload := *req
+	payload.Api, payload.IdempotencyKey = "", ""
+	idem, err := newIdempotencyKey(ctx, req.IdempotencyKey, "BatchCreate", &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]batchItem, len(req.Requests))
+	for i, r := range req.Requests {
+		if err := s.checkAPI(r.Api); err != nil {
+			items[i].err = err
+			continue
+		}
+
+		if len(r.IdempotencyKey) > 0 {
+			items[i].err = status.Error(codes.InvalidArgument, "idempotency_key field must be set on the batch request")
+			continue
+		}
+
+		ins, err := prepareCreate(r.Todo)
+		if err != nil {
+			items[i].err = err
+			continue
+		}
+
+		items[i].run = func(ctx context.Context, q querier) (*v1.BatchResult, error) {
+			id, err := s.insert(ctx, q, ins)
+			if err != nil {
+				return nil, err
+			}
+			// new ToDo starts with the first version
+			return &v1.BatchResult{Id: id, Etag: formatETag(1)}, nil
+		}
+	}
+
+	resp := &v1.BatchCreateResponse{Api: apiVersion}
+	if err := s.runBatch(ctx, idem, req.BestEffort, items, resp, &resp.Results); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// BatchUpdate updates todo tasks in a single transaction
+func (s *todoServiceServer) BatchUpdate(ctx context.Context, req *v1.BatchUpdateRequest) (*v1.BatchUpdateResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if err := checkBatchSize(len(req.Requests)); err != nil {
+		return nil, err
+	}
+
+	payload := *req
+	payload.Api, payload.IdempotencyKey = "", ""
+	idem, err := newIdempotencyKey(ctx, req.IdempotencyKey, "BatchUpdate", &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]batchItem, len(req.Requests))
+	for i, r := range req.Requests {
+		if err := s.checkAPI(r.Api); err != nil {
+			items[i].err = err
+			continue
+		}
+
+		var etag string
+		if r.Todo != nil {
+			etag = r.Todo.Etag
+		}
+		u, err := s.prepareUpdate(r, etag)
+		if err != nil {
+			items[i].err = err
+			continue
+		}
+
+		items[i].run = func(ctx context.Context, q querier) (*v1.BatchResult, error) {
+			version, err := s.update(ctx, q, u)
+			if err != nil {
+				return nil, err
+			}
+			return &v1.BatchResult{Id: u.id, Etag: formatETag(version)}, nil
+		}
+	}
+
+	resp := &v1.BatchUpdateResponse{Api: apiVersion}
+	if err := s.runBatch(ctx, idem, req.BestEffort, items, resp, &resp.Results); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// BatchDelete moves todo tasks to trash in a single transaction
+func (s *todoServiceServer) BatchDelete(ctx context.Context, req *v1.BatchDeleteRequest) (*v1.BatchDeleteResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if err := checkBatchSize(len(req.Requests)); err != nil {
+		return nil, err
+	}
+
+	payload := *req
+	payload.Api, payload.IdempotencyKey = "", ""
+	idem, err := newIdempotencyKey(ctx, req.IdempotencyKey, "BatchDelete", &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]batchItem, len(req.Requests))
+	for i, r := range req.Requests {
+		if err := s.checkAPI(r.Api); err != nil {
+			items[i].err = err
+			continue
+		}
+
+		if _, _, err := checkETag(r.Etag); err != nil {
+			items[i].err = err
+			continue
+		}
+
+		id, etag := r.Id, r.Etag
+		items[i].run = func(ctx context.Context, q querier) (*v1.BatchResult, error) {
+			if err := s.delete(ctx, q, id, etag); err != nil {
+				return nil, err
+			}
+			return &v1.BatchResult{Id: id}, nil
+		}
+	}
+
+	resp := &v1.BatchDeleteResponse{Api: apiVersion}
+	if err := s.runBatch(ctx, idem, req.BestEffort, items, resp, &resp.Results); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,263 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// batchCodes returns status codes of the batch results
+func batchCodes(results []*v1.BatchResult) []codes.Code {
+	list := make([]codes.Code, len(results))
+	for i, r := range results {
+		list[i] = codes.Code(r.Status.GetCode())
+	}
+	return list
+}
+
+func Test_toDoServiceServer_BatchCreate(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+	invalid := &timestamp.Timestamp{Seconds: 1, Nanos: -1}
+
+	create := func(title string, reminder *timestamp.Timestamp) *v1.CreateRequest {
+		return &v1.CreateRequest{Todo: &v1.Todo{Title: title, Reminder: reminder}}
+	}
+
+	tests := []struct {
+		name      string
+		req       *v1.BatchCreateRequest
+		mock      func()
+		wantIDs   []int64
+		wantCodes []codes.Code
+		wantCode  codes.Code
+	}{
+		{
+			name: "All or nothing",
+			req: &v1.BatchCreateRequest{
+				Api:      "v1",
+				Requests: []*v1.CreateRequest{create("first", reminder), create("second", reminder)},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("first", "", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecordChange(mock, 1, 1)
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("second", "", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				expectRecordChange(mock, 2, 1)
+				mock.ExpectCommit()
+			},
+			wantIDs:   []int64{1, 2},
+			wantCodes: []codes.Code{codes.OK, codes.OK},
+		},
+		{
+			name: "Invalid item rejects batch",
+			req: &v1.BatchCreateRequest{
+				Api:      "v1",
+				Requests: []*v1.CreateRequest{create("first", reminder), create("second", invalid)},
+			},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Failed item rolls back batch",
+			req: &v1.BatchCreateRequest{
+				Api:      "v1",
+				Requests: []*v1.CreateRequest{create("first", reminder), create("second", reminder)},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("first", "", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecordChange(mock, 1, 1)
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("second", "", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
+					WillReturnError(errors.New("INSERT failed"))
+				mock.ExpectRollback()
+			},
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "Best effort",
+			req: &v1.BatchCreateRequest{
+				Api:        "v1",
+				Requests:   []*v1.CreateRequest{create("first", reminder), create("second", invalid), create("third", reminder)},
+				BestEffort: true,
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("first", "", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
+					WillReturnError(errors.New("INSERT failed"))
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("third", "", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				expectRecordChange(mock, 3, 1)
+				mock.ExpectCommit()
+			},
+			wantIDs:   []int64{0, 0, 3},
+			wantCodes: []codes.Code{codes.Unknown, codes.InvalidArgument, codes.OK},
+		},
+		{
+			name: "Too many requests",
+			req: &v1.BatchCreateRequest{
+				Api:      "v1",
+				Requests: make([]*v1.CreateRequest, maxBatchSize+1),
+			},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := s.BatchCreate(ctx, tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("toDoServiceServer.BatchCreate() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+			if err != nil {
+				return
+			}
+			var ids []int64
+			for _, r := range got.Results {
+				ids = append(ids, r.Id)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("toDoServiceServer.BatchCreate() ids = %v, want %v", ids, tt.wantIDs)
+			}
+			if gotCodes := batchCodes(got.Results); !reflect.DeepEqual(gotCodes, tt.wantCodes) {
+				t.Errorf("toDoServiceServer.BatchCreate() codes = %v, want %v", gotCodes, tt.wantCodes)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_BatchUpdate(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+
+	update := func(id int64, title, etag string) *v1.UpdateRequest {
+		return &v1.UpdateRequest{
+			Todo:       &v1.Todo{Id: id, Title: title, Etag: etag},
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+		}
+	}
+
+	mock.ExpectBegin()
+	expectLock(mock, 1, 1)
+	mock.ExpectExec("UPDATE ToDo SET `Title`=\\?").WithArgs("first", 1).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	expectRecordChange(mock, 1, 2)
+	expectLock(mock, 2, 2)
+	mock.ExpectExec("UPDATE ToDo SET `Title`=\\?").WithArgs("second", 2, 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT `Version` FROM ToDo").WithArgs(2, "").
+		WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(4))
+	mock.ExpectRollback()
+
+	_, err = s.BatchUpdate(ctx, &v1.BatchUpdateRequest{
+		Api:      "v1",
+		Requests: []*v1.UpdateRequest{update(1, "first", ""), update(2, "second", `"3"`)},
+	})
+	if code := status.Code(err); code != codes.Aborted {
+		t.Errorf("toDoServiceServer.BatchUpdate() error = %v, wantCode %v", err, codes.Aborted)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	expectLock(mock, 1, 1)
+	mock.ExpectExec("UPDATE ToDo SET `Title`=\\?").WithArgs("first", 1).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	expectRecordChange(mock, 1, 2)
+	mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	expectLock(mock, 2, 2)
+	mock.ExpectExec("UPDATE ToDo SET `Title`=\\?").WithArgs("second", 2, 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT `Version` FROM ToDo").WithArgs(2, "").
+		WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(4))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	got, err := s.BatchUpdate(ctx, &v1.BatchUpdateRequest{
+		Api:        "v1",
+		Requests:   []*v1.UpdateRequest{update(1, "first", ""), update(2, "second", `"3"`)},
+		BestEffort: true,
+	})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.BatchUpdate() error = %v", err)
+	}
+	if gotCodes := batchCodes(got.Results); !reflect.DeepEqual(gotCodes, []codes.Code{codes.OK, codes.Aborted}) {
+		t.Errorf("toDoServiceServer.BatchUpdate() codes = %v", gotCodes)
+	}
+	if got.Results[0].Id != 1 || got.Results[0].Etag != `"2"` {
+		t.Errorf("toDoServiceServer.BatchUpdate() first result = %v", got.Results[0])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_BatchDelete(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	expectLock(mock, 1, 1)
+	mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectRecordChange(mock, 1, 2)
+	mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	expectLock(mock, 2, 0)
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	got, err := s.BatchDelete(ctx, &v1.BatchDeleteRequest{
+		Api:        "v1",
+		Requests:   []*v1.DeleteRequest{{Id: 1}, {Id: 2}, {Id: 3, Etag: "abc"}},
+		BestEffort: true,
+	})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.BatchDelete() error = %v", err)
+	}
+	want := []codes.Code{codes.OK, codes.NotFound, codes.InvalidArgument}
+	if gotCodes := batchCodes(got.Results); !reflect.DeepEqual(gotCodes, want) {
+		t.Errorf("toDoServiceServer.BatchDelete() codes = %v, want %v", gotCodes, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,277 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/ical"
+)
+
+const (
+	// calendarContentType is media type of exported calendars
+	calendarContentType = "text/calendar; charset=utf-8"
+
+	// calendarProductID identifies the service as the creator of exported
+	// calendars
+	calendarProductID = "-//Lilanga//go-grpc-http-rest-microservice//EN"
+)
+
+var (
+	// calendarStatuses maps status of the todo to STATUS of the VTODO
+	calendarStatuses = map[v1.Todo_Status]string{
+		v1.Todo_OPEN:        "NEEDS-ACTION",
+		v1.Todo_IN_PROGRESS: "IN-PROCESS",
+		v1.Todo_DONE:        "COMPLETED",
+	}
+
+	// todoStatuses maps STATUS of the VTODO to status of the todo, cancelled
+	// VTODO needs no more work, so it is done
+	todoStatuses = map[string]v1.Todo_Status{
+		"":             v1.Todo_OPEN,
+		"NEEDS-ACTION": v1.Todo_OPEN,
+		"IN-PROCESS":   v1.Todo_IN_PROGRESS,
+		"COMPLETED":    v1.Todo_DONE,
+		"CANCELLED":    v1.Todo_DONE,
+	}
+)
+
+// exportUID returns ID of the exported todo, which is the external ID of
+// the todo created when the file is imported
+func exportUID(td *v1.Todo) string {
+	if len(td.ExternalId) > 0 {
+		return td.ExternalId
+	}
+	return fmt.Sprintf("todo-%d", td.Id)
+}
+
+// vtodo renders the todo as VTODO component, stamp is the time the
+// calendar is created
+func vtodo(td *v1.Todo, stamp time.Time) (*ical.Component, error) {
+	reminder, err := todoReminder(td)
+	if err != nil {
+		return nil, err
+	}
+
+	c := ical.NewComponent("VTODO")
+	c.AddText("UID", exportUID(td))
+	c.Add("DTSTAMP", ical.FormatTime(stamp))
+	c.AddText("SUMMARY", td.Title)
+	if len(td.Description) > 0 {
+		c.AddText("DESCRIPTION", td.Description)
+	}
+	c.Add("STATUS", calendarStatuses[td.Status])
+	if td.CompletedAt != nil {
+		completedAt, err := ptypes.Timestamp(td.CompletedAt)
+		if err != nil {
+			return nil, status.Error(codes.Unknown, "completed_at field has invalid format-> "+err.Error())
+		}
+		c.Add("COMPLETED", ical.FormatTime(completedAt))
+	}
+	if len(td.Labels) > 0 {
+		labels := make([]string, len(td.Labels))
+		for i, l := range td.Labels {
+			labels[i] = ical.EscapeText(l)
+		}
+		c.Add("CATEGORIES", strings.Join(labels, ","))
+	}
+
+	// the alarm is relative to the start, so that it repeats with the
+	// occurrences of recurring todo
+	// start in the time zone of the todo keeps local time of the occurrences
+	if len(td.TimeZone) > 0 {
+		c.Add("DTSTART", ical.FormatLocalTime(reminder), "TZID", td.TimeZone)
+	} else {
+		c.Add("DTSTART", ical.FormatTime(reminder))
+	}
+	if len(td.Recurrence) > 0 {
+		c.Add("RRULE", td.Recurrence)
+	}
+	alarm := ical.NewComponent("VALARM")
+	alarm.Add("ACTION", "DISPLAY")
+	alarm.AddText("DESCRIPTION", td.Title)
+	alarm.Add("TRIGGER", "PT0S", "RELATED", "START")
+	c.Components = append(c.Components, alarm)
+
+	return c, nil
+}
+
+// calendarTime parses time of the property, zero time if the property is
+// not set
+func calendarTime(p *ical.Property) (time.Time, error) {
+	if p == nil {
+		return time.Time{}, nil
+	}
+	t, err := p.Time()
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s is invalid-> %v", p.Name, err)
+	}
+	return t, nil
+}
+
+// calendarReminder returns time of the first VALARM of the VTODO, DUE or
+// DTSTART if it has no alarm
+func calendarReminder(c *ical.Component) (time.Time, error) {
+	start, err := calendarTime(c.Get("DTSTART"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	due, err := calendarTime(c.Get("DUE"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if p := c.Get("DURATION"); due.IsZero() && !start.IsZero() && p != nil {
+		d, err := ical.ParseDuration(p.Value)
+		if err != nil {
+			return time.Time{}, status.Error(codes.InvalidArgument, "DURATION is invalid-> "+err.Error())
+		}
+		due = start.Add(d)
+	}
+
+	for _, alarm := range c.Children("VALARM") {
+		trigger := alarm.Get("TRIGGER")
+		if trigger == nil {
+			continue
+		}
+		if trigger.Param("VALUE") == "DATE-TIME" {
+			return calendarTime(trigger)
+		}
+
+		d, err := ical.ParseDuration(trigger.Value)
+		if err != nil {
+			return time.Time{}, status.Error(codes.InvalidArgument, "TRIGGER is invalid-> "+err.Error())
+		}
+		related := start
+		if trigger.Param("RELATED") == "END" {
+			related = due
+		}
+		if !related.IsZero() {
+			return related.Add(d), nil
+		}
+	}
+
+	switch {
+	case !due.IsZero():
+		return due, nil
+	case !start.IsZero():
+		return start, nil
+	}
+	return time.Time{}, status.Error(codes.InvalidArgument, "VTODO has no VALARM, DUE or DTSTART for the reminder")
+}
+
+// parseVTODO converts the VTODO component to todo, the todo is returned with
+// the external ID even if the component is invalid
+func parseVTODO(c *ical.Component) (*v1.Todo, error) {
+	td := &v1.Todo{}
+	if p := c.Get("UID"); p != nil {
+		td.ExternalId = p.Text()
+	}
+	if p := c.Get("SUMMARY"); p != nil {
+		td.Title = p.Text()
+	}
+	if p := c.Get("DESCRIPTION"); p != nil {
+		td.Description = p.Text()
+	}
+	if p := c.Get("RRULE"); p != nil {
+		td.Recurrence = p.Value
+	}
+	for _, p := range c.All("CATEGORIES") {
+		td.Labels = append(td.Labels, p.TextList()...)
+	}
+
+	// recurrence of the VTODO follows local time of its start
+	for _, name := range []string{"DTSTART", "DUE"} {
+		if p := c.Get(name); p != nil && len(p.TimeZone()) > 0 {
+			td.TimeZone = p.TimeZone()
+			break
+		}
+	}
+
+	var statusName string
+	if p := c.Get("STATUS"); p != nil {
+		statusName = strings.ToUpper(p.Value)
+	}
+	st, ok := todoStatuses[statusName]
+	if !ok {
+		return td, status.Errorf(codes.InvalidArgument, "STATUS has unknown value '%s'", statusName)
+	}
+	td.Status = st
+
+	reminder, err := calendarReminder(c)
+	if err != nil {
+		return td, err
+	}
+	td.Reminder, err = ptypes.TimestampProto(reminder)
+	if err != nil {
+		return td, status.Error(codes.InvalidArgument, "reminder is invalid-> "+err.Error())
+	}
+
+	return td, nil
+}
+
+// ExportCalendar renders todo tasks matching the filter as iCalendar object
+func (s *todoServiceServer) ExportCalendar(ctx context.Context, req *v1.ExportCalendarRequest) (*httpbody.HttpBody, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	list, err := s.exportList(ctx, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := ical.NewComponent("VCALENDAR")
+	cal.Add("VERSION", "2.0")
+	cal.AddText("PRODID", calendarProductID)
+	stamp := s.now()
+	for _, td := range list {
+		comp, err := vtodo(td, stamp)
+		if err != nil {
+			return nil, err
+		}
+		cal.Components = append(cal.Components, comp)
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, cal); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to render calendar-> "+err.Error())
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: calendarContentType,
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// ImportCalendar creates todo tasks from VTODO components of the calendar
+func (s *todoServiceServer) ImportCalendar(ctx context.Context, req *v1.ImportCalendarRequest) (*v1.ImportSummary, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	cal, err := ical.Decode(bytes.NewReader(req.GetCalendar().GetData()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "calendar field is invalid-> "+err.Error())
+	}
+	if cal.Name != "VCALENDAR" {
+		return nil, status.Errorf(codes.InvalidArgument, "calendar field is invalid-> %s is not VCALENDAR", cal.Name)
+	}
+
+	var rows []importRow
+	for _, c := range cal.Children("VTODO") {
+		td, err := parseVTODO(c)
+		rows = append(rows, importRow{todo: td, err: err})
+	}
+
+	return s.importRows(ctx, rows)
+}
@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+func Test_toDoServiceServer_ExportCalendar(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db).(*todoServiceServer)
+	s.now = func() time.Time { return time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC) }
+	reminder := time.Date(2026, 10, 20, 9, 30, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Owner`=\\? AND `DeletedAt` IS NULL ORDER BY `ID`$").WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+			AddRow(1, "Water plants", "Kitchen, balcony", reminder, v1.Todo_OPEN, nil, nil, 1, nil, "FREQ=WEEKLY;BYDAY=TU", nil, "").
+			AddRow(2, "Pay rent", "", reminder, v1.Todo_DONE, reminder, nil, 3, "bank-7", "", nil, "Europe/Berlin"))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").
+		WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}).AddRow(1, "home"))
+
+	got, err := s.ExportCalendar(context.Background(), &v1.ExportCalendarRequest{Api: "v1"})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.ExportCalendar() error = %v", err)
+	}
+	if got.ContentType != calendarContentType {
+		t.Errorf("toDoServiceServer.ExportCalendar() content type = %s, want %s", got.ContentType, calendarContentType)
+	}
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + calendarProductID,
+		"BEGIN:VTODO",
+		"UID:todo-1",
+		"DTSTAMP:20261018T080000Z",
+		"SUMMARY:Water plants",
+		"DESCRIPTION:Kitchen\\, balcony",
+		"STATUS:NEEDS-ACTION",
+		"CATEGORIES:home",
+		"DTSTART:20261020T093000Z",
+		"RRULE:FREQ=WEEKLY;BYDAY=TU",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DESCRIPTION:Water plants",
+		"TRIGGER;RELATED=START:PT0S",
+		"END:VALARM",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:bank-7",
+		"DTSTAMP:20261018T080000Z",
+		"SUMMARY:Pay rent",
+		"STATUS:COMPLETED",
+		"COMPLETED:20261020T093000Z",
+		"DTSTART;TZID=Europe/Berlin:20261020T113000",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DESCRIPTION:Pay rent",
+		"TRIGGER;RELATED=START:PT0S",
+		"END:VALARM",
+		"END:VTODO",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if string(got.Data) != want {
+		t.Errorf("toDoServiceServer.ExportCalendar() =\n%s\nwant\n%s", got.Data, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_ImportCalendar(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	ctx := context.Background()
+
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:event-1",
+		"DTSTART:20261020T090000Z",
+		"END:VEVENT",
+		"BEGIN:VTODO",
+		"UID:cal-1",
+		"SUMMARY:Call the plumber",
+		"DESCRIPTION:Kitchen sink\\nleaks",
+		"CATEGORIES:home",
+		"DUE;TZID=/Europe/Berlin:20261020T120000",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER;RELATED=END:-PT15M",
+		"END:VALARM",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:cal-2",
+		"SUMMARY:Someday",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:cal-3",
+		"SUMMARY:Renew passport",
+		"STATUS:COMPLETED",
+		"DTSTART;VALUE=DATE:20261101",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO ToDo").
+		WithArgs("Call the plumber", "Kitchen sink\nleaks", time.Date(2026, 10, 20, 9, 45, 0, 0, time.UTC), v1.Todo_OPEN, nil, "cal-1", "", "", nil, "Europe/Berlin").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO Label").WithArgs("", "home").WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("INSERT INTO ToDoLabel").WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectRecordChange(mock, 1, 1)
+	mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO ToDo").
+		WithArgs("Renew passport", "", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), v1.Todo_DONE, sqlmock.AnyArg(), "cal-3", "", "", nil, "").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	expectRecordChange(mock, 2, 1)
+	mock.ExpectCommit()
+
+	got, err := s.ImportCalendar(ctx, &v1.ImportCalendarRequest{
+		Api:      "v1",
+		Calendar: &httpbody.HttpBody{ContentType: "text/calendar", Data: []byte(calendar)},
+	})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.ImportCalendar() error = %v", err)
+	}
+	if got.Created != 2 || got.Failed != 1 {
+		t.Errorf("toDoServiceServer.ImportCalendar() created = %d, failed = %d, want 2, 1", got.Created, got.Failed)
+	}
+	if len(got.Errors) != 1 || got.Errors[0].Index != 1 || got.Errors[0].ExternalId != "cal-2" ||
+		codes.Code(got.Errors[0].Status.Code) != codes.InvalidArgument {
+		t.Errorf("toDoServiceServer.ImportCalendar() errors = %v", got.Errors)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	// malformed calendar is rejected as a whole
+	_, err = s.ImportCalendar(ctx, &v1.ImportCalendarRequest{
+		Api:      "v1",
+		Calendar: &httpbody.HttpBody{Data: []byte("BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VCALENDAR\r\n")},
+	})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("toDoServiceServer.ImportCalendar() error = %v, wantCode %v", err, codes.InvalidArgument)
+	}
+}
+
+func Test_parseVTODO_roundTrip(t *testing.T) {
+	reminder, _ := ptypes.TimestampProto(time.Date(2026, 10, 20, 9, 30, 0, 0, time.UTC))
+	td := &v1.Todo{
+		Id:          1,
+		Title:       "Water plants; all of them",
+		Description: "Kitchen, balcony",
+		Reminder:    reminder,
+		Status:      v1.Todo_IN_PROGRESS,
+		Labels:      []string{"garden", "home"},
+		Recurrence:  "FREQ=WEEKLY;BYDAY=TU",
+		TimeZone:    "America/New_York",
+	}
+
+	c, err := vtodo(td, time.Now())
+	if err != nil {
+		t.Fatalf("vtodo() error = %v", err)
+	}
+	got, err := parseVTODO(c)
+	if err != nil {
+		t.Fatalf("parseVTODO() error = %v", err)
+	}
+
+	td.ExternalId = "todo-1"
+	td.Id = 0
+	if got.String() != td.String() {
+		t.Errorf("parseVTODO() = %v, want %v", got, td)
+	}
+}
@@ -0,0 +1,333 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// maxCommentLength is the maximum length of the comment body
+const maxCommentLength = 4096
+
+// commentColumns is list of ToDoComment columns in the order scanComment
+// reads them
+const commentColumns = "`ID`, `ToDoID`, `Author`, `Body`, `CreatedAt`, `EditedAt`"
+
+// normalizeCommentBody trims the comment body and checks its length
+func normalizeCommentBody(c *v1.Comment) (string, error) {
+	if c == nil {
+		return "", status.Error(codes.InvalidArgument, "comment field is required")
+	}
+	body := strings.TrimSpace(c.Body)
+	if len(body) == 0 {
+		return "", status.Error(codes.InvalidArgument, "body field must not be empty")
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return "", status.Errorf(codes.InvalidArgument, "body field is longer than %d characters", maxCommentLength)
+	}
+	return body, nil
+}
+
+// checkTodo checks the ToDo of the tenant exists and is not in trash,
+// comments of ToDo in trash are hidden
+func checkTodo(ctx context.Context, q querier, id int64) error {
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT `ID` FROM ToDo WHERE `ID`=? AND `Owner`=? AND `DeletedAt` IS NULL", id, tenant)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+		}
+		return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
+			id))
+	}
+	return nil
+}
+
+// scanComment reads comment from the current row of the result set
+func scanComment(rows *sql.Rows) (*v1.Comment, error) {
+	var c v1.Comment
+	var createdAt time.Time
+	var editedAt sql.NullTime
+	if err := rows.Scan(&c.Id, &c.TodoId, &c.Author, &c.Body, &createdAt, &editedAt); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDoComment row-> "+err.Error())
+	}
+
+	var err error
+	if c.CreateTime, err = ptypes.TimestampProto(createdAt); err != nil {
+		return nil, status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+	}
+	if editedAt.Valid {
+		if c.EditTime, err = ptypes.TimestampProto(editedAt.Time); err != nil {
+			return nil, status.Error(codes.Unknown, "edited_at field has invalid format-> "+err.Error())
+		}
+	}
+	return &c, nil
+}
+
+// commentAuthor returns who writes or changes the comment, comments of
+// anonymous callers could be changed by anyone
+func commentAuthor(ctx context.Context) (string, error) {
+	author := requestActor(ctx)
+	if len(author) == 0 {
+		return "", status.Error(codes.Unauthenticated, "actor is required to write comments")
+	}
+	return author, nil
+}
+
+// readComment checks the ToDo and returns its comment, the comment must be
+// written by the caller if author is set
+func readComment(ctx context.Context, q querier, todoID, id int64, author bool) (*v1.Comment, error) {
+	var caller string
+	if author {
+		var err error
+		if caller, err = commentAuthor(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkTodo(ctx, q, todoID); err != nil {
+		return nil, err
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT "+commentColumns+" FROM ToDoComment WHERE `ID`=? AND `ToDoID`=?", id, todoID)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoComment-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoComment-> "+err.Error())
+		}
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Comment with ID='%d' is not found",
+			id))
+	}
+
+	c, err := scanComment(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if author && c.Author != caller {
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("Comment with ID='%d' is written by another author",
+			id))
+	}
+	return c, nil
+}
+
+// AddComment adds comment to todo task
+func (s *todoServiceServer) AddComment(ctx context.Context, req *v1.AddCommentRequest) (*v1.AddCommentResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	body, err := normalizeCommentBody(req.Comment)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := commentAuthor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := checkTodo(ctx, c, req.TodoId); err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	res, err := c.ExecContext(ctx, "INSERT INTO ToDoComment(`ToDoID`, `Author`, `Body`, `CreatedAt`) VALUES(?, ?, ?, ?)",
+		req.TodoId, author, body, now)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to insert into ToDoComment-> "+err.Error())
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve id for created ToDoComment-> "+err.Error())
+	}
+
+	createTime, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+	}
+
+	return &v1.AddCommentResponse{
+		Api: apiVersion,
+		Comment: &v1.Comment{
+			Id:         id,
+			TodoId:     req.TodoId,
+			Author:     author,
+			Body:       body,
+			CreateTime: createTime,
+		},
+	}, nil
+}
+
+// ListComments returns comments of todo task from the oldest one
+func (s *todoServiceServer) ListComments(ctx context.Context, req *v1.ListCommentsRequest) (*v1.ListCommentsResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "page_size field is invalid-> "+err.Error())
+	}
+
+	// page token is ID of the last comment of the previous page
+	var after int64
+	if len(req.PageToken) > 0 {
+		if after, err = strconv.ParseInt(req.PageToken, 10, 64); err != nil || after < 0 {
+			return nil, status.Error(codes.InvalidArgument, "page_token field is invalid")
+		}
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := checkTodo(ctx, c, req.TodoId); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.QueryContext(ctx, "SELECT "+commentColumns+" FROM ToDoComment WHERE `ToDoID`=? AND `ID`>? ORDER BY `ID` LIMIT ?",
+		req.TodoId, after, size+1)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoComment-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.Comment{}
+	for rows.Next() {
+		cm, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, cm)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoComment-> "+err.Error())
+	}
+
+	// one extra row tells if there is a next page
+	var next string
+	if len(list) > size {
+		list = list[:size]
+		next = strconv.FormatInt(list[size-1].Id, 10)
+	}
+
+	return &v1.ListCommentsResponse{
+		Api:           apiVersion,
+		Comments:      list,
+		NextPageToken: next,
+	}, nil
+}
+
+// EditComment changes body of the comment written by the caller
+func (s *todoServiceServer) EditComment(ctx context.Context, req *v1.EditCommentRequest) (*v1.EditCommentResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	body, err := normalizeCommentBody(req.Comment)
+	if err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	cm, err := readComment(ctx, c, req.TodoId, req.Comment.Id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	if _, err := c.ExecContext(ctx, "UPDATE ToDoComment SET `Body`=?, `EditedAt`=? WHERE `ID`=?",
+		body, now, cm.Id); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to update ToDoComment-> "+err.Error())
+	}
+
+	cm.Body = body
+	if cm.EditTime, err = ptypes.TimestampProto(now); err != nil {
+		return nil, status.Error(codes.Unknown, "edited_at field has invalid format-> "+err.Error())
+	}
+
+	return &v1.EditCommentResponse{
+		Api:     apiVersion,
+		Comment: cm,
+	}, nil
+}
+
+// DeleteComment deletes the comment written by the caller
+func (s *todoServiceServer) DeleteComment(ctx context.Context, req *v1.DeleteCommentRequest) (*v1.DeleteCommentResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	cm, err := readComment(ctx, c, req.TodoId, req.Id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.ExecContext(ctx, "DELETE FROM ToDoComment WHERE `ID`=?", cm.Id)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to delete ToDoComment-> "+err.Error())
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+
+	return &v1.DeleteCommentResponse{
+		Api:     apiVersion,
+		Deleted: rows,
+	}, nil
+}
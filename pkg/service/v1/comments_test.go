@@ -0,0 +1,247 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// expectCheckTodo expects the ToDo to be checked before its comments are
+// accessed
+func expectCheckTodo(mock sqlmock.Sqlmock, id int64, found bool) {
+	rows := sqlmock.NewRows([]string{"ID"})
+	if found {
+		rows.AddRow(id)
+	}
+	mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ID`=\\? AND `Owner`=\\? AND `DeletedAt` IS NULL").WithArgs(id, "").
+		WillReturnRows(rows)
+}
+
+// commentRows returns result set with comments of the ToDo 1 written by the
+// given author
+func commentRows(author string, ids ...int64) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"ID", "ToDoID", "Author", "Body", "CreatedAt", "EditedAt"})
+	for _, id := range ids {
+		rows.AddRow(id, 1, author, "body", time.Now().In(time.UTC), nil)
+	}
+	return rows
+}
+
+func Test_toDoServiceServer_AddComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	ctx := WithActor(context.Background(), "alice")
+
+	tests := []struct {
+		name     string
+		req      *v1.AddCommentRequest
+		mock     func()
+		wantCode codes.Code
+	}{
+		{
+			name: "OK",
+			req:  &v1.AddCommentRequest{Api: "v1", TodoId: 1, Comment: &v1.Comment{Body: " looks good "}},
+			mock: func() {
+				expectCheckTodo(mock, 1, true)
+				mock.ExpectExec("INSERT INTO ToDoComment").WithArgs(1, "alice", "looks good", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(7, 1))
+			},
+		},
+		{
+			name:     "Empty body",
+			req:      &v1.AddCommentRequest{Api: "v1", TodoId: 1, Comment: &v1.Comment{Body: " "}},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "ToDo not found",
+			req:  &v1.AddCommentRequest{Api: "v1", TodoId: 2, Comment: &v1.Comment{Body: "looks good"}},
+			mock: func() {
+				expectCheckTodo(mock, 2, false)
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := s.AddComment(ctx, tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("toDoServiceServer.AddComment() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err == nil && (got.Comment.Id != 7 || got.Comment.Author != "alice" || got.Comment.Body != "looks good") {
+				t.Errorf("toDoServiceServer.AddComment() = %v", got.Comment)
+			}
+		})
+	}
+
+	// anonymous comments could be changed by anyone
+	_, err = s.AddComment(context.Background(), &v1.AddCommentRequest{Api: "v1", TodoId: 1, Comment: &v1.Comment{Body: "looks good"}})
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Errorf("toDoServiceServer.AddComment() error = %v, wantCode %v", err, codes.Unauthenticated)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_ListComments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	ctx := context.Background()
+
+	// one extra comment tells there is a next page
+	expectCheckTodo(mock, 1, true)
+	mock.ExpectQuery("SELECT (.+) FROM ToDoComment WHERE `ToDoID`=\\? AND `ID`>\\? ORDER BY `ID` LIMIT \\?").
+		WithArgs(1, 0, 3).WillReturnRows(commentRows("alice", 1, 2, 3))
+	got, err := s.ListComments(ctx, &v1.ListCommentsRequest{Api: "v1", TodoId: 1, PageSize: 2})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.ListComments() error = %v", err)
+	}
+	if len(got.Comments) != 2 || got.NextPageToken != "2" {
+		t.Errorf("toDoServiceServer.ListComments() = %v, next page token '%v'", got.Comments, got.NextPageToken)
+	}
+
+	expectCheckTodo(mock, 1, true)
+	mock.ExpectQuery("SELECT (.+) FROM ToDoComment").WithArgs(1, 2, 3).WillReturnRows(commentRows("alice", 3))
+	got, err = s.ListComments(ctx, &v1.ListCommentsRequest{Api: "v1", TodoId: 1, PageSize: 2, PageToken: got.NextPageToken})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.ListComments() error = %v", err)
+	}
+	if len(got.Comments) != 1 || got.NextPageToken != "" {
+		t.Errorf("toDoServiceServer.ListComments() = %v, next page token '%v'", got.Comments, got.NextPageToken)
+	}
+
+	if _, err := s.ListComments(ctx, &v1.ListCommentsRequest{Api: "v1", TodoId: 1, PageToken: "x"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("toDoServiceServer.ListComments() error = %v, wantCode %v", err, codes.InvalidArgument)
+	}
+
+	// comments of ToDo in trash are hidden
+	expectCheckTodo(mock, 2, false)
+	if _, err := s.ListComments(ctx, &v1.ListCommentsRequest{Api: "v1", TodoId: 2}); status.Code(err) != codes.NotFound {
+		t.Errorf("toDoServiceServer.ListComments() error = %v, wantCode %v", err, codes.NotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_EditComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	ctx := WithActor(context.Background(), "alice")
+
+	tests := []struct {
+		name     string
+		mock     func()
+		wantCode codes.Code
+	}{
+		{
+			name: "OK",
+			mock: func() {
+				expectCheckTodo(mock, 1, true)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoComment WHERE `ID`=\\? AND `ToDoID`=\\?").WithArgs(7, 1).
+					WillReturnRows(commentRows("alice", 7))
+				mock.ExpectExec("UPDATE ToDoComment SET `Body`=\\?, `EditedAt`=\\? WHERE `ID`=\\?").
+					WithArgs("new body", sqlmock.AnyArg(), 7).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Another author",
+			mock: func() {
+				expectCheckTodo(mock, 1, true)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoComment").WithArgs(7, 1).
+					WillReturnRows(commentRows("bob", 7))
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "Not found",
+			mock: func() {
+				expectCheckTodo(mock, 1, true)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoComment").WithArgs(7, 1).
+					WillReturnRows(commentRows("alice"))
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := s.EditComment(ctx, &v1.EditCommentRequest{Api: "v1", TodoId: 1, Comment: &v1.Comment{Id: 7, Body: "new body"}})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("toDoServiceServer.EditComment() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err == nil && (got.Comment.Body != "new body" || got.Comment.EditTime == nil) {
+				t.Errorf("toDoServiceServer.EditComment() = %v", got.Comment)
+			}
+		})
+	}
+
+	// actor sent by client is ignored when the request is authenticated
+	expectCheckTodo(mock, 1, true)
+	mock.ExpectQuery("SELECT (.+) FROM ToDoComment").WithArgs(7, 1).WillReturnRows(commentRows("alice", 7))
+	_, err = s.EditComment(WithActor(ctx, "bob"), &v1.EditCommentRequest{Api: "v1", TodoId: 1, Comment: &v1.Comment{Id: 7, Body: "new body"}})
+	if code := status.Code(err); code != codes.PermissionDenied {
+		t.Errorf("toDoServiceServer.EditComment() error = %v, wantCode %v", err, codes.PermissionDenied)
+	}
+
+	_, err = s.EditComment(context.Background(), &v1.EditCommentRequest{Api: "v1", TodoId: 1, Comment: &v1.Comment{Id: 7, Body: "new body"}})
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Errorf("toDoServiceServer.EditComment() error = %v, wantCode %v", err, codes.Unauthenticated)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_DeleteComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	ctx := WithActor(context.Background(), "alice")
+
+	expectCheckTodo(mock, 1, true)
+	mock.ExpectQuery("SELECT (.+) FROM ToDoComment").WithArgs(7, 1).WillReturnRows(commentRows("alice", 7))
+	mock.ExpectExec("DELETE FROM ToDoComment WHERE `ID`=\\?").WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	got, err := s.DeleteComment(ctx, &v1.DeleteCommentRequest{Api: "v1", TodoId: 1, Id: 7})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.DeleteComment() error = %v", err)
+	}
+	if got.Deleted != 1 {
+		t.Errorf("toDoServiceServer.DeleteComment() deleted = %v, want %v", got.Deleted, 1)
+	}
+
+	_, err = s.DeleteComment(context.Background(), &v1.DeleteCommentRequest{Api: "v1", TodoId: 1, Id: 7})
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Errorf("toDoServiceServer.DeleteComment() error = %v, wantCode %v", err, codes.Unauthenticated)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
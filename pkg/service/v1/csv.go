@@ -0,0 +1,322 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// csvContentType is media type of exported CSV files
+	csvContentType = "text/csv; charset=utf-8"
+
+	// csvLabelSeparator separates labels in the labels column
+	csvLabelSeparator = ","
+
+	// utf8BOM is written by spreadsheets at the start of UTF-8 files
+	utf8BOM = "\ufeff"
+)
+
+// csvColumn is a column of CSV files
+type csvColumn struct {
+	// format returns value of the column for the todo
+	format func(td *v1.Todo) (string, error)
+
+	// parse sets the field of the todo from the column value, nil if the
+	// column is ignored on import
+	parse func(td *v1.Todo, value string) error
+}
+
+// defaultCSVColumns are the columns exported if the request has none
+var defaultCSVColumns = []string{
+	"id", "title", "description", "reminder", "time_zone", "status", "completed_at", "labels", "external_id", "recurrence", "list_id",
+}
+
+// csvColumns are the columns by name
+var csvColumns = map[string]csvColumn{
+	"id": {
+		format: func(td *v1.Todo) (string, error) { return strconv.FormatInt(td.Id, 10), nil },
+	},
+	"title": {
+		format: func(td *v1.Todo) (string, error) { return escapeCSVText(td.Title), nil },
+		parse:  func(td *v1.Todo, value string) error { td.Title = unescapeCSVText(value); return nil },
+	},
+	"description": {
+		format: func(td *v1.Todo) (string, error) { return escapeCSVText(td.Description), nil },
+		parse:  func(td *v1.Todo, value string) error { td.Description = unescapeCSVText(value); return nil },
+	},
+	"reminder": {
+		format: func(td *v1.Todo) (string, error) { return formatCSVTime(td.Reminder, "reminder") },
+		parse: func(td *v1.Todo, value string) error {
+			if len(value) == 0 {
+				return status.Error(codes.InvalidArgument, "reminder column must not be empty")
+			}
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return status.Error(codes.InvalidArgument, "reminder column is not RFC 3339 time-> "+err.Error())
+			}
+			td.Reminder, err = ptypes.TimestampProto(t)
+			if err != nil {
+				return status.Error(codes.InvalidArgument, "reminder column is invalid-> "+err.Error())
+			}
+			return nil
+		},
+	},
+	"time_zone": {
+		format: func(td *v1.Todo) (string, error) { return td.TimeZone, nil },
+		parse:  func(td *v1.Todo, value string) error { td.TimeZone = value; return nil },
+	},
+	"local_reminder": {
+		format: func(td *v1.Todo) (string, error) { return td.LocalReminder, nil },
+	},
+	"status": {
+		format: func(td *v1.Todo) (string, error) { return td.Status.String(), nil },
+		parse: func(td *v1.Todo, value string) error {
+			if len(value) == 0 {
+				td.Status = v1.Todo_OPEN
+				return nil
+			}
+			st, ok := v1.Todo_Status_value[strings.ToUpper(value)]
+			if !ok {
+				return status.Errorf(codes.InvalidArgument, "status column has unknown value '%s'", value)
+			}
+			td.Status = v1.Todo_Status(st)
+			return nil
+		},
+	},
+	"completed_at": {
+		format: func(td *v1.Todo) (string, error) { return formatCSVTime(td.CompletedAt, "completed_at") },
+	},
+	"labels": {
+		format: func(td *v1.Todo) (string, error) {
+			return escapeCSVText(strings.Join(td.Labels, csvLabelSeparator)), nil
+		},
+		parse: func(td *v1.Todo, value string) error {
+			td.Labels = nil
+			for _, l := range strings.Split(unescapeCSVText(value), csvLabelSeparator) {
+				if l = strings.TrimSpace(l); len(l) > 0 {
+					td.Labels = append(td.Labels, l)
+				}
+			}
+			return nil
+		},
+	},
+	"external_id": {
+		format: func(td *v1.Todo) (string, error) { return td.ExternalId, nil },
+		parse:  func(td *v1.Todo, value string) error { td.ExternalId = value; return nil },
+	},
+	"recurrence": {
+		format: func(td *v1.Todo) (string, error) { return td.Recurrence, nil },
+		parse:  func(td *v1.Todo, value string) error { td.Recurrence = value; return nil },
+	},
+	"list_id": {
+		format: func(td *v1.Todo) (string, error) {
+			if td.ListId == 0 {
+				return "", nil
+			}
+			return strconv.FormatInt(td.ListId, 10), nil
+		},
+		parse: func(td *v1.Todo, value string) error {
+			if len(value) == 0 {
+				td.ListId = 0
+				return nil
+			}
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "list_id column has invalid value '%s'", value)
+			}
+			td.ListId = id
+			return nil
+		},
+	},
+}
+
+// isCSVFormula reports whether spreadsheet would evaluate the value as
+// formula, quotes the value is escaped with are skipped
+func isCSVFormula(value string) bool {
+	value = strings.TrimLeft(value, "'")
+	return len(value) > 0 && strings.ContainsRune("=+-@\t\r", rune(value[0]))
+}
+
+// escapeCSVText prefixes text looking like formula with quote, so that
+// spreadsheet opening the exported file shows it as text
+func escapeCSVText(value string) string {
+	if isCSVFormula(value) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeCSVText removes the quote escapeCSVText adds
+func unescapeCSVText(value string) string {
+	if strings.HasPrefix(value, "'") && isCSVFormula(value) {
+		return value[1:]
+	}
+	return value
+}
+
+// formatCSVTime formats the timestamp as RFC 3339 time in UTC, empty if it is
+// not set
+func formatCSVTime(ts *timestamp.Timestamp, field string) (string, error) {
+	if ts == nil {
+		return "", nil
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return "", status.Error(codes.Unknown, field+" field has invalid format-> "+err.Error())
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// parseCSVColumns validates names of the columns, names may be separated by
+// commas. It returns the default columns if there are no names.
+func parseCSVColumns(names []string) ([]string, error) {
+	var list []string
+	seen := map[string]bool{}
+	for _, n := range names {
+		for _, name := range strings.Split(n, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if len(name) == 0 {
+				continue
+			}
+			if _, ok := csvColumns[name]; !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "column '%s' is unknown", name)
+			}
+			if seen[name] {
+				return nil, status.Errorf(codes.InvalidArgument, "column '%s' is repeated", name)
+			}
+			seen[name] = true
+			list = append(list, name)
+		}
+	}
+	if len(list) == 0 {
+		return defaultCSVColumns, nil
+	}
+	return list, nil
+}
+
+// ExportCSV renders todo tasks matching the filter as CSV file
+func (s *todoServiceServer) ExportCSV(ctx context.Context, req *v1.ExportCSVRequest) (*httpbody.HttpBody, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	columns, err := parseCSVColumns(req.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.exportList(ctx, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(columns)
+	record := make([]string, len(columns))
+	for _, td := range list {
+		for i, name := range columns {
+			if record[i], err = csvColumns[name].format(td); err != nil {
+				return nil, err
+			}
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to render CSV-> "+err.Error())
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: csvContentType,
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// ImportCSV creates todo tasks from rows of the CSV file
+func (s *todoServiceServer) ImportCSV(ctx context.Context, req *v1.ImportCSVRequest) (*v1.ImportSummary, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	data := bytes.TrimPrefix(req.GetFile().GetData(), []byte(utf8BOM))
+	r := csv.NewReader(bytes.NewReader(data))
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, status.Error(codes.InvalidArgument, "file field is invalid-> header row is missing")
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "file field is invalid-> "+err.Error())
+	}
+	for _, name := range header {
+		if len(strings.TrimSpace(name)) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "file field is invalid-> header row has empty column name")
+		}
+	}
+	columns, err := parseCSVColumns(header)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "file field is invalid-> "+status.Convert(err).Message())
+	}
+	if len(columns) != len(header) {
+		return nil, status.Error(codes.InvalidArgument, "file field is invalid-> header row has column name with comma")
+	}
+	hasReminder := false
+	for _, name := range columns {
+		hasReminder = hasReminder || name == "reminder"
+	}
+	if !hasReminder {
+		return nil, status.Error(codes.InvalidArgument, "file field is invalid-> reminder column is required")
+	}
+
+	var rows []importRow
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+
+		td := &v1.Todo{}
+		if err != nil {
+			// the reader continues with the next row after malformed one
+			rows = append(rows, importRow{todo: td, err: status.Error(codes.InvalidArgument, "row is invalid-> "+err.Error())})
+			continue
+		}
+		rows = append(rows, importRow{todo: td, err: parseCSVRow(td, columns, record)})
+	}
+
+	return s.importRows(ctx, rows)
+}
+
+// parseCSVRow sets fields of the todo from the CSV record, the external ID is
+// set even if other columns are invalid
+func parseCSVRow(td *v1.Todo, columns, record []string) error {
+	for i, name := range columns {
+		if name == "external_id" {
+			td.ExternalId = record[i]
+		}
+	}
+	for i, name := range columns {
+		if parse := csvColumns[name].parse; parse != nil {
+			if err := parse(td, strings.TrimSpace(record[i])); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
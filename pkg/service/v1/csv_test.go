@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+func Test_toDoServiceServer_ExportCSV(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	reminder := time.Date(2026, 10, 20, 9, 30, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Owner`=\\? AND `DeletedAt` IS NULL ORDER BY `ID`$").WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+			AddRow(1, "Water plants", "Kitchen, balcony", reminder, v1.Todo_OPEN, nil, nil, 1, nil, "", 4, "").
+			AddRow(2, "Pay \"rent\"", "", reminder, v1.Todo_DONE, reminder, nil, 3, "bank-7", "", nil, "").
+			AddRow(3, "=1+1", "", reminder, v1.Todo_OPEN, nil, nil, 1, nil, "", nil, ""))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").
+		WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}).AddRow(1, "garden").AddRow(1, "home").AddRow(3, "@home"))
+
+	got, err := s.ExportCSV(context.Background(), &v1.ExportCSVRequest{
+		Api:     "v1",
+		Columns: []string{"title,status", "labels", "completed_at", "list_id"},
+	})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.ExportCSV() error = %v", err)
+	}
+	if got.ContentType != csvContentType {
+		t.Errorf("toDoServiceServer.ExportCSV() content type = %s, want %s", got.ContentType, csvContentType)
+	}
+	want := "title,status,labels,completed_at,list_id\n" +
+		"Water plants,OPEN,\"garden,home\",,4\n" +
+		"\"Pay \"\"rent\"\"\",DONE,,2026-10-20T09:30:00Z,\n" +
+		"'=1+1,OPEN,'@home,,\n"
+	if string(got.Data) != want {
+		t.Errorf("toDoServiceServer.ExportCSV() =\n%s\nwant\n%s", got.Data, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	// unknown column is rejected before the query
+	_, err = s.ExportCSV(context.Background(), &v1.ExportCSVRequest{Api: "v1", Columns: []string{"title", "owner"}})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("toDoServiceServer.ExportCSV() error = %v, wantCode %v", err, codes.InvalidArgument)
+	}
+}
+
+func Test_escapeCSVText(t *testing.T) {
+	for value, want := range map[string]string{
+		"Buy milk": "Buy milk",
+		"=1+1":     "'=1+1",
+		"-5 kg":    "'-5 kg",
+		"'=1+1":    "''=1+1",
+		"'quoted'": "'quoted'",
+	} {
+		if got := escapeCSVText(value); got != want {
+			t.Errorf("escapeCSVText(%q) = %q, want %q", value, got, want)
+		}
+		if got := unescapeCSVText(want); got != value {
+			t.Errorf("unescapeCSVText(%q) = %q, want %q", want, got, value)
+		}
+	}
+}
+
+func Test_toDoServiceServer_ImportCSV(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	ctx := context.Background()
+
+	file := utf8BOM + "ID,Title,Reminder,Status,Labels,External_ID\n" +
+		"9,'@plumber call,2026-10-20T12:00:00+02:00,in_progress,\"home, urgent\",sheet-1\n" +
+		"10,Someday,,OPEN,,sheet-2\n" +
+		"11,Renew passport,2026-11-01T00:00:00Z,WAITING,,sheet-3\n"
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO ToDo").
+		WithArgs("@plumber call", "", time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC), v1.Todo_IN_PROGRESS, nil, "sheet-1", "", "", nil, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO Label").WithArgs("", "home").WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("INSERT INTO ToDoLabel").WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO Label").WithArgs("", "urgent").WillReturnResult(sqlmock.NewResult(6, 1))
+	mock.ExpectExec("INSERT INTO ToDoLabel").WithArgs(1, 6).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectRecordChange(mock, 1, 1)
+	mock.ExpectCommit()
+
+	got, err := s.ImportCSV(ctx, &v1.ImportCSVRequest{
+		Api:  "v1",
+		File: &httpbody.HttpBody{ContentType: "text/csv", Data: []byte(file)},
+	})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.ImportCSV() error = %v", err)
+	}
+	if got.Created != 1 || got.Failed != 2 {
+		t.Errorf("toDoServiceServer.ImportCSV() created = %d, failed = %d, want 1, 2", got.Created, got.Failed)
+	}
+	if len(got.Errors) != 2 || got.Errors[0].Index != 1 || got.Errors[0].ExternalId != "sheet-2" ||
+		got.Errors[1].Index != 2 || got.Errors[1].ExternalId != "sheet-3" {
+		t.Errorf("toDoServiceServer.ImportCSV() errors = %v", got.Errors)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	// files without reminder column or with unknown columns are rejected as a
+	// whole
+	for _, file := range []string{"", "title\nBuy milk\n", "title,reminder,owner\n", "title,,reminder\n"} {
+		_, err = s.ImportCSV(ctx, &v1.ImportCSVRequest{Api: "v1", File: &httpbody.HttpBody{Data: []byte(file)}})
+		if code := status.Code(err); code != codes.InvalidArgument {
+			t.Errorf("toDoServiceServer.ImportCSV(%q) error = %v, wantCode %v", file, err, codes.InvalidArgument)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// etagHeader is metadata key the etag of the returned ToDo is sent in,
+	// REST gateway maps it to the ETag header
+	etagHeader = "etag"
+
+	// ifMatchHeader is metadata key clients can send the expected etag in,
+	// REST gateway maps the If-Match header to it
+	ifMatchHeader = "if-match"
+)
+
+// formatETag returns etag for the given ToDo version
+func formatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseETag returns ToDo version encoded in the etag. Weak etags and etags
+// without quotes are accepted, empty etag and "*" match any version.
+func parseETag(etag string) (int64, bool, error) {
+	etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	if len(etag) == 0 || etag == "*" {
+		return 0, false, nil
+	}
+
+	if unquoted, err := strconv.Unquote(etag); err == nil {
+		etag = unquoted
+	}
+	version, err := strconv.ParseInt(etag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false, fmt.Errorf("invalid etag '%s'", etag)
+	}
+	return version, true, nil
+}
+
+// requestETag returns etag the client expects the ToDo to have, the etag
+// field of the request takes precedence over If-Match metadata
+func requestETag(ctx context.Context, etag string) string {
+	if len(etag) == 0 {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(ifMatchHeader); len(values) > 0 {
+				etag = values[0]
+			}
+		}
+	}
+	return etag
+}
+
+// checkETag validates etag sent by client and returns the expected version
+func checkETag(etag string) (int64, bool, error) {
+	version, ok, err := parseETag(etag)
+	if err != nil {
+		return 0, false, status.Error(codes.InvalidArgument, "etag field is invalid-> "+err.Error())
+	}
+	return version, ok, nil
+}
+
+// setETagHeader sends etag of the returned ToDo in response metadata
+func setETagHeader(ctx context.Context, etag string) {
+	// fails only when called outside of gRPC server, e.g. in tests
+	_ = grpc.SetHeader(ctx, metadata.Pairs(etagHeader, etag))
+}
+
+// staleETagError returns error for the conditional change of the ToDo which
+// did not affect any row: Aborted if ToDo exists but has another version,
+// NotFound otherwise
+func staleETagError(ctx context.Context, q querier, id int64) error {
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT `Version` FROM ToDo WHERE `ID`=? AND `Owner`=? AND `DeletedAt` IS NULL", id, tenant)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+		}
+		return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
+			id))
+	}
+
+	var version int64
+	if err := rows.Scan(&version); err != nil {
+		return status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
+	}
+	return status.Error(codes.Aborted, fmt.Sprintf("ToDo with ID='%d' was modified concurrently, current etag is %s",
+		id, formatETag(version)))
+}
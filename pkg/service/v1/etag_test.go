@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"testing"
+)
+
+func Test_parseETag(t *testing.T) {
+	tests := []struct {
+		name        string
+		etag        string
+		wantVersion int64
+		wantOK      bool
+		wantErr     bool
+	}{
+		{"Empty", "", 0, false, false},
+		{"Any", "*", 0, false, false},
+		{"Strong", `"3"`, 3, true, false},
+		{"Weak", `W/"3"`, 3, true, false},
+		{"Unquoted", "3", 3, true, false},
+		{"Formatted", formatETag(42), 42, true, false},
+		{"Not a number", `"abc"`, 0, false, true},
+		{"Zero", `"0"`, 0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, ok, err := parseETag(tt.etag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseETag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if version != tt.wantVersion || ok != tt.wantOK {
+				t.Errorf("parseETag() = %v, %v, want %v, %v", version, ok, tt.wantVersion, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// exportChunkSize is number of todos labels are loaded for at once, it
+	// bounds memory used by the export regardless of number of todos
+	exportChunkSize = 100
+)
+
+// exportWhere returns WHERE clause selecting todos of the tenant matching
+// the filter
+func exportWhere(tenant string, filter filterExpr, showDeleted bool) (string, []interface{}) {
+	conds := []string{"`Owner`=?"}
+	args := []interface{}{tenant}
+	if !showDeleted {
+		conds = append(conds, "`DeletedAt` IS NULL")
+	}
+	if filter != nil {
+		cond, filterArgs := filterSQL(filter)
+		conds = append(conds, cond)
+		args = append(args, filterArgs...)
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// ExportTodos streams all todo tasks matching the filter
+func (s *todoServiceServer) ExportTodos(req *v1.ExportTodosRequest, stream v1.TodoService_ExportTodosServer) error {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return err
+	}
+
+	filter, err := parseFilter(req.Filter)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "filter field is invalid-> "+err.Error())
+	}
+
+	ctx := stream.Context()
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	where, args := exportWhere(tenant, filter, req.ShowDeleted)
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	// rows are read from the server as they are iterated, so the result set
+	// is never held in memory
+	rows, err := c.QueryContext(ctx, "SELECT "+todoColumns+" FROM ToDo"+where+" ORDER BY `ID`", args...)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	// labels are loaded using another connection from the pool, as the
+	// connection is busy reading the rows
+	chunk := make([]*v1.Todo, 0, exportChunkSize)
+	flush := func() error {
+		if err := loadLabels(ctx, s.db, chunk); err != nil {
+			return err
+		}
+		for _, td := range chunk {
+			if err := stream.Send(td); err != nil {
+				return err
+			}
+		}
+		chunk = chunk[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		td, err := scanTodo(rows)
+		if err != nil {
+			return err
+		}
+
+		chunk = append(chunk, td)
+		if len(chunk) == exportChunkSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+
+	return flush()
+}
+
+// exportList reads todos of the tenant matching the filter together with
+// their labels, todos in trash are skipped. It is used by exports rendering
+// whole file at once.
+func (s *todoServiceServer) exportList(ctx context.Context, filterText string) ([]*v1.Todo, error) {
+	filter, err := parseFilter(filterText)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "filter field is invalid-> "+err.Error())
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where, args := exportWhere(tenant, filter, false)
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	rows, err := c.QueryContext(ctx, "SELECT "+todoColumns+" FROM ToDo"+where+" ORDER BY `ID`", args...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.Todo{}
+	for rows.Next() {
+		td, err := scanTodo(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, td)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+	rows.Close()
+
+	if err := loadLabels(ctx, c, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
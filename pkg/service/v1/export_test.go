@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// exportStream collects todos sent by ExportTodos
+type exportStream struct {
+	grpc.ServerStream
+	todos []*v1.Todo
+}
+
+func (s *exportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *exportStream) Send(td *v1.Todo) error {
+	s.todos = append(s.todos, td)
+	return nil
+}
+
+func Test_toDoServiceServer_ExportTodos(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+
+	rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"})
+	for i := 1; i <= exportChunkSize+1; i++ {
+		rows.AddRow(i, "title", "description", tm, v1.Todo_DONE, tm, nil, 1, nil, "", nil, "")
+	}
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Owner`=\\? AND `DeletedAt` IS NULL AND `Status` = \\? ORDER BY `ID`$").
+		WithArgs("", v1.Todo_DONE).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").
+		WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}).AddRow(2, "backend"))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WithArgs(exportChunkSize + 1).
+		WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+
+	stream := &exportStream{}
+	if err := s.ExportTodos(&v1.ExportTodosRequest{Api: "v1", Filter: "status = DONE"}, stream); err != nil {
+		t.Fatalf("toDoServiceServer.ExportTodos() error = %v", err)
+	}
+	if len(stream.todos) != exportChunkSize+1 {
+		t.Fatalf("toDoServiceServer.ExportTodos() sent %d todos, want %d", len(stream.todos), exportChunkSize+1)
+	}
+	if labels := stream.todos[1].Labels; len(labels) != 1 || labels[0] != "backend" {
+		t.Errorf("toDoServiceServer.ExportTodos() labels = %v, want [backend]", labels)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_ExportTodos_invalidFilter(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+
+	err = s.ExportTodos(&v1.ExportTodosRequest{Api: "v1", Filter: "owner = me"}, &exportStream{})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("toDoServiceServer.ExportTodos() error = %v, wantCode %v", err, codes.InvalidArgument)
+	}
+}
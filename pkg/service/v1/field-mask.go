@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"fmt"
+
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+var (
+	// updatableFields is list of ToDo fields which can be set by Update, in
+	// the order they are written to the database
+	updatableFields = []string{"title", "description", "reminder", "status", "labels", "recurrence", "list_id", "time_zone"}
+
+	// replacedFields is list of fields updated when update mask is empty
+	replacedFields = []string{"title", "description", "reminder"}
+
+	// outputOnlyFields are ToDo fields set by the server. REST gateway builds
+	// the mask from all fields of the body, so they are in the mask when
+	// client sends back the ToDo it has read.
+	outputOnlyFields = map[string]bool{
+		"id":             true,
+		"etag":           true,
+		"completed_at":   true,
+		"deleted_at":     true,
+		"external_id":    true,
+		"local_reminder": true,
+	}
+)
+
+// updatePaths validates update mask and returns the fields to update.
+// Content fields are returned for the empty mask, all updatable fields are
+// returned for the "*" path.
+// Output only paths are ignored, "id" identifies the ToDo to update and
+// "etag" is the version client expects.
+func updatePaths(mask *field_mask.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return replacedFields, nil
+	}
+
+	requested := map[string]bool{}
+	for _, path := range mask.GetPaths() {
+		if path == "*" {
+			return updatableFields, nil
+		}
+		if outputOnlyFields[path] {
+			continue
+		}
+
+		found := false
+		for _, f := range updatableFields {
+			if f == path {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown field path '%s'", path)
+		}
+		requested[path] = true
+	}
+
+	paths := []string{}
+	for _, f := range updatableFields {
+		if requested[f] {
+			paths = append(paths, f)
+		}
+	}
+	return paths, nil
+}
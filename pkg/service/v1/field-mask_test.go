@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// gatewayUpdate returns update request REST gateway decodes from the body of
+// PATCH request without explicit mask
+func gatewayUpdate(t *testing.T, body string) *v1.UpdateRequest {
+	t.Helper()
+	req := &v1.UpdateRequest{Api: "v1", Todo: &v1.Todo{}}
+	if err := (&runtime.JSONPb{OrigName: true}).Unmarshal([]byte(body), req.Todo); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	_, md := descriptor.ForMessage(req.Todo)
+	mask, err := runtime.FieldMaskFromRequestBody(strings.NewReader(body), md)
+	if err != nil {
+		t.Fatalf("runtime.FieldMaskFromRequestBody() error = %v", err)
+	}
+	req.UpdateMask = mask
+	return req
+}
+
+func Test_updatePaths(t *testing.T) {
+	// ToDo read before is sent back with the changed title
+	req := gatewayUpdate(t, `{"id":"1","title":"x","etag":"\"2\"","completed_at":null,"external_id":"ext-1","local_reminder":""}`)
+	got, err := updatePaths(req.UpdateMask)
+	if err != nil {
+		t.Fatalf("updatePaths(%v) error = %v", req.UpdateMask.GetPaths(), err)
+	}
+	if !reflect.DeepEqual(got, []string{"title"}) {
+		t.Errorf("updatePaths(%v) = %v, want [title]", req.UpdateMask.GetPaths(), got)
+	}
+
+	req = gatewayUpdate(t, `{"title":"x","owner":"team-b"}`)
+	if _, err := updatePaths(req.UpdateMask); err == nil {
+		t.Errorf("updatePaths(%v) error = nil, want error", req.UpdateMask.GetPaths())
+	}
+}
+
+func Test_toDoServiceServer_Update_gatewayETag(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+
+	// etag of the body is the precondition, not a field to update
+	req := gatewayUpdate(t, `{"title":"x","etag":"\"2\""}`)
+	req.Todo.Id = 1
+	mock.ExpectBegin()
+	expectLock(mock, 1, 1)
+	mock.ExpectExec("UPDATE ToDo SET `Title`=\\?, `Version`=(.+) AND `Version`=\\?").WithArgs("x", 1, 2).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	expectRecordChange(mock, 1, 1)
+	mock.ExpectCommit()
+
+	got, err := s.Update(context.Background(), req)
+	if err != nil {
+		t.Fatalf("toDoServiceServer.Update() error = %v", err)
+	}
+	if got.Etag != `"3"` {
+		t.Errorf("toDoServiceServer.Update() etag = %v, want %v", got.Etag, `"3"`)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
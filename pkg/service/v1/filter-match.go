@@ -0,0 +1,152 @@
+package v1
+
+import (
+	"strings"
+	"time"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// filterMatch reports whether the ToDo satisfies the filter, nil filter
+// matches any ToDo
+func filterMatch(expr filterExpr, td *v1.Todo) bool {
+	if expr == nil {
+		return true
+	}
+	result, known := expr.match(td)
+	return known && result
+}
+
+func (e *filterAnd) match(td *v1.Todo) (bool, bool) {
+	left, leftKnown := e.left.match(td)
+	right, rightKnown := e.right.match(td)
+	switch {
+	case leftKnown && !left, rightKnown && !right:
+		return false, true
+	case leftKnown && rightKnown:
+		return true, true
+	}
+	return false, false
+}
+
+func (e *filterOr) match(td *v1.Todo) (bool, bool) {
+	left, leftKnown := e.left.match(td)
+	right, rightKnown := e.right.match(td)
+	switch {
+	case leftKnown && left, rightKnown && right:
+		return true, true
+	case leftKnown && rightKnown:
+		return false, true
+	}
+	return false, false
+}
+
+func (e *filterNot) match(td *v1.Todo) (bool, bool) {
+	result, known := e.expr.match(td)
+	return !result, known
+}
+
+func (e *filterRestriction) match(td *v1.Todo) (bool, bool) {
+	if e.field.kind == filterLabels {
+		for _, l := range td.Labels {
+			if sameLabel(l, e.value.(string)) {
+				return true, true
+			}
+		}
+		return false, true
+	}
+
+	v, ok := todoFieldValue(e.name, td)
+	if !ok {
+		// comparison with NULL column
+		return false, false
+	}
+
+	if e.op == ":" {
+		return strings.Contains(strings.ToLower(v.(string)), strings.ToLower(e.value.(string))), true
+	}
+
+	c := compareFilterValues(v, e.value)
+	switch e.op {
+	case "=":
+		return c == 0, true
+	case "!=":
+		return c != 0, true
+	case "<":
+		return c < 0, true
+	case "<=":
+		return c <= 0, true
+	case ">":
+		return c > 0, true
+	case ">=":
+		return c >= 0, true
+	}
+	return false, false
+}
+
+// todoFieldValue returns value of the filter field of the ToDo in the type
+// filterValue converts literals to, ok is false for unset timestamps
+func todoFieldValue(name string, td *v1.Todo) (interface{}, bool) {
+	switch name {
+	case "id":
+		return td.Id, true
+	case "title":
+		return td.Title, true
+	case "description":
+		return td.Description, true
+	case "reminder":
+		return timestampValue(td.Reminder)
+	case "status":
+		return int32(td.Status), true
+	case "completed_at":
+		return timestampValue(td.CompletedAt)
+	case "deleted_at":
+		return timestampValue(td.DeletedAt)
+	}
+	return nil, false
+}
+
+func timestampValue(ts *timestamp.Timestamp) (interface{}, bool) {
+	if ts == nil {
+		return nil, false
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return nil, false
+	}
+	return t, true
+}
+
+// compareFilterValues compares values of the same filter kind, strings are
+// compared case-insensitively like the default MySQL collation does
+func compareFilterValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		return compareInt64(a, b.(int64))
+	case int32:
+		return compareInt64(int64(a), int64(b.(int32)))
+	case string:
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b.(string)))
+	case time.Time:
+		b := b.(time.Time)
+		switch {
+		case a.Before(b):
+			return -1
+		case a.After(b):
+			return 1
+		}
+	}
+	return 0
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,442 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// filterKind is type of the value a filter field accepts
+type filterKind int
+
+const (
+	filterInt filterKind = iota
+	filterString
+	filterTimestamp
+	filterEnum
+	filterLabels
+)
+
+// filterField describes ToDo field that can be used in filter expressions
+type filterField struct {
+	column string
+	kind   filterKind
+
+	// enum maps names to values for the enum fields
+	enum map[string]int32
+}
+
+// filterFields is list of fields supported by ReadAll filter
+var filterFields = map[string]filterField{
+	"id":           {column: "`ID`", kind: filterInt},
+	"title":        {column: "`Title`", kind: filterString},
+	"description":  {column: "`Description`", kind: filterString},
+	"reminder":     {column: "`Reminder`", kind: filterTimestamp},
+	"status":       {column: "`Status`", kind: filterEnum, enum: v1.Todo_Status_value},
+	"completed_at": {column: "`CompletedAt`", kind: filterTimestamp},
+	"deleted_at":   {column: "`DeletedAt`", kind: filterTimestamp},
+	"labels":       {kind: filterLabels},
+}
+
+// filterError is returned when filter expression can not be parsed
+type filterError struct {
+	// Pos is 1-based position of the offending token in the expression
+	Pos int
+	Msg string
+}
+
+func (e *filterError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// filterExpr is node of parsed filter expression
+type filterExpr interface {
+	// sql writes parameterized SQL condition and appends its arguments
+	sql(b *strings.Builder, args []interface{}) []interface{}
+
+	// match evaluates the condition for the ToDo in memory the same way
+	// database evaluates its SQL, known is false where SQL result is NULL
+	match(td *v1.Todo) (result, known bool)
+}
+
+type filterAnd struct {
+	left, right filterExpr
+}
+
+func (e *filterAnd) sql(b *strings.Builder, args []interface{}) []interface{} {
+	b.WriteString("(")
+	args = e.left.sql(b, args)
+	b.WriteString(" AND ")
+	args = e.right.sql(b, args)
+	b.WriteString(")")
+	return args
+}
+
+type filterOr struct {
+	left, right filterExpr
+}
+
+func (e *filterOr) sql(b *strings.Builder, args []interface{}) []interface{} {
+	b.WriteString("(")
+	args = e.left.sql(b, args)
+	b.WriteString(" OR ")
+	args = e.right.sql(b, args)
+	b.WriteString(")")
+	return args
+}
+
+type filterNot struct {
+	expr filterExpr
+}
+
+func (e *filterNot) sql(b *strings.Builder, args []interface{}) []interface{} {
+	b.WriteString("NOT ")
+	return e.expr.sql(b, args)
+}
+
+// filterRestriction is single comparison of a field with a value
+type filterRestriction struct {
+	name  string
+	field filterField
+	op    string
+	value interface{}
+}
+
+func (e *filterRestriction) sql(b *strings.Builder, args []interface{}) []interface{} {
+	if e.field.kind == filterLabels {
+		b.WriteString("EXISTS (SELECT 1 FROM ToDoLabel tl JOIN Label l ON l.`ID`=tl.`LabelID` WHERE tl.`ToDoID`=ToDo.`ID` AND l.`Name`=?)")
+		return append(args, e.value)
+	}
+
+	b.WriteString(e.field.column)
+	if e.op == ":" {
+		b.WriteString(" LIKE ?")
+		return append(args, "%"+escapeLike(e.value.(string))+"%")
+	}
+	op := e.op
+	if op == "!=" {
+		op = "<>"
+	}
+	b.WriteString(" " + op + " ?")
+	return append(args, e.value)
+}
+
+// escapeLike escapes LIKE wildcards so that value is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// parseFilter parses AIP-160 style filter expression, e.g.
+// `title:"invoice" AND reminder < "2026-11-01T00:00:00Z"`.
+// Returns nil expression for the empty filter.
+func parseFilter(filter string) (filterExpr, error) {
+	p := &filterParser{lexer: filterLexer{input: filter}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokEOF {
+		return nil, nil
+	}
+
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+	return expr, nil
+}
+
+// filterSQL converts filter expression into SQL condition and its arguments
+func filterSQL(expr filterExpr) (string, []interface{}) {
+	var b strings.Builder
+	args := expr.sql(&b, nil)
+	return b.String(), args
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokText
+	tokString
+	tokComparator
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+	tokMinus
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of filter"
+	case tokString:
+		return strconv.Quote(t.text)
+	}
+	return "'" + t.text + "'"
+}
+
+type filterLexer struct {
+	input string
+	pos   int
+}
+
+func (l *filterLexer) next() (token, error) {
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.pos += size
+	}
+
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokEOF, pos: start + 1}, nil
+	}
+
+	switch c := l.input[l.pos]; c {
+	case '(':
+		l.pos++
+		return token{kind: tokLParen, text: "(", pos: start + 1}, nil
+	case ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")", pos: start + 1}, nil
+	case '-':
+		l.pos++
+		return token{kind: tokMinus, text: "-", pos: start + 1}, nil
+	case ':', '=':
+		l.pos++
+		return token{kind: tokComparator, text: string(c), pos: start + 1}, nil
+	case '<', '>', '!':
+		l.pos++
+		if l.pos < len(l.input) && l.input[l.pos] == '=' {
+			l.pos++
+		} else if c == '!' {
+			return token{}, &filterError{Pos: start + 1, Msg: "expected '=' after '!'"}
+		}
+		return token{kind: tokComparator, text: l.input[start:l.pos], pos: start + 1}, nil
+	case '"', '\'':
+		return l.quoted(c)
+	}
+
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !(unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.-+", r)) {
+			break
+		}
+		l.pos += size
+	}
+	if l.pos == start {
+		r, _ := utf8.DecodeRuneInString(l.input[l.pos:])
+		return token{}, &filterError{Pos: start + 1, Msg: fmt.Sprintf("unexpected character %q", r)}
+	}
+
+	text := l.input[start:l.pos]
+	t := token{kind: tokText, text: text, pos: start + 1}
+	switch text {
+	case "AND":
+		t.kind = tokAnd
+	case "OR":
+		t.kind = tokOr
+	case "NOT":
+		t.kind = tokNot
+	}
+	return t, nil
+}
+
+func (l *filterLexer) quoted(quote byte) (token, error) {
+	start := l.pos
+	l.pos++
+
+	var b strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case c == quote:
+			l.pos++
+			return token{kind: tokString, text: b.String(), pos: start + 1}, nil
+		case c == '\\' && l.pos+1 < len(l.input):
+			b.WriteByte(l.input[l.pos+1])
+			l.pos += 2
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return token{}, &filterError{Pos: start + 1, Msg: "unterminated string"}
+}
+
+type filterParser struct {
+	lexer filterLexer
+	tok   token
+}
+
+func (p *filterParser) next() error {
+	t, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = t
+	return nil
+}
+
+func (p *filterParser) errorf(format string, a ...interface{}) error {
+	return &filterError{Pos: p.tok.pos, Msg: fmt.Sprintf(format, a...)}
+}
+
+// parseExpression parses sequence of factors joined by AND or whitespace
+func (p *filterParser) parseExpression() (filterExpr, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch p.tok.kind {
+		case tokEOF, tokRParen:
+			return left, nil
+		case tokAnd:
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterAnd{left: left, right: right}
+	}
+}
+
+// parseFactor parses terms joined by OR, which binds tighter than AND
+func (p *filterParser) parseFactor() (filterExpr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.kind == tokOr {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseTerm() (filterExpr, error) {
+	if p.tok.kind == tokNot || p.tok.kind == tokMinus {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseSimple()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNot{expr: expr}, nil
+	}
+	return p.parseSimple()
+}
+
+func (p *filterParser) parseSimple() (filterExpr, error) {
+	if p.tok.kind == tokLParen {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.errorf("expected ')' but found %s", p.tok)
+		}
+		return expr, p.next()
+	}
+	return p.parseRestriction()
+}
+
+func (p *filterParser) parseRestriction() (filterExpr, error) {
+	if p.tok.kind != tokText {
+		return nil, p.errorf("expected field name but found %s", p.tok)
+	}
+	name := p.tok
+	field, ok := filterFields[name.text]
+	if !ok {
+		return nil, p.errorf("unknown field '%s'", name.text)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != tokComparator {
+		return nil, p.errorf("expected comparator after '%s' but found %s", name.text, p.tok)
+	}
+	op := p.tok
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != tokText && p.tok.kind != tokString {
+		return nil, p.errorf("expected value but found %s", p.tok)
+	}
+	arg := p.tok
+
+	if !filterOperatorAllowed(field.kind, op.text) {
+		return nil, &filterError{Pos: op.pos, Msg: fmt.Sprintf("operator '%s' is not supported for '%s'", op.text, name.text)}
+	}
+	value, err := filterValue(field, arg.text)
+	if err != nil {
+		return nil, &filterError{Pos: arg.pos, Msg: fmt.Sprintf("invalid value for '%s'", name.text)}
+	}
+
+	return &filterRestriction{name: name.text, field: field, op: op.text, value: value}, p.next()
+}
+
+// filterOperatorAllowed reports whether comparator can be used with the field kind
+func filterOperatorAllowed(kind filterKind, op string) bool {
+	switch kind {
+	case filterString:
+		return true
+	case filterLabels:
+		return op == ":"
+	}
+	return op != ":"
+}
+
+// filterValue converts literal to the type of the field
+func filterValue(field filterField, text string) (interface{}, error) {
+	switch field.kind {
+	case filterInt:
+		return strconv.ParseInt(text, 10, 64)
+	case filterTimestamp:
+		return time.Parse(time.RFC3339Nano, text)
+	case filterEnum:
+		v, ok := field.enum[text]
+		if !ok {
+			return nil, fmt.Errorf("unknown value '%s'", text)
+		}
+		return v, nil
+	}
+	return text, nil
+}
@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func Test_parseFilter(t *testing.T) {
+	tm, _ := time.Parse(time.RFC3339, "2026-11-01T00:00:00Z")
+
+	tests := []struct {
+		name     string
+		filter   string
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "Substring",
+			filter:   `title:"invoice"`,
+			wantSQL:  "`Title` LIKE ?",
+			wantArgs: []interface{}{"%invoice%"},
+		},
+		{
+			name:     "Escape wildcards",
+			filter:   `description:"100%_done"`,
+			wantSQL:  "`Description` LIKE ?",
+			wantArgs: []interface{}{`%100\%\_done%`},
+		},
+		{
+			name:     "AND with timestamp",
+			filter:   `title:"invoice" AND reminder < "2026-11-01T00:00:00Z"`,
+			wantSQL:  "(`Title` LIKE ? AND `Reminder` < ?)",
+			wantArgs: []interface{}{"%invoice%", tm},
+		},
+		{
+			name:     "OR binds tighter than AND",
+			filter:   `id = 1 AND id = 2 OR id != 3`,
+			wantSQL:  "(`ID` = ? AND (`ID` = ? OR `ID` <> ?))",
+			wantArgs: []interface{}{int64(1), int64(2), int64(3)},
+		},
+		{
+			name:     "Status",
+			filter:   `status = DONE OR status = IN_PROGRESS`,
+			wantSQL:  "(`Status` = ? OR `Status` = ?)",
+			wantArgs: []interface{}{int32(2), int32(1)},
+		},
+		{
+			name:     "Labels",
+			filter:   `labels:backend`,
+			wantSQL:  "EXISTS (SELECT 1 FROM ToDoLabel tl JOIN Label l ON l.`ID`=tl.`LabelID` WHERE tl.`ToDoID`=ToDo.`ID` AND l.`Name`=?)",
+			wantArgs: []interface{}{"backend"},
+		},
+		{
+			name:     "Implicit AND, NOT and parentheses",
+			filter:   `NOT (title = a OR title = b) -description:x`,
+			wantSQL:  "(NOT (`Title` = ? OR `Title` = ?) AND NOT `Description` LIKE ?)",
+			wantArgs: []interface{}{"a", "b", "%x%"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("parseFilter() error = %v", err)
+			}
+			gotSQL, gotArgs := filterSQL(expr)
+			if gotSQL != tt.wantSQL {
+				t.Errorf("filterSQL() sql = %v, want %v", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("filterSQL() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func Test_parseFilter_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		wantPos int
+	}{
+		{"Unknown field", `title:a AND owner = b`, 13},
+		{"Missing value", `title:`, 7},
+		{"Missing comparator", `title a`, 7},
+		{"Unterminated string", `title:"abc`, 7},
+		{"Unbalanced parentheses", `(title:a`, 9},
+		{"Has on timestamp", `reminder:"2026"`, 9},
+		{"Invalid timestamp", `reminder < "tomorrow"`, 12},
+		{"Invalid integer", `id = abc`, 6},
+		{"Unknown status", `status = CLOSED`, 10},
+		{"Equality on labels", `labels = a`, 8},
+		{"Trailing AND", `id = 1 AND`, 11},
+		{"Unexpected character", `id = 1 & id = 2`, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseFilter(tt.filter)
+			ferr, ok := err.(*filterError)
+			if !ok {
+				t.Fatalf("parseFilter() error = %v, want filterError", err)
+			}
+			if ferr.Pos != tt.wantPos {
+				t.Errorf("parseFilter() error position = %d, want %d (%v)", ferr.Pos, tt.wantPos, ferr)
+			}
+		})
+	}
+}
+
+func Test_filterMatch(t *testing.T) {
+	reminder, _ := ptypes.TimestampProto(time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC))
+	td := &v1.Todo{
+		Id:          7,
+		Title:       "Send Invoice",
+		Description: "to the customer",
+		Reminder:    reminder,
+		Status:      v1.Todo_IN_PROGRESS,
+		Labels:      []string{"billing"},
+	}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{``, true},
+		{`title:"invoice"`, true},
+		{`title:"receipt"`, false},
+		{`title = "send invoice"`, true},
+		{`id >= 7 AND id < 8`, true},
+		{`id = 1 OR id = 7`, true},
+		{`status = IN_PROGRESS`, true},
+		{`status > IN_PROGRESS`, false},
+		{`reminder < "2026-11-01T00:00:00Z"`, true},
+		{`labels:billing`, true},
+		{`-labels:billing`, false},
+		{`labels:urgent`, false},
+		// comparisons with unset timestamps are NULL in SQL, also when negated
+		{`completed_at < "2026-11-01T00:00:00Z"`, false},
+		{`NOT completed_at < "2026-11-01T00:00:00Z"`, false},
+		{`completed_at < "2026-11-01T00:00:00Z" OR id = 7`, true},
+		{`completed_at < "2026-11-01T00:00:00Z" AND id = 7`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			expr, err := parseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("parseFilter() error = %v", err)
+			}
+			if got := filterMatch(expr, td); got != tt.want {
+				t.Errorf("filterMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// idempotencyKeyHeader is metadata key clients can send the idempotency
+	// key in, REST gateway maps the Idempotency-Key header to it
+	idempotencyKeyHeader = "idempotency-key"
+
+	// maxIdempotencyKeyLength is the maximum length of the idempotency key
+	maxIdempotencyKeyLength = 128
+
+	// defaultIdempotencyWindow is how long responses are kept for retries
+	defaultIdempotencyWindow = 24 * time.Hour
+
+	// mysqlErrDeadlock is MySQL error number for transaction rolled back
+	// because of a deadlock
+	mysqlErrDeadlock = 1213
+)
+
+// WithIdempotencyWindow sets how long responses of requests sent with
+// idempotency key are returned on retries
+func WithIdempotencyWindow(d time.Duration) Option {
+	return func(s *todoServiceServer) {
+		if d > 0 {
+			s.idempotencyWindow = d
+		}
+	}
+}
+
+// idempotencyKey identifies request whose response is stored to be returned
+// on retries
+type idempotencyKey struct {
+	tenant string
+	key    string
+	method string
+
+	// hash is fingerprint of the request payload, retry must have the same
+	hash []byte
+}
+
+// newIdempotencyKey returns key of the request, nil if client did not send
+// any. The key field of the request takes precedence over Idempotency-Key
+// metadata. The fingerprint is computed from req, which must have api and
+// idempotency_key fields cleared. Keys of different tenants never match.
+func newIdempotencyKey(ctx context.Context, key, method string, req proto.Message) (*idempotencyKey, error) {
+	if len(key) == 0 {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(idempotencyKeyHeader); len(values) > 0 {
+				key = values[0]
+			}
+		}
+	}
+	if len(key) == 0 {
+		return nil, nil
+	}
+	if utf8.RuneCountInString(key) > maxIdempotencyKeyLength {
+		return nil, status.Errorf(codes.InvalidArgument, "idempotency_key field is longer than %d characters", maxIdempotencyKeyLength)
+	}
+
+	// deterministic encoding makes equal requests have equal fingerprints
+	var b proto.Buffer
+	b.SetDeterministic(true)
+	if err := b.Marshal(req); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to encode request-> "+err.Error())
+	}
+	hash := sha256.Sum256(b.Bytes())
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &idempotencyKey{tenant: tenant, key: key, method: method, hash: hash[:]}, nil
+}
+
+// replay decodes stored response of the request with the same key into resp
+// and reports whether it was found. It must be called in the transaction
+// making the change, the row lock serializes concurrent retries. Response
+// older than idempotency window is removed and the key is used again.
+func (s *todoServiceServer) replay(ctx context.Context, q querier, k *idempotencyKey, resp proto.Message) (bool, error) {
+	if k == nil {
+		return false, nil
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT `RequestHash`, `Response`, `CreatedAt` FROM IdempotencyKey WHERE `Owner`=? AND `Key`=? AND `Method`=? FOR UPDATE",
+		k.tenant, k.key, k.method)
+	if err != nil {
+		return false, status.Error(codes.Unknown, "failed to select from IdempotencyKey-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return false, status.Error(codes.Unknown, "failed to retrieve data from IdempotencyKey-> "+err.Error())
+		}
+		return false, nil
+	}
+
+	var hash, stored []byte
+	var createdAt time.Time
+	if err := rows.Scan(&hash, &stored, &createdAt); err != nil {
+		return false, status.Error(codes.Unknown, "failed to retrieve field values from IdempotencyKey row-> "+err.Error())
+	}
+	rows.Close()
+
+	if s.now().Sub(createdAt) >= s.idempotencyWindow {
+		if _, err := q.ExecContext(ctx, "DELETE FROM IdempotencyKey WHERE `Owner`=? AND `Key`=? AND `Method`=?", k.tenant, k.key, k.method); err != nil {
+			return false, status.Error(codes.Unknown, "failed to delete from IdempotencyKey-> "+err.Error())
+		}
+		return false, nil
+	}
+
+	if !bytes.Equal(hash, k.hash) {
+		return false, status.Errorf(codes.AlreadyExists, "idempotency key '%s' was already used for another request", k.key)
+	}
+
+	if err := proto.Unmarshal(stored, resp); err != nil {
+		return false, status.Error(codes.Unknown, "failed to decode stored response-> "+err.Error())
+	}
+	return true, nil
+}
+
+// storeResponse saves response of the request to be returned on retries, it
+// must be called in the transaction making the change
+func (s *todoServiceServer) storeResponse(ctx context.Context, q querier, k *idempotencyKey, resp proto.Message) error {
+	if k == nil {
+		return nil
+	}
+
+	b, err := proto.Marshal(resp)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to encode response-> "+err.Error())
+	}
+
+	if _, err := q.ExecContext(ctx, "INSERT INTO IdempotencyKey(`Owner`, `Key`, `Method`, `RequestHash`, `Response`, `CreatedAt`) VALUES(?, ?, ?, ?, ?, ?)",
+		k.tenant, k.key, k.method, k.hash, b, s.now().UTC()); err != nil {
+		if me, ok := err.(*mysql.MySQLError); ok && (me.Number == mysqlErrDuplicateEntry || me.Number == mysqlErrDeadlock) {
+			// another request with the same key is applied concurrently
+			return status.Errorf(codes.Aborted, "request with idempotency key '%s' is in progress, retry it", k.key)
+		}
+		return status.Error(codes.Unknown, "failed to insert into IdempotencyKey-> "+err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func Test_toDoServiceServer_Create_idempotency(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	todo := &v1.Todo{Title: "title", Reminder: reminder}
+	other := &v1.Todo{Title: "other", Reminder: reminder}
+	idem, err := newIdempotencyKey(ctx, "key-1", "Create", &v1.CreateRequest{Todo: todo})
+	if err != nil {
+		t.Fatalf("newIdempotencyKey() error = %v", err)
+	}
+	stored, _ := proto.Marshal(&v1.CreateResponse{Api: "v1", Id: 7, Etag: `"1"`})
+	keyColumns := []string{"RequestHash", "Response", "CreatedAt"}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *v1.CreateRequest
+		mock     func()
+		wantID   int64
+		wantCode codes.Code
+	}{
+		{
+			name: "New key",
+			ctx:  ctx,
+			req:  &v1.CreateRequest{Api: "v1", Todo: todo, IdempotencyKey: "key-1"},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM IdempotencyKey WHERE `Owner`=\\? AND `Key`=\\? AND `Method`=\\? FOR UPDATE").
+					WithArgs("", "key-1", "Create").WillReturnRows(sqlmock.NewRows(keyColumns))
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
+					WillReturnResult(sqlmock.NewResult(7, 1))
+				expectRecordChange(mock, 7, 1)
+				mock.ExpectExec("INSERT INTO IdempotencyKey").WithArgs("", "key-1", "Create", idem.hash, stored, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantID: 7,
+		},
+		{
+			name: "Replay",
+			ctx:  metadata.NewIncomingContext(ctx, metadata.Pairs(idempotencyKeyHeader, "key-1")),
+			req:  &v1.CreateRequest{Todo: todo},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM IdempotencyKey").WithArgs("", "key-1", "Create").
+					WillReturnRows(sqlmock.NewRows(keyColumns).AddRow(idem.hash, stored, tm))
+				mock.ExpectRollback()
+			},
+			wantID: 7,
+		},
+		{
+			name: "Different payload",
+			ctx:  ctx,
+			req:  &v1.CreateRequest{Api: "v1", Todo: other, IdempotencyKey: "key-1"},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM IdempotencyKey").WithArgs("", "key-1", "Create").
+					WillReturnRows(sqlmock.NewRows(keyColumns).AddRow(idem.hash, stored, tm))
+				mock.ExpectRollback()
+			},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name: "Expired key",
+			ctx:  ctx,
+			req:  &v1.CreateRequest{Api: "v1", Todo: todo, IdempotencyKey: "key-1"},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM IdempotencyKey").WithArgs("", "key-1", "Create").
+					WillReturnRows(sqlmock.NewRows(keyColumns).AddRow(idem.hash, stored, tm.Add(-defaultIdempotencyWindow)))
+				mock.ExpectExec("DELETE FROM IdempotencyKey").WithArgs("", "key-1", "Create").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
+					WillReturnResult(sqlmock.NewResult(8, 1))
+				expectRecordChange(mock, 8, 1)
+				mock.ExpectExec("INSERT INTO IdempotencyKey").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantID: 8,
+		},
+		{
+			name:     "Too long key",
+			ctx:      ctx,
+			req:      &v1.CreateRequest{Api: "v1", Todo: todo, IdempotencyKey: strings.Repeat("k", maxIdempotencyKeyLength+1)},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := s.Create(tt.ctx, tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("toDoServiceServer.Create() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err == nil && got.Id != tt.wantID {
+				t.Errorf("toDoServiceServer.Create() id = %v, want %v", got.Id, tt.wantID)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_BatchDelete_replay(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+
+	req := &v1.BatchDeleteRequest{
+		Api:            "v1",
+		Requests:       []*v1.DeleteRequest{{Id: 1}, {Id: 2}},
+		IdempotencyKey: "key-2",
+	}
+	idem, err := newIdempotencyKey(ctx, "key-2", "BatchDelete", &v1.BatchDeleteRequest{Requests: req.Requests})
+	if err != nil {
+		t.Fatalf("newIdempotencyKey() error = %v", err)
+	}
+	stored, _ := proto.Marshal(&v1.BatchDeleteResponse{Api: "v1", Results: []*v1.BatchResult{
+		{Id: 1, Status: status.New(codes.OK, "").Proto()},
+		{Id: 2, Status: status.New(codes.OK, "").Proto()},
+	}})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM IdempotencyKey").WithArgs("", "key-2", "BatchDelete").
+		WillReturnRows(sqlmock.NewRows([]string{"RequestHash", "Response", "CreatedAt"}).AddRow(idem.hash, stored, time.Now()))
+	mock.ExpectRollback()
+
+	got, err := s.BatchDelete(ctx, req)
+	if err != nil {
+		t.Fatalf("toDoServiceServer.BatchDelete() error = %v", err)
+	}
+	if len(got.Results) != 2 || got.Results[1].Id != 2 {
+		t.Errorf("toDoServiceServer.BatchDelete() results = %v", got.Results)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
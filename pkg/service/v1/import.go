@@ -0,0 +1,171 @@
+package v1
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc/codes"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// importBatchSize is number of todos created in a single transaction
+	importBatchSize = 100
+
+	// maxImportErrors is the maximum number of failures reported in detail
+	maxImportErrors = 1000
+)
+
+// importBatch collects todos received from the import stream until they are
+// created in a single transaction
+type importBatch struct {
+	// next is index of the next todo in the stream
+	next int64
+
+	// indexes are indexes of the todos of the batch in the stream
+	indexes []int64
+	todos   []*v1.Todo
+	items   []batchItem
+}
+
+// add validates the todo and appends it to the batch
+func (b *importBatch) add(s *todoServiceServer, td *v1.Todo) {
+	var item batchItem
+	ins, err := prepareCreate(td)
+	if err != nil {
+		item.err = err
+	} else {
+		item.run = func(ctx context.Context, q querier) (*v1.BatchResult, error) {
+			id, err := s.insert(ctx, q, ins)
+			if err != nil {
+				return nil, err
+			}
+			return &v1.BatchResult{Id: id, Etag: formatETag(1)}, nil
+		}
+	}
+
+	b.append(td, item)
+}
+
+// reject appends the todo which failed to convert from imported file, it is
+// reported as failed
+func (b *importBatch) reject(td *v1.Todo, err error) {
+	b.append(td, batchItem{err: err})
+}
+
+// skip skips index of the row which is not a todo, such as blank line
+func (b *importBatch) skip() {
+	b.next++
+}
+
+func (b *importBatch) append(td *v1.Todo, item batchItem) {
+	b.indexes = append(b.indexes, b.next)
+	b.todos = append(b.todos, td)
+	b.items = append(b.items, item)
+	b.next++
+}
+
+// flush creates todos of the batch and adds the results to the summary,
+// failure of a todo does not roll back the other todos of the batch
+func (b *importBatch) flush(ctx context.Context, s *todoServiceServer, summary *v1.ImportSummary) error {
+	if len(b.items) == 0 {
+		return nil
+	}
+
+	// imported todos are de-duplicated by external ID, not idempotency key
+	var results []*v1.BatchResult
+	if err := s.runBatch(ctx, nil, true, b.items, nil, &results); err != nil {
+		return err
+	}
+
+	for i, res := range results {
+		switch codes.Code(res.Status.Code) {
+		case codes.OK:
+			summary.Created++
+		case codes.AlreadyExists:
+			// todo with the same external ID was imported before
+			summary.Skipped++
+		default:
+			summary.Failed++
+			if len(summary.Errors) < maxImportErrors {
+				summary.Errors = append(summary.Errors, &v1.ImportError{
+					Index:      b.indexes[i],
+					ExternalId: b.todos[i].GetExternalId(),
+					Status:     res.Status,
+				})
+			}
+		}
+	}
+
+	b.indexes = b.indexes[:0]
+	b.todos = b.todos[:0]
+	b.items = b.items[:0]
+	return nil
+}
+
+// importRow is todo converted from a row of imported file, err is set if the
+// row is invalid. Row without todo and error is skipped.
+type importRow struct {
+	todo *v1.Todo
+	err  error
+}
+
+// importRows creates todos of the rows of imported file in batches, like
+// ImportTodos does
+func (s *todoServiceServer) importRows(ctx context.Context, rows []importRow) (*v1.ImportSummary, error) {
+	summary := &v1.ImportSummary{Api: apiVersion}
+	batch := &importBatch{}
+	for _, row := range rows {
+		if row.todo == nil && row.err == nil {
+			batch.skip()
+			continue
+		}
+		if row.err != nil {
+			batch.reject(row.todo, row.err)
+		} else {
+			batch.add(s, row.todo)
+		}
+
+		if len(batch.items) == importBatchSize {
+			if err := batch.flush(ctx, s, summary); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := batch.flush(ctx, s, summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// ImportTodos creates todo tasks received from the stream
+func (s *todoServiceServer) ImportTodos(stream v1.TodoService_ImportTodosServer) error {
+	ctx := stream.Context()
+	summary := &v1.ImportSummary{Api: apiVersion}
+	batch := &importBatch{}
+
+	for {
+		td, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		batch.add(s, td)
+		if len(batch.items) == importBatchSize {
+			if err := batch.flush(ctx, s, summary); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := batch.flush(ctx, s, summary); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(summary)
+}
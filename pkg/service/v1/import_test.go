@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// importStream feeds todos to ImportTodos and collects the summary
+type importStream struct {
+	grpc.ServerStream
+	todos   []*v1.Todo
+	summary *v1.ImportSummary
+}
+
+func (s *importStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *importStream) Recv() (*v1.Todo, error) {
+	if len(s.todos) == 0 {
+		return nil, io.EOF
+	}
+	td := s.todos[0]
+	s.todos = s.todos[1:]
+	return td, nil
+}
+
+func (s *importStream) SendAndClose(summary *v1.ImportSummary) error {
+	s.summary = summary
+	return nil
+}
+
+func Test_toDoServiceServer_ImportTodos(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	stream := &importStream{todos: []*v1.Todo{
+		{Title: "first", Reminder: reminder, ExternalId: "a-1"},
+		{Title: "second", Reminder: reminder, ExternalId: "a-2"},
+		{Title: "third", Reminder: reminder, ExternalId: "a-3", Status: 10},
+	}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO ToDo").WithArgs("first", "", tm, v1.Todo_OPEN, nil, "a-1", "", "", nil, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectRecordChange(mock, 1, 1)
+	mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO ToDo").WithArgs("second", "", tm, v1.Todo_OPEN, nil, "a-2", "", "", nil, "").
+		WillReturnError(&mysql.MySQLError{Number: mysqlErrDuplicateEntry, Message: "Duplicate entry 'a-2'"})
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	if err := s.ImportTodos(stream); err != nil {
+		t.Fatalf("toDoServiceServer.ImportTodos() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	got := stream.summary
+	if got.Created != 1 || got.Skipped != 1 || got.Failed != 1 {
+		t.Errorf("toDoServiceServer.ImportTodos() created = %d, skipped = %d, failed = %d, want 1, 1, 1",
+			got.Created, got.Skipped, got.Failed)
+	}
+	if len(got.Errors) != 1 || got.Errors[0].Index != 2 || got.Errors[0].ExternalId != "a-3" ||
+		codes.Code(got.Errors[0].Status.Code) != codes.InvalidArgument {
+		t.Errorf("toDoServiceServer.ImportTodos() errors = %v", got.Errors)
+	}
+}
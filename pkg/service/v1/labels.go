@@ -0,0 +1,335 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-sql-driver/mysql"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// maxLabelLength is the maximum length of label name
+	maxLabelLength = 64
+
+	// mysqlErrDuplicateEntry is MySQL error number for unique key violation
+	mysqlErrDuplicateEntry = 1062
+)
+
+// normalizeLabel trims the label name and checks its length
+func normalizeLabel(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return "", status.Error(codes.InvalidArgument, "label name must not be empty")
+	}
+	if utf8.RuneCountInString(name) > maxLabelLength {
+		return "", status.Errorf(codes.InvalidArgument, "label name '%s' is longer than %d characters", name, maxLabelLength)
+	}
+	return name, nil
+}
+
+// sameLabel reports whether the names are of the same label, label names
+// are compared ignoring case like the unique index of the Label table does
+func sameLabel(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+// normalizeLabels validates labels and removes duplicates, the first spelling
+// of the label is kept
+func normalizeLabels(labels []string) ([]string, error) {
+	list := make([]string, 0, len(labels))
+	for _, l := range labels {
+		name, err := normalizeLabel(l)
+		if err != nil {
+			return nil, err
+		}
+		duplicate := false
+		for _, other := range list {
+			if sameLabel(name, other) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			list = append(list, name)
+		}
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// setLabels replaces labels of the ToDo
+func setLabels(ctx context.Context, q querier, id int64, labels []string) error {
+	if _, err := q.ExecContext(ctx, "DELETE FROM ToDoLabel WHERE `ToDoID`=?", id); err != nil {
+		return status.Error(codes.Unknown, "failed to delete from ToDoLabel-> "+err.Error())
+	}
+	return addLabels(ctx, q, id, labels)
+}
+
+// addLabels attaches labels to the ToDo, missing labels of the tenant are
+// created
+func addLabels(ctx context.Context, q querier, id int64, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range labels {
+		// LAST_INSERT_ID(expr) makes ID of the existing label available as insert ID
+		res, err := q.ExecContext(ctx, "INSERT INTO Label(`Owner`, `Name`) VALUES(?, ?) ON DUPLICATE KEY UPDATE `ID`=LAST_INSERT_ID(`ID`)", tenant, name)
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to insert into Label-> "+err.Error())
+		}
+		labelID, err := res.LastInsertId()
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve id for Label-> "+err.Error())
+		}
+
+		if _, err := q.ExecContext(ctx, "INSERT INTO ToDoLabel(`ToDoID`, `LabelID`) VALUES(?, ?)", id, labelID); err != nil {
+			return status.Error(codes.Unknown, "failed to insert into ToDoLabel-> "+err.Error())
+		}
+	}
+	return nil
+}
+
+// loadLabels reads labels of the given ToDo list in a single query
+func loadLabels(ctx context.Context, q querier, list []*v1.Todo) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*v1.Todo, len(list))
+	ids := make([]interface{}, 0, len(list))
+	for _, td := range list {
+		byID[td.Id] = td
+		ids = append(ids, td.Id)
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT tl.`ToDoID`, l.`Name` FROM ToDoLabel tl JOIN Label l ON l.`ID`=tl.`LabelID` WHERE tl.`ToDoID` IN (?"+
+		strings.Repeat(", ?", len(ids)-1)+") ORDER BY l.`Name`", ids...)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDoLabel-> "+err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve field values from ToDoLabel row-> "+err.Error())
+		}
+		if td, ok := byID[id]; ok {
+			td.Labels = append(td.Labels, name)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return status.Error(codes.Unknown, "failed to retrieve data from ToDoLabel-> "+err.Error())
+	}
+	return nil
+}
+
+// ListLabels returns all labels of the tenant with number of todos having them
+func (s *todoServiceServer) ListLabels(ctx context.Context, req *v1.ListLabelsRequest) (*v1.ListLabelsResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// todos in trash are not counted
+	rows, err := c.QueryContext(ctx, "SELECT l.`Name`, COUNT(t.`ID`) FROM Label l LEFT JOIN ToDoLabel tl ON tl.`LabelID`=l.`ID` "+
+		"LEFT JOIN ToDo t ON t.`ID`=tl.`ToDoID` AND t.`DeletedAt` IS NULL WHERE l.`Owner`=? GROUP BY l.`ID`, l.`Name` ORDER BY l.`Name`", tenant)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from Label-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.Label{}
+	for rows.Next() {
+		l := new(v1.Label)
+		if err := rows.Scan(&l.Name, &l.TodoCount); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from Label row-> "+err.Error())
+		}
+		list = append(list, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from Label-> "+err.Error())
+	}
+
+	return &v1.ListLabelsResponse{
+		Api:    apiVersion,
+		Labels: list,
+	}, nil
+}
+
+// lockLabelTodos locks todos having the label of the tenant and returns them
+func lockLabelTodos(ctx context.Context, q querier, tenant, name string) ([]*v1.Todo, error) {
+	rows, err := q.QueryContext(ctx, "SELECT tl.`ToDoID` FROM ToDoLabel tl JOIN Label l ON l.`ID`=tl.`LabelID` WHERE l.`Owner`=? AND l.`Name`=? ORDER BY tl.`ToDoID`",
+		tenant, name)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoLabel-> "+err.Error())
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDoLabel row-> "+err.Error())
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoLabel-> "+err.Error())
+	}
+	rows.Close()
+
+	list := make([]*v1.Todo, 0, len(ids))
+	for _, id := range ids {
+		td, err := lockTodo(ctx, q, id)
+		if err != nil {
+			return nil, err
+		}
+		if td != nil {
+			list = append(list, td)
+		}
+	}
+	return list, nil
+}
+
+// RenameLabel changes name of the label of the tenant for all todos having it
+func (s *todoServiceServer) RenameLabel(ctx context.Context, req *v1.RenameLabelRequest) (*v1.RenameLabelResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// labels are stored normalized, so the name is looked up the same way
+	name, err := normalizeLabel(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	newName, err := normalizeLabel(req.NewName)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	// renaming to the same name changes nothing, the label is returned as is
+	if name == newName {
+		var count int64
+		err := c.QueryRowContext(ctx, "SELECT COUNT(t.`ID`) FROM Label l LEFT JOIN ToDoLabel tl ON tl.`LabelID`=l.`ID` "+
+			"LEFT JOIN ToDo t ON t.`ID`=tl.`ToDoID` AND t.`DeletedAt` IS NULL WHERE l.`Owner`=? AND l.`Name`=? GROUP BY l.`ID`", tenant, name).
+			Scan(&count)
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("Label with name='%s' is not found",
+				name))
+		}
+		if err != nil {
+			return nil, status.Error(codes.Unknown, "failed to select from Label-> "+err.Error())
+		}
+		return &v1.RenameLabelResponse{
+			Api: apiVersion,
+			Label: &v1.Label{
+				Name:      name,
+				TodoCount: count,
+			},
+		}, nil
+	}
+
+	tx, err := s.begin(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// todos having the label are locked before the change for their history
+	befores, err := lockLabelTodos(ctx, tx, tenant, name)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE Label SET `Name`=? WHERE `Owner`=? AND `Name`=?", newName, tenant, name)
+	if err != nil {
+		if me, ok := err.(*mysql.MySQLError); ok && me.Number == mysqlErrDuplicateEntry {
+			return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("Label with name='%s' already exists", newName))
+		}
+		return nil, status.Error(codes.Unknown, "failed to update Label-> "+err.Error())
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+
+	if rows == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Label with name='%s' is not found",
+			name))
+	}
+
+	// labels are part of the todos, so all todos having the label are changed
+	if _, err := tx.ExecContext(ctx, "UPDATE ToDo t JOIN ToDoLabel tl ON tl.`ToDoID`=t.`ID` JOIN Label l ON l.`ID`=tl.`LabelID` "+
+		"SET t.`Version`=t.`Version`+1 WHERE l.`Owner`=? AND l.`Name`=?", tenant, newName); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+	}
+	for _, before := range befores {
+		if _, err := s.recordChange(ctx, tx, before.Id, v1.TodoEvent_UPDATED, before); err != nil {
+			return nil, err
+		}
+	}
+
+	var count int64
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM ToDoLabel tl JOIN Label l ON l.`ID`=tl.`LabelID` JOIN ToDo t ON t.`ID`=tl.`ToDoID` WHERE l.`Owner`=? AND l.`Name`=? AND t.`DeletedAt` IS NULL",
+		tenant, newName).
+		Scan(&count); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoLabel-> "+err.Error())
+	}
+
+	if err := s.commit(tx); err != nil {
+		return nil, err
+	}
+
+	return &v1.RenameLabelResponse{
+		Api: apiVersion,
+		Label: &v1.Label{
+			Name:      newName,
+			TodoCount: count,
+		},
+	}, nil
+}
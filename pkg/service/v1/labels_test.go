@@ -0,0 +1,249 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+func Test_normalizeLabels(t *testing.T) {
+	// labels differing in case only are the same label, the first spelling
+	// is kept
+	got, err := normalizeLabels([]string{" urgent", "Bug", "bug ", "URGENT", "backend"})
+	if err != nil {
+		t.Fatalf("normalizeLabels() error = %v", err)
+	}
+	if want := []string{"Bug", "backend", "urgent"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeLabels() = %v, want %v", got, want)
+	}
+
+	if _, err := normalizeLabels([]string{"bug", " "}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("normalizeLabels() error = %v, wantCode %v", err, codes.InvalidArgument)
+	}
+}
+
+func Test_toDoServiceServer_ListLabels(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+
+	rows := sqlmock.NewRows([]string{"Name", "Count"}).
+		AddRow("backend", 3).
+		AddRow("urgent", 0)
+	mock.ExpectQuery("SELECT (.+) FROM Label").WillReturnRows(rows)
+
+	got, err := s.ListLabels(ctx, &v1.ListLabelsRequest{Api: "v1"})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.ListLabels() error = %v", err)
+	}
+	want := &v1.ListLabelsResponse{
+		Api: "v1",
+		Labels: []*v1.Label{
+			{Name: "backend", TodoCount: 3},
+			{Name: "urgent", TodoCount: 0},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("toDoServiceServer.ListLabels() = %v, want %v", got, want)
+	}
+}
+
+func Test_toDoServiceServer_RenameLabel(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.RenameLabelRequest
+	}
+	tests := []struct {
+		name     string
+		s        v1.TodoServiceServer
+		args     args
+		mock     func()
+		want     *v1.RenameLabelResponse
+		wantCode codes.Code
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RenameLabelRequest{
+					Api:     "v1",
+					Name:    " backend ",
+					NewName: " server ",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT tl.`ToDoID` FROM ToDoLabel").WithArgs("", "backend").
+					WillReturnRows(sqlmock.NewRows([]string{"ToDoID"}).AddRow(1).AddRow(2))
+				expectLock(mock, 1, 1)
+				expectLock(mock, 2, 1)
+				mock.ExpectExec("UPDATE Label").WithArgs("server", "", "backend").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE ToDo t JOIN ToDoLabel").WithArgs("", "server").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectRecordChange(mock, 1, 2)
+				expectRecordChange(mock, 2, 2)
+				mock.ExpectQuery("SELECT COUNT(.+) FROM ToDoLabel").WithArgs("", "server").
+					WillReturnRows(sqlmock.NewRows([]string{"Count"}).AddRow(3))
+				mock.ExpectCommit()
+			},
+			want: &v1.RenameLabelResponse{
+				Api:   "v1",
+				Label: &v1.Label{Name: "server", TodoCount: 3},
+			},
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RenameLabelRequest{
+					Api:     "v1",
+					Name:    "backend",
+					NewName: "server",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT tl.`ToDoID` FROM ToDoLabel").WithArgs("", "backend").
+					WillReturnRows(sqlmock.NewRows([]string{"ToDoID"}))
+				mock.ExpectExec("UPDATE Label").WithArgs("server", "", "backend").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Already exists",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RenameLabelRequest{
+					Api:     "v1",
+					Name:    "backend",
+					NewName: "server",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT tl.`ToDoID` FROM ToDoLabel").WithArgs("", "backend").
+					WillReturnRows(sqlmock.NewRows([]string{"ToDoID"}))
+				mock.ExpectExec("UPDATE Label").WithArgs("server", "", "backend").
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+				mock.ExpectRollback()
+			},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name: "Empty new name",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RenameLabelRequest{
+					Api:  "v1",
+					Name: "backend",
+				},
+			},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Case of the name",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RenameLabelRequest{
+					Api:     "v1",
+					Name:    "backend",
+					NewName: "Backend",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT tl.`ToDoID` FROM ToDoLabel").WithArgs("", "backend").
+					WillReturnRows(sqlmock.NewRows([]string{"ToDoID"}))
+				mock.ExpectExec("UPDATE Label").WithArgs("Backend", "", "backend").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE ToDo t JOIN ToDoLabel").WithArgs("", "Backend").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT COUNT(.+) FROM ToDoLabel").WithArgs("", "Backend").
+					WillReturnRows(sqlmock.NewRows([]string{"Count"}).AddRow(0))
+				mock.ExpectCommit()
+			},
+			want: &v1.RenameLabelResponse{
+				Api:   "v1",
+				Label: &v1.Label{Name: "Backend", TodoCount: 0},
+			},
+		},
+		{
+			name: "Same name",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RenameLabelRequest{
+					Api:     "v1",
+					Name:    " backend",
+					NewName: "backend ",
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM Label l").WithArgs("", "backend").
+					WillReturnRows(sqlmock.NewRows([]string{"Count"}).AddRow(3))
+			},
+			want: &v1.RenameLabelResponse{
+				Api:   "v1",
+				Label: &v1.Label{Name: "backend", TodoCount: 3},
+			},
+		},
+		{
+			name: "Same name not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RenameLabelRequest{
+					Api:     "v1",
+					Name:    "backend",
+					NewName: "backend",
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT(.+) FROM Label l").WithArgs("", "backend").
+					WillReturnRows(sqlmock.NewRows([]string{"Count"}))
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.RenameLabel(tt.args.ctx, tt.args.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("toDoServiceServer.RenameLabel() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.RenameLabel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
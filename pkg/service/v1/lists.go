@@ -0,0 +1,426 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// maxListNameLength is the maximum length of the list name
+	maxListNameLength = 200
+
+	// maxListDescriptionLength is the maximum length of the list description
+	maxListDescriptionLength = 1024
+)
+
+// listColumnValue returns value of the ListID column for the list ID sent by
+// client, zero means the ToDo is not in any list
+func listColumnValue(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// normalizeListName trims the list name and checks its length
+func normalizeListName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return "", status.Error(codes.InvalidArgument, "name field must not be empty")
+	}
+	if utf8.RuneCountInString(name) > maxListNameLength {
+		return "", status.Errorf(codes.InvalidArgument, "name field is longer than %d characters", maxListNameLength)
+	}
+	return name, nil
+}
+
+// checkListDescription checks length of the list description
+func checkListDescription(description string) error {
+	if utf8.RuneCountInString(description) > maxListDescriptionLength {
+		return status.Errorf(codes.InvalidArgument, "description field is longer than %d characters", maxListDescriptionLength)
+	}
+	return nil
+}
+
+// checkList checks the list of the tenant exists before ToDo is added to it,
+// the list is locked in share mode so it is not deleted meanwhile
+func checkList(ctx context.Context, q querier, tenant string, id int64) error {
+	rows, err := q.QueryContext(ctx, "SELECT `ID` FROM ToDoList WHERE `ID`=? AND `Owner`=? LOCK IN SHARE MODE", id, tenant)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDoList-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve data from ToDoList-> "+err.Error())
+		}
+		return status.Errorf(codes.InvalidArgument, "list_id field refers to ToDoList with ID='%d' which is not found", id)
+	}
+	return nil
+}
+
+// readLists queries lists of the tenant with number of their todos, all
+// lists are returned if id is zero
+func readLists(ctx context.Context, q querier, tenant string, id int64) ([]*v1.TodoList, error) {
+	query := "SELECT l.`ID`, l.`Name`, l.`Description`, l.`CreatedAt`, l.`UpdatedAt`, COUNT(t.`ID`) FROM ToDoList l " +
+		"LEFT JOIN ToDo t ON t.`ListID`=l.`ID` AND t.`DeletedAt` IS NULL WHERE l.`Owner`=?"
+	args := []interface{}{tenant}
+	if id != 0 {
+		query += " AND l.`ID`=?"
+		args = append(args, id)
+	}
+	query += " GROUP BY l.`ID`, l.`Name`, l.`Description`, l.`CreatedAt`, l.`UpdatedAt` ORDER BY l.`Name`"
+
+	// todos in trash are not counted
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoList-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.TodoList{}
+	for rows.Next() {
+		l := new(v1.TodoList)
+		var createdAt, updatedAt time.Time
+		if err := rows.Scan(&l.Id, &l.Name, &l.Description, &createdAt, &updatedAt, &l.TodoCount); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDoList row-> "+err.Error())
+		}
+		if l.CreateTime, err = ptypes.TimestampProto(createdAt); err != nil {
+			return nil, status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+		}
+		if l.UpdateTime, err = ptypes.TimestampProto(updatedAt); err != nil {
+			return nil, status.Error(codes.Unknown, "updated_at field has invalid format-> "+err.Error())
+		}
+		list = append(list, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoList-> "+err.Error())
+	}
+	return list, nil
+}
+
+// readList queries list of the tenant by ID
+func readList(ctx context.Context, q querier, tenant string, id int64) (*v1.TodoList, error) {
+	list, err := readLists(ctx, q, tenant, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDoList with ID='%d' is not found",
+			id))
+	}
+	return list[0], nil
+}
+
+// CreateList creates todo list
+func (s *todoServiceServer) CreateList(ctx context.Context, req *v1.CreateListRequest) (*v1.CreateListResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if req.List == nil {
+		return nil, status.Error(codes.InvalidArgument, "list field is required")
+	}
+	name, err := normalizeListName(req.List.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkListDescription(req.List.Description); err != nil {
+		return nil, err
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	now := s.now().UTC()
+	res, err := c.ExecContext(ctx, "INSERT INTO ToDoList(`Owner`, `Name`, `Description`, `CreatedAt`, `UpdatedAt`) VALUES(?, ?, ?, ?, ?)",
+		tenant, name, req.List.Description, now, now)
+	if err != nil {
+		if me, ok := err.(*mysql.MySQLError); ok && me.Number == mysqlErrDuplicateEntry {
+			return nil, status.Errorf(codes.AlreadyExists, "ToDoList with name='%s' already exists", name)
+		}
+		return nil, status.Error(codes.Unknown, "failed to insert into ToDoList-> "+err.Error())
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve id for created ToDoList-> "+err.Error())
+	}
+
+	createTime, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+	}
+
+	return &v1.CreateListResponse{
+		Api: apiVersion,
+		List: &v1.TodoList{
+			Id:          id,
+			Name:        name,
+			Description: req.List.Description,
+			CreateTime:  createTime,
+			UpdateTime:  createTime,
+		},
+	}, nil
+}
+
+// ListLists returns all todo lists of the tenant
+func (s *todoServiceServer) ListLists(ctx context.Context, req *v1.ListListsRequest) (*v1.ListListsResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	list, err := readLists(ctx, c, tenant, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ListListsResponse{
+		Api:   apiVersion,
+		Lists: list,
+	}, nil
+}
+
+// ReadList returns todo list
+func (s *todoServiceServer) ReadList(ctx context.Context, req *v1.ReadListRequest) (*v1.ReadListResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	l, err := readList(ctx, c, tenant, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ReadListResponse{
+		Api:  apiVersion,
+		List: l,
+	}, nil
+}
+
+// UpdateList changes name or description of todo list
+func (s *todoServiceServer) UpdateList(ctx context.Context, req *v1.UpdateListRequest) (*v1.UpdateListResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if req.List == nil {
+		return nil, status.Error(codes.InvalidArgument, "list field is required")
+	}
+
+	paths := req.UpdateMask.GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"name", "description"}
+	}
+
+	var sets []string
+	var args []interface{}
+	for _, path := range paths {
+		switch path {
+		case "id":
+		case "name":
+			name, err := normalizeListName(req.List.Name)
+			if err != nil {
+				return nil, err
+			}
+			sets = append(sets, "`Name`=?")
+			args = append(args, name)
+		case "description":
+			if err := checkListDescription(req.List.Description); err != nil {
+				return nil, err
+			}
+			sets = append(sets, "`Description`=?")
+			args = append(args, req.List.Description)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "update_mask field is invalid-> unknown field path '%s'", path)
+		}
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	// update time changes on every update, so the list is always affected
+	sets = append(sets, "`UpdatedAt`=?")
+	args = append(args, s.now().UTC(), req.List.Id, tenant)
+	res, err := c.ExecContext(ctx, "UPDATE ToDoList SET "+strings.Join(sets, ", ")+" WHERE `ID`=? AND `Owner`=?", args...)
+	if err != nil {
+		if me, ok := err.(*mysql.MySQLError); ok && me.Number == mysqlErrDuplicateEntry {
+			return nil, status.Errorf(codes.AlreadyExists, "ToDoList with name='%s' already exists", strings.TrimSpace(req.List.Name))
+		}
+		return nil, status.Error(codes.Unknown, "failed to update ToDoList-> "+err.Error())
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+
+	if rows == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDoList with ID='%d' is not found",
+			req.List.Id))
+	}
+
+	l, err := readList(ctx, c, tenant, req.List.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.UpdateListResponse{
+		Api:  apiVersion,
+		List: l,
+	}, nil
+}
+
+// lockListTodos locks the list of the tenant and returns IDs of its todos
+// which are not in trash
+func lockListTodos(ctx context.Context, q querier, tenant string, id int64) ([]int64, error) {
+	rows, err := q.QueryContext(ctx, "SELECT `ID` FROM ToDoList WHERE `ID`=? AND `Owner`=? FOR UPDATE", id, tenant)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoList-> "+err.Error())
+	}
+	found := rows.Next()
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoList-> "+err.Error())
+	}
+	if !found {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDoList with ID='%d' is not found",
+			id))
+	}
+
+	rows, err = q.QueryContext(ctx, "SELECT `ID` FROM ToDo WHERE `ListID`=? AND `Owner`=? AND `DeletedAt` IS NULL ORDER BY `ID`", id, tenant)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var todoID int64
+		if err := rows.Scan(&todoID); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
+		}
+		ids = append(ids, todoID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+	return ids, nil
+}
+
+// DeleteList deletes todo list, its todos are moved to trash if cascade is
+// requested, otherwise list having todos is not deleted
+func (s *todoServiceServer) DeleteList(ctx context.Context, req *v1.DeleteListRequest) (*v1.DeleteListResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	tx, err := s.begin(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	ids, err := lockListTodos(ctx, tx, tenant, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 && !req.Cascade {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("ToDoList with ID='%d' has %d todos, move them to another list or delete with cascade",
+			req.Id, len(ids)))
+	}
+
+	for _, id := range ids {
+		if err := s.delete(ctx, tx, id, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	// todos in trash are detached by the foreign key
+	if _, err := tx.ExecContext(ctx, "DELETE FROM ToDoList WHERE `ID`=?", req.Id); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to delete ToDoList-> "+err.Error())
+	}
+
+	if err := s.commit(tx); err != nil {
+		return nil, err
+	}
+
+	return &v1.DeleteListResponse{
+		Api:          apiVersion,
+		Deleted:      1,
+		DeletedTodos: int64(len(ids)),
+	}, nil
+}
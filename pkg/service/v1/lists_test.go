@@ -0,0 +1,265 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// listRows returns result set with the list having given number of todos
+func listRows(id int64, name string, todos int64) *sqlmock.Rows {
+	tm := time.Now().In(time.UTC)
+	return sqlmock.NewRows([]string{"ID", "Name", "Description", "CreatedAt", "UpdatedAt", "TodoCount"}).
+		AddRow(id, name, "", tm, tm, todos)
+}
+
+func Test_toDoServiceServer_CreateList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		req      *v1.CreateListRequest
+		mock     func()
+		wantID   int64
+		wantCode codes.Code
+	}{
+		{
+			name: "OK",
+			req:  &v1.CreateListRequest{Api: "v1", List: &v1.TodoList{Name: " home ", Description: "chores"}},
+			mock: func() {
+				mock.ExpectExec("INSERT INTO ToDoList").
+					WithArgs("", "home", "chores", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			wantID: 3,
+		},
+		{
+			name:     "Empty name",
+			req:      &v1.CreateListRequest{Api: "v1", List: &v1.TodoList{Name: " "}},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Already exists",
+			req:  &v1.CreateListRequest{Api: "v1", List: &v1.TodoList{Name: "home"}},
+			mock: func() {
+				mock.ExpectExec("INSERT INTO ToDoList").
+					WillReturnError(&mysql.MySQLError{Number: mysqlErrDuplicateEntry})
+			},
+			wantCode: codes.AlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := s.CreateList(ctx, tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("toDoServiceServer.CreateList() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err == nil && (got.List.Id != tt.wantID || got.List.Name != "home" || got.List.CreateTime == nil) {
+				t.Errorf("toDoServiceServer.CreateList() = %v", got)
+			}
+		})
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_ReadList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	ctx := context.Background()
+
+	mock.ExpectQuery("SELECT (.+) FROM ToDoList l LEFT JOIN ToDo t (.+) WHERE l.`Owner`=\\? AND l.`ID`=\\?").
+		WithArgs("", 3).WillReturnRows(listRows(3, "home", 2))
+	got, err := s.ReadList(ctx, &v1.ReadListRequest{Api: "v1", Id: 3})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.ReadList() error = %v", err)
+	}
+	if got.List.Id != 3 || got.List.Name != "home" || got.List.TodoCount != 2 {
+		t.Errorf("toDoServiceServer.ReadList() = %v", got.List)
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM ToDoList").WithArgs("", 4).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Name", "Description", "CreatedAt", "UpdatedAt", "TodoCount"}))
+	if _, err := s.ReadList(ctx, &v1.ReadListRequest{Api: "v1", Id: 4}); status.Code(err) != codes.NotFound {
+		t.Errorf("toDoServiceServer.ReadList() error = %v, wantCode %v", err, codes.NotFound)
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM ToDoList l (.+) WHERE l.`Owner`=\\? GROUP BY").WithArgs("").
+		WillReturnRows(listRows(3, "home", 2).AddRow(5, "work", "", time.Now(), time.Now(), 0))
+	lists, err := s.ListLists(ctx, &v1.ListListsRequest{Api: "v1"})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.ListLists() error = %v", err)
+	}
+	if len(lists.Lists) != 2 || lists.Lists[1].Name != "work" {
+		t.Errorf("toDoServiceServer.ListLists() = %v", lists.Lists)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_UpdateList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	ctx := context.Background()
+
+	mock.ExpectExec("UPDATE ToDoList SET `Name`=\\?, `UpdatedAt`=\\? WHERE `ID`=\\? AND `Owner`=\\?").
+		WithArgs("house", sqlmock.AnyArg(), 3, "").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoList").WithArgs("", 3).WillReturnRows(listRows(3, "house", 0))
+	got, err := s.UpdateList(ctx, &v1.UpdateListRequest{
+		Api:        "v1",
+		List:       &v1.TodoList{Id: 3, Name: "house", Description: "ignored"},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+	})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.UpdateList() error = %v", err)
+	}
+	if got.List.Name != "house" {
+		t.Errorf("toDoServiceServer.UpdateList() = %v", got.List)
+	}
+
+	mock.ExpectExec("UPDATE ToDoList SET `Name`=\\?, `Description`=\\?, `UpdatedAt`=\\?").
+		WithArgs("house", "", sqlmock.AnyArg(), 4, "").WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err = s.UpdateList(ctx, &v1.UpdateListRequest{Api: "v1", List: &v1.TodoList{Id: 4, Name: "house"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("toDoServiceServer.UpdateList() error = %v, wantCode %v", err, codes.NotFound)
+	}
+
+	_, err = s.UpdateList(ctx, &v1.UpdateListRequest{
+		Api:        "v1",
+		List:       &v1.TodoList{Id: 3},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"todo_count"}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("toDoServiceServer.UpdateList() error = %v, wantCode %v", err, codes.InvalidArgument)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_DeleteList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	ctx := context.Background()
+
+	expectLockList := func(id int64, found bool, todos ...int64) {
+		rows := sqlmock.NewRows([]string{"ID"})
+		if found {
+			rows.AddRow(id)
+		}
+		mock.ExpectQuery("SELECT `ID` FROM ToDoList WHERE `ID`=\\? AND `Owner`=\\? FOR UPDATE").WithArgs(id, "").
+			WillReturnRows(rows)
+		if !found {
+			return
+		}
+		rows = sqlmock.NewRows([]string{"ID"})
+		for _, todo := range todos {
+			rows.AddRow(todo)
+		}
+		mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ListID`=\\? AND `Owner`=\\? AND `DeletedAt` IS NULL").WithArgs(id, "").
+			WillReturnRows(rows)
+	}
+
+	tests := []struct {
+		name      string
+		req       *v1.DeleteListRequest
+		mock      func()
+		wantTodos int64
+		wantCode  codes.Code
+	}{
+		{
+			name: "Empty",
+			req:  &v1.DeleteListRequest{Api: "v1", Id: 3},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLockList(3, true)
+				mock.ExpectExec("DELETE FROM ToDoList").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Not empty",
+			req:  &v1.DeleteListRequest{Api: "v1", Id: 3},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLockList(3, true, 1)
+				mock.ExpectRollback()
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "Cascade",
+			req:  &v1.DeleteListRequest{Api: "v1", Id: 3, Cascade: true},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLockList(3, true, 1)
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordChange(mock, 1, 2)
+				mock.ExpectExec("DELETE FROM ToDoList").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantTodos: 1,
+		},
+		{
+			name: "Not found",
+			req:  &v1.DeleteListRequest{Api: "v1", Id: 4},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLockList(4, false)
+				mock.ExpectRollback()
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := s.DeleteList(ctx, tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("toDoServiceServer.DeleteList() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err == nil && (got.Deleted != 1 || got.DeletedTodos != tt.wantTodos) {
+				t.Errorf("toDoServiceServer.DeleteList() = %v", got)
+			}
+		})
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// orderFields is whitelist of fields ReadAll results can be sorted by
+var orderFields = map[string]filterField{
+	"id":          {column: "`ID`", kind: filterInt},
+	"title":       {column: "`Title`", kind: filterString},
+	"description": {column: "`Description`", kind: filterString},
+	"reminder":    {column: "`Reminder`", kind: filterTimestamp},
+	"status":      {column: "`Status`", kind: filterEnum, enum: v1.Todo_Status_value},
+}
+
+// orderTerm is single field of the order_by clause
+type orderTerm struct {
+	name  string
+	field filterField
+	desc  bool
+}
+
+// parseOrderBy parses AIP-132 style order_by clause, e.g. "reminder desc, id".
+// ID is always appended as the last term, so that the order is total and
+// can be used as pagination cursor.
+func parseOrderBy(orderBy string) ([]orderTerm, error) {
+	var terms []orderTerm
+	seen := map[string]bool{}
+
+	if len(strings.TrimSpace(orderBy)) > 0 {
+		for _, part := range strings.Split(orderBy, ",") {
+			words := strings.Fields(part)
+			if len(words) == 0 || len(words) > 2 {
+				return nil, fmt.Errorf("invalid order term '%s'", strings.TrimSpace(part))
+			}
+
+			field, ok := orderFields[words[0]]
+			if !ok {
+				return nil, fmt.Errorf("unknown order field '%s'", words[0])
+			}
+			if seen[words[0]] {
+				return nil, fmt.Errorf("duplicated order field '%s'", words[0])
+			}
+			seen[words[0]] = true
+
+			t := orderTerm{name: words[0], field: field}
+			if len(words) == 2 {
+				switch strings.ToLower(words[1]) {
+				case "asc":
+				case "desc":
+					t.desc = true
+				default:
+					return nil, fmt.Errorf("invalid order direction '%s'", words[1])
+				}
+			}
+			terms = append(terms, t)
+		}
+	}
+
+	if !seen["id"] {
+		terms = append(terms, orderTerm{name: "id", field: orderFields["id"]})
+	}
+	return terms, nil
+}
+
+// orderString returns normalized form of the order terms
+func orderString(terms []orderTerm) string {
+	parts := make([]string, len(terms))
+	for i, t := range terms {
+		parts[i] = t.name
+		if t.desc {
+			parts[i] += " desc"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// orderSQL returns ORDER BY list for the order terms
+func orderSQL(terms []orderTerm) string {
+	parts := make([]string, len(terms))
+	for i, t := range terms {
+		parts[i] = t.field.column
+		if t.desc {
+			parts[i] += " DESC"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// keysetSQL returns condition selecting rows which follow the row with the
+// given key values in the order of the terms
+func keysetSQL(terms []orderTerm, keys []string) (string, []interface{}, error) {
+	if len(keys) != len(terms) {
+		return "", nil, errPageTokenMismatch
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, t := range terms {
+		v, err := filterValue(t.field, keys[i])
+		if err != nil {
+			return "", nil, errInvalidPageToken
+		}
+		values[i] = v
+	}
+
+	var args []interface{}
+	ors := make([]string, len(terms))
+	for i, t := range terms {
+		var ands []string
+		for j := 0; j < i; j++ {
+			ands = append(ands, terms[j].field.column+" = ?")
+			args = append(args, values[j])
+		}
+		op := " > ?"
+		if t.desc {
+			op = " < ?"
+		}
+		ands = append(ands, t.field.column+op)
+		args = append(args, values[i])
+		ors[i] = "(" + strings.Join(ands, " AND ") + ")"
+	}
+	return "(" + strings.Join(ors, " OR ") + ")", args, nil
+}
+
+// orderKeys returns values of the order terms for the ToDo
+func orderKeys(terms []orderTerm, td *v1.Todo) []string {
+	keys := make([]string, len(terms))
+	for i, t := range terms {
+		switch t.name {
+		case "id":
+			keys[i] = strconv.FormatInt(td.Id, 10)
+		case "title":
+			keys[i] = td.Title
+		case "description":
+			keys[i] = td.Description
+		case "reminder":
+			reminder, _ := ptypes.Timestamp(td.Reminder)
+			keys[i] = reminder.Format(time.RFC3339Nano)
+		case "status":
+			keys[i] = td.Status.String()
+		}
+	}
+	return keys
+}
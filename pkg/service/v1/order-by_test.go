@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_parseOrderBy(t *testing.T) {
+	tests := []struct {
+		name      string
+		orderBy   string
+		wantOrder string
+		wantSQL   string
+		wantErr   bool
+	}{
+		{"Default", "", "id", "`ID`", false},
+		{"Descending", "reminder desc", "reminder desc, id", "`Reminder` DESC, `ID`", false},
+		{"Multiple", " title ASC,reminder DESC , id desc", "title, reminder desc, id desc", "`Title`, `Reminder` DESC, `ID` DESC", false},
+		{"Unknown field", "owner", "", "", true},
+		{"Invalid direction", "title up", "", "", true},
+		{"Duplicated field", "title, title desc", "", "", true},
+		{"Empty term", "title,", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOrderBy(tt.orderBy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOrderBy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if s := orderString(got); s != tt.wantOrder {
+				t.Errorf("orderString() = %v, want %v", s, tt.wantOrder)
+			}
+			if s := orderSQL(got); s != tt.wantSQL {
+				t.Errorf("orderSQL() = %v, want %v", s, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func Test_keysetSQL(t *testing.T) {
+	tm, _ := time.Parse(time.RFC3339, "2026-11-01T00:00:00Z")
+	order, _ := parseOrderBy("reminder desc, title")
+
+	gotSQL, gotArgs, err := keysetSQL(order, []string{"2026-11-01T00:00:00Z", "abc", "7"})
+	if err != nil {
+		t.Fatalf("keysetSQL() error = %v", err)
+	}
+	wantSQL := "((`Reminder` < ?) OR (`Reminder` = ? AND `Title` > ?) OR (`Reminder` = ? AND `Title` = ? AND `ID` > ?))"
+	if gotSQL != wantSQL {
+		t.Errorf("keysetSQL() sql = %v, want %v", gotSQL, wantSQL)
+	}
+	wantArgs := []interface{}{tm, tm, "abc", tm, "abc", int64(7)}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Errorf("keysetSQL() args = %v, want %v", gotArgs, wantArgs)
+	}
+
+	if _, _, err := keysetSQL(order, []string{"7"}); err != errPageTokenMismatch {
+		t.Errorf("keysetSQL() error = %v, want %v", err, errPageTokenMismatch)
+	}
+	if _, _, err := keysetSQL(order, []string{"yesterday", "abc", "7"}); err != errInvalidPageToken {
+		t.Errorf("keysetSQL() error = %v, want %v", err, errInvalidPageToken)
+	}
+}
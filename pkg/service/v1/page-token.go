@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const (
+	// defaultPageSize is used when client does not provide page size
+	defaultPageSize = 50
+
+	// maxPageSize is the upper limit of the page size accepted from clients
+	maxPageSize = 1000
+)
+
+var (
+	// errInvalidPageToken is returned when page token is malformed or tampered
+	errInvalidPageToken = errors.New("invalid page token")
+
+	// errPageTokenMismatch is returned when page token was issued for another query
+	errPageTokenMismatch = errors.New("page token does not match request parameters")
+)
+
+// pageToken is the cursor carried between ReadAll calls
+type pageToken struct {
+	// Keys are values of the order fields of the last ToDo in the previous page
+	Keys []string `json:"k"`
+
+	// Query is fingerprint of request parameters the token was issued for
+	Query string `json:"q,omitempty"`
+}
+
+// queryFingerprint returns short hash of the request parameters which must
+// not change between the pages
+func queryFingerprint(params ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(params, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// newPageTokenKey generates random key to sign page tokens
+func newPageTokenKey() []byte {
+	key := make([]byte, sha256.Size)
+	_, _ = rand.Read(key)
+	return key
+}
+
+// encodePageToken serializes the cursor and signs it with the given key
+func encodePageToken(key []byte, t pageToken) (string, error) {
+	payload, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(payload)
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(payload)), nil
+}
+
+// decodePageToken verifies signature of the token and returns the cursor
+func decodePageToken(key []byte, token string) (pageToken, error) {
+	var t pageToken
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) < sha256.Size {
+		return t, errInvalidPageToken
+	}
+
+	payload, sum := raw[:len(raw)-sha256.Size], raw[len(raw)-sha256.Size:]
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(payload)
+	if !hmac.Equal(sum, mac.Sum(nil)) {
+		return t, errInvalidPageToken
+	}
+
+	if err := json.Unmarshal(payload, &t); err != nil {
+		return t, errInvalidPageToken
+	}
+
+	return t, nil
+}
+
+// cursorSQL verifies page token and returns condition selecting the rows of
+// the next page
+func cursorSQL(key []byte, token, query string, order []orderTerm) (string, []interface{}, error) {
+	cursor, err := decodePageToken(key, token)
+	if err != nil {
+		return "", nil, err
+	}
+	if cursor.Query != query {
+		return "", nil, errPageTokenMismatch
+	}
+	return keysetSQL(order, cursor.Keys)
+}
+
+// pageSize validates requested page size and applies the defaults
+func pageSize(size int32) (int, error) {
+	switch {
+	case size < 0:
+		return 0, errors.New("page size must not be negative")
+	case size == 0:
+		return defaultPageSize, nil
+	case size > maxPageSize:
+		return maxPageSize, nil
+	}
+	return int(size), nil
+}
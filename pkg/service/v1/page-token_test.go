@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"testing"
+)
+
+func Test_pageToken(t *testing.T) {
+	key := []byte("secret")
+
+	token, err := encodePageToken(key, pageToken{Keys: []string{"42"}})
+	if err != nil {
+		t.Fatalf("encodePageToken() error = %v", err)
+	}
+
+	got, err := decodePageToken(key, token)
+	if err != nil {
+		t.Fatalf("decodePageToken() error = %v", err)
+	}
+	if len(got.Keys) != 1 || got.Keys[0] != "42" {
+		t.Errorf("decodePageToken() Keys = %v, want %v", got.Keys, []string{"42"})
+	}
+
+	tests := []struct {
+		name  string
+		key   []byte
+		token string
+	}{
+		{"Wrong key", []byte("other"), token},
+		{"Truncated", key, token[:len(token)-2]},
+		{"Not base64", key, "!!!"},
+		{"Empty", key, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodePageToken(tt.key, tt.token); err != errInvalidPageToken {
+				t.Errorf("decodePageToken() error = %v, want %v", err, errInvalidPageToken)
+			}
+		})
+	}
+}
+
+func Test_pageSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int32
+		want    int
+		wantErr bool
+	}{
+		{"Default", 0, defaultPageSize, false},
+		{"Requested", 10, 10, false},
+		{"Capped", maxPageSize + 1, maxPageSize, false},
+		{"Negative", -1, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pageSize(tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("pageSize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("pageSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
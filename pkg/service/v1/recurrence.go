@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/rrule"
+)
+
+const (
+	// maxRecurrenceLength is the maximum length of the stored recurrence rule
+	maxRecurrenceLength = 255
+
+	// maxOccurrences is the maximum number of occurrences ListOccurrences
+	// returns
+	maxOccurrences = 1000
+
+	// maxOccurrenceYears is how far after the reminder ListOccurrences may
+	// start, rules with COUNT are expanded from the reminder
+	maxOccurrenceYears = 100
+)
+
+// parseRecurrence validates recurrence rule sent by client and returns it in
+// canonical form, empty rule means the ToDo does not repeat
+func parseRecurrence(s string) (string, error) {
+	if len(s) == 0 {
+		return "", nil
+	}
+
+	r, err := rrule.Parse(s)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "recurrence field is invalid-> "+err.Error())
+	}
+
+	rule := r.String()
+	if len(rule) > maxRecurrenceLength {
+		return "", status.Errorf(codes.InvalidArgument, "recurrence field is longer than %d characters", maxRecurrenceLength)
+	}
+	return rule, nil
+}
+
+// nextOccurrence returns reminder of the next occurrence of the recurring
+// ToDo and the recurrence rule of the occurrences remaining after it. It
+// returns false if the ToDo does not repeat or the current reminder is its
+// last occurrence.
+func nextOccurrence(td *v1.Todo) (time.Time, string, bool, error) {
+	if len(td.Recurrence) == 0 {
+		return time.Time{}, "", false, nil
+	}
+
+	r, err := rrule.Parse(td.Recurrence)
+	if err != nil {
+		return time.Time{}, "", false, status.Error(codes.Unknown, "recurrence field has invalid format-> "+err.Error())
+	}
+	reminder, err := todoReminder(td)
+	if err != nil {
+		return time.Time{}, "", false, err
+	}
+
+	next, rest, ok := r.Advance(reminder)
+	if !ok {
+		return time.Time{}, "", false, nil
+	}
+	return next.UTC(), rest.String(), true, nil
+}
+
+// completeStatement returns statement completing the ToDo. Recurring ToDo
+// is moved to its next occurrence and stays open, it is marked as done only
+// after its last occurrence.
+func (s *todoServiceServer) completeStatement(id int64) todoStatement {
+	return func(before *v1.Todo) (string, []interface{}, error) {
+		if before.DeletedAt == nil {
+			next, rule, ok, err := nextOccurrence(before)
+			if err != nil {
+				return "", nil, err
+			}
+			if ok {
+				return "UPDATE ToDo SET `Reminder`=?, `Recurrence`=?, `Status`=?, `CompletedAt`=NULL, `Version`=`Version`+1 WHERE `ID`=? AND `DeletedAt` IS NULL",
+					[]interface{}{next, rule, v1.Todo_OPEN, id}, nil
+			}
+		}
+
+		// completion time of already done ToDo is kept
+		return "UPDATE ToDo SET `Status`=?, `CompletedAt`=COALESCE(`CompletedAt`, ?), `Version`=`Version`+1 WHERE `ID`=? AND `DeletedAt` IS NULL",
+			[]interface{}{v1.Todo_DONE, s.completedAt(v1.Todo_DONE), id}, nil
+	}
+}
+
+// ListOccurrences returns reminders of todo task and its upcoming recurrences
+func (s *todoServiceServer) ListOccurrences(ctx context.Context, req *v1.ListOccurrencesRequest) (*v1.ListOccurrencesResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	from, err := ptypes.Timestamp(req.From)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "from field has invalid format-> "+err.Error())
+	}
+	to, err := ptypes.Timestamp(req.To)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "to field has invalid format-> "+err.Error())
+	}
+	if !to.After(from) {
+		return nil, status.Error(codes.InvalidArgument, "to field must be after from field")
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	td, err := s.read(ctx, c, req.Id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// occurrences keep the local time of the reminder in the time zone of
+	// the todo
+	reminder, err := todoReminder(td)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.After(reminder.AddDate(maxOccurrenceYears, 0, 0)) {
+		return nil, status.Errorf(codes.InvalidArgument, "from field must be within %d years after the reminder", maxOccurrenceYears)
+	}
+
+	var times []time.Time
+	if len(td.Recurrence) == 0 {
+		if !reminder.Before(from) && reminder.Before(to) {
+			times = append(times, reminder)
+		}
+	} else {
+		r, err := rrule.Parse(td.Recurrence)
+		if err != nil {
+			return nil, status.Error(codes.Unknown, "recurrence field has invalid format-> "+err.Error())
+		}
+		times = r.Between(reminder, from, to, maxOccurrences)
+	}
+
+	list := make([]*timestamp.Timestamp, 0, len(times))
+	local := make([]string, 0, len(times))
+	for _, t := range times {
+		ts, err := ptypes.TimestampProto(t)
+		if err != nil {
+			return nil, status.Error(codes.Unknown, "occurrence has invalid format-> "+err.Error())
+		}
+		list = append(list, ts)
+		local = append(local, t.Format(time.RFC3339))
+	}
+
+	return &v1.ListOccurrencesResponse{
+		Api:              apiVersion,
+		Occurrences:      list,
+		LocalOccurrences: local,
+	}, nil
+}
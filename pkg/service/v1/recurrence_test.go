@@ -0,0 +1,281 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// recurringRows returns result set with the ToDo repeating by the rule
+func recurringRows(id, version int64, reminder time.Time, rule string) *sqlmock.Rows {
+	return zonedRows(id, version, reminder, rule, "")
+}
+
+// zonedRows returns result set with the ToDo repeating by the rule in the
+// time zone
+func zonedRows(id, version int64, reminder time.Time, rule, zone string) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+		AddRow(id, "title", "description", reminder, v1.Todo_OPEN, nil, nil, version, nil, rule, nil, zone)
+}
+
+func Test_parseRecurrence(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		want     string
+		wantCode codes.Code
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name: "Canonical form",
+			rule: "RRULE:byday=mo;freq=weekly",
+			want: "FREQ=WEEKLY;BYDAY=MO",
+		},
+		{
+			name:     "Invalid",
+			rule:     "FREQ=SOMETIMES",
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRecurrence(tt.rule)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("parseRecurrence() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if got != tt.want {
+				t.Errorf("parseRecurrence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_Complete_recurring(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	next := tm.AddDate(0, 0, 7)
+
+	// 8am in Berlin is 6am UTC before the end of DST on 25 October and 7am
+	// UTC after it
+	berlin := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	berlinNext := time.Date(2026, 10, 26, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		mock     func()
+		wantCode codes.Code
+	}{
+		{
+			name: "Next occurrence",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\? FOR UPDATE").WithArgs(1, "").
+					WillReturnRows(recurringRows(1, 1, tm, "FREQ=WEEKLY;COUNT=3"))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+				mock.ExpectExec("UPDATE ToDo SET `Reminder`=\\?, `Recurrence`=\\?, `Status`=\\?, `CompletedAt`=NULL").
+					WithArgs(next, "FREQ=WEEKLY;COUNT=2", v1.Todo_OPEN, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\?$").WithArgs(1, "").
+					WillReturnRows(recurringRows(1, 2, next, "FREQ=WEEKLY;COUNT=2"))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+				expectRevision(mock, 1, 2)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Next occurrence across DST",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\? FOR UPDATE").WithArgs(1, "").
+					WillReturnRows(zonedRows(1, 1, berlin, "FREQ=WEEKLY", "Europe/Berlin"))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+				mock.ExpectExec("UPDATE ToDo SET `Reminder`=\\?, `Recurrence`=\\?, `Status`=\\?, `CompletedAt`=NULL").
+					WithArgs(berlinNext, "FREQ=WEEKLY", v1.Todo_OPEN, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\?$").WithArgs(1, "").
+					WillReturnRows(zonedRows(1, 2, berlinNext, "FREQ=WEEKLY", "Europe/Berlin"))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+				expectRevision(mock, 1, 2)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Last occurrence",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\? FOR UPDATE").WithArgs(1, "").
+					WillReturnRows(recurringRows(1, 1, tm, "FREQ=WEEKLY;COUNT=1"))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+				mock.ExpectExec("UPDATE ToDo SET `Status`=\\?, `CompletedAt`=COALESCE").
+					WithArgs(v1.Todo_DONE, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordChange(mock, 1, 2)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Invalid stored rule",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\? FOR UPDATE").WithArgs(1, "").
+					WillReturnRows(recurringRows(1, 1, tm, "FREQ=SOMETIMES"))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+				mock.ExpectRollback()
+			},
+			wantCode: codes.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			_, err := s.Complete(ctx, &v1.CompleteRequest{Api: "v1", Id: 1})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("toDoServiceServer.Complete() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_ListOccurrences(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	ts := func(t time.Time) *timestamp.Timestamp {
+		p, _ := ptypes.TimestampProto(t)
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		req      *v1.ListOccurrencesRequest
+		mock     func()
+		want     []*timestamp.Timestamp
+		wantCode codes.Code
+
+		// wantLocal are local times of the occurrences, UTC times of want
+		// if not set
+		wantLocal []string
+	}{
+		{
+			name: "Recurring",
+			req:  &v1.ListOccurrencesRequest{Api: "v1", Id: 1, From: ts(tm.AddDate(0, 0, 1)), To: ts(tm.AddDate(0, 0, 22))},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\? AND `DeletedAt` IS NULL").WithArgs(1, "").
+					WillReturnRows(recurringRows(1, 1, tm, "FREQ=WEEKLY;BYDAY=MO,FR"))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+			},
+			want: []*timestamp.Timestamp{
+				ts(tm.AddDate(0, 0, 4)), ts(tm.AddDate(0, 0, 7)), ts(tm.AddDate(0, 0, 11)),
+				ts(tm.AddDate(0, 0, 14)), ts(tm.AddDate(0, 0, 18)), ts(tm.AddDate(0, 0, 21)),
+			},
+		},
+		{
+			name: "Recurring in time zone",
+			req:  &v1.ListOccurrencesRequest{Api: "v1", Id: 1, From: ts(tm), To: ts(tm.AddDate(0, 0, 14))},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1, "").
+					WillReturnRows(zonedRows(1, 1, time.Date(2026, 10, 18, 3, 30, 0, 0, time.UTC), "FREQ=WEEKLY", "Europe/Berlin"))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+			},
+			want: []*timestamp.Timestamp{
+				ts(time.Date(2026, 10, 25, 4, 30, 0, 0, time.UTC)), ts(time.Date(2026, 11, 1, 4, 30, 0, 0, time.UTC)),
+			},
+			wantLocal: []string{"2026-10-25T05:30:00+01:00", "2026-11-01T05:30:00+01:00"},
+		},
+		{
+			name: "Not recurring",
+			req:  &v1.ListOccurrencesRequest{Api: "v1", Id: 1, From: ts(tm), To: ts(tm.Add(time.Hour))},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1, "").WillReturnRows(recurringRows(1, 1, tm, ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+			},
+			want: []*timestamp.Timestamp{ts(tm)},
+		},
+		{
+			name: "Not recurring out of range",
+			req:  &v1.ListOccurrencesRequest{Api: "v1", Id: 1, From: ts(tm.Add(time.Second)), To: ts(tm.Add(time.Hour))},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1, "").WillReturnRows(recurringRows(1, 1, tm, ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+			},
+			want: []*timestamp.Timestamp{},
+		},
+		{
+			name: "Range too far",
+			req:  &v1.ListOccurrencesRequest{Api: "v1", Id: 1, From: ts(tm.AddDate(101, 0, 0)), To: ts(tm.AddDate(102, 0, 0))},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1, "").WillReturnRows(recurringRows(1, 1, tm, "FREQ=DAILY;COUNT=1000000000"))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Missing range",
+			req:      &v1.ListOccurrencesRequest{Api: "v1", Id: 1, From: ts(tm)},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Empty range",
+			req:      &v1.ListOccurrencesRequest{Api: "v1", Id: 1, From: ts(tm), To: ts(tm)},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Not found",
+			req:  &v1.ListOccurrencesRequest{Api: "v1", Id: 1, From: ts(tm), To: ts(tm.Add(time.Hour))},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1, "").WillReturnRows(todoRows(1, 0))
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := s.ListOccurrences(ctx, tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("toDoServiceServer.ListOccurrences() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err == nil {
+				want := &v1.ListOccurrencesResponse{Api: "v1", Occurrences: tt.want, LocalOccurrences: tt.wantLocal}
+				if tt.wantLocal == nil {
+					for _, o := range tt.want {
+						occurrence, _ := ptypes.Timestamp(o)
+						want.LocalOccurrences = append(want.LocalOccurrences, occurrence.Format(time.RFC3339))
+					}
+				}
+				if !proto.Equal(got, want) {
+					t.Errorf("toDoServiceServer.ListOccurrences() = %v, want %v", got, want)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
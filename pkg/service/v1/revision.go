@@ -0,0 +1,312 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// maxActorLength is the maximum length of the stored actor
+const maxActorLength = 128
+
+// actorContextKey is the context key of the authenticated actor
+type actorContextKey struct{}
+
+// WithActor returns context of the request made by the actor. Authentication
+// middleware sets it, the service never reads actor from client metadata.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns actor the request is authenticated as, false if
+// the request is not authenticated as actor
+func ActorFromContext(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(actorContextKey{}).(string)
+	return actor, ok
+}
+
+// requestActor returns who makes the change, empty if the request is not
+// authenticated as actor
+func requestActor(ctx context.Context) string {
+	name, _ := ActorFromContext(ctx)
+	actor := []rune(name)
+	if len(actor) > maxActorLength {
+		actor = actor[:maxActorLength]
+	}
+	return string(actor)
+}
+
+// lockTodo reads ToDo with its labels and locks it until the end of the
+// transaction, ToDo moved to trash is included. It returns nil if the ToDo
+// does not exist or is owned by another tenant. Every change locks the ToDo
+// first, so statements changing it by ID are scoped to the tenant as well.
+func lockTodo(ctx context.Context, q querier, id int64) (*v1.Todo, error) {
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT "+todoColumns+" FROM ToDo WHERE `ID`=? AND `Owner`=? FOR UPDATE", id, tenant)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+		}
+		return nil, nil
+	}
+
+	td, err := scanTodo(rows)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := loadLabels(ctx, q, []*v1.Todo{td}); err != nil {
+		return nil, err
+	}
+	return td, nil
+}
+
+// recordChange records change of the ToDo made in the transaction: revision
+// with before and after snapshots for the history and event for Watch.
+// before is the ToDo read by lockTodo prior to the change, nil for created
+// ToDo. It returns the changed ToDo.
+func (s *todoServiceServer) recordChange(ctx context.Context, q querier, id int64, t v1.TodoEvent_Type, before *v1.Todo) (*v1.Todo, error) {
+	after, err := s.read(ctx, q, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// every change increments version, so it identifies the revision
+	revision, _, err := parseETag(after.Etag)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to parse version of ToDo-> "+err.Error())
+	}
+
+	var beforeData []byte
+	if before != nil {
+		if beforeData, err = proto.Marshal(before); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to encode ToDo snapshot-> "+err.Error())
+		}
+	}
+	afterData, err := proto.Marshal(after)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to encode ToDo snapshot-> "+err.Error())
+	}
+
+	if _, err := q.ExecContext(ctx, "INSERT INTO ToDoRevision(`ToDoID`, `Revision`, `Type`, `Before`, `After`, `Actor`, `CreatedAt`) VALUES(?, ?, ?, ?, ?, ?, ?)",
+		id, revision, t, beforeData, afterData, requestActor(ctx), s.now().UTC()); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to insert into ToDoRevision-> "+err.Error())
+	}
+
+	if err := s.recordEvent(ctx, q, id, t); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// GetHistory returns revisions of todo task
+func (s *todoServiceServer) GetHistory(ctx context.Context, req *v1.GetHistoryRequest) (*v1.GetHistoryResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// revisions of todos owned by another tenant are not returned
+	rows, err := c.QueryContext(ctx, "SELECT "+revisionColumns+" FROM ToDoRevision WHERE `ToDoID` IN (SELECT `ID` FROM ToDo WHERE `ID`=? AND `Owner`=?) ORDER BY `Revision` DESC",
+		req.Id, tenant)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoRevision-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.TodoRevision{}
+	for rows.Next() {
+		r, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoRevision-> "+err.Error())
+	}
+
+	return &v1.GetHistoryResponse{
+		Api:       apiVersion,
+		Revisions: list,
+	}, nil
+}
+
+// revisionColumns is list of ToDoRevision columns in the order scanRevision
+// reads them
+const revisionColumns = "`Revision`, `Type`, `Before`, `After`, `Actor`, `CreatedAt`"
+
+// scanRevision reads revision from the current row of the result set
+func scanRevision(rows *sql.Rows) (*v1.TodoRevision, error) {
+	var r v1.TodoRevision
+	var before, after []byte
+	var createdAt time.Time
+	if err := rows.Scan(&r.Revision, &r.Type, &before, &after, &r.Actor, &createdAt); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDoRevision row-> "+err.Error())
+	}
+
+	if before != nil {
+		r.Before = new(v1.Todo)
+		if err := proto.Unmarshal(before, r.Before); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to decode ToDo snapshot-> "+err.Error())
+		}
+	}
+	r.After = new(v1.Todo)
+	if err := proto.Unmarshal(after, r.After); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to decode ToDo snapshot-> "+err.Error())
+	}
+
+	var err error
+	r.ChangeTime, err = ptypes.TimestampProto(createdAt)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+	}
+	return &r, nil
+}
+
+// revisionSnapshot returns ToDo as it was after the revision
+func (s *todoServiceServer) revisionSnapshot(ctx context.Context, q querier, id, revision int64) (*v1.Todo, error) {
+	rows, err := q.QueryContext(ctx, "SELECT "+revisionColumns+" FROM ToDoRevision WHERE `ToDoID`=? AND `Revision`=?",
+		id, revision)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoRevision-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoRevision-> "+err.Error())
+		}
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("revision %d of ToDo with ID='%d' is not found",
+			revision, id))
+	}
+
+	r, err := scanRevision(rows)
+	if err != nil {
+		return nil, err
+	}
+	return r.After, nil
+}
+
+// RestoreRevision changes todo task back to the state after the revision
+func (s *todoServiceServer) RestoreRevision(ctx context.Context, req *v1.RestoreRevisionRequest) (*v1.RestoreRevisionResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	version, conditional, err := checkETag(requestETag(ctx, req.Etag))
+	if err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	tx, err := s.begin(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	before, err := lockTodo(ctx, tx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil || before.DeletedAt != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
+			req.Id))
+	}
+	if conditional && before.Etag != formatETag(version) {
+		return nil, status.Error(codes.Aborted, fmt.Sprintf("ToDo with ID='%d' was modified concurrently, current etag is %s",
+			req.Id, before.Etag))
+	}
+
+	td, err := s.revisionSnapshot(ctx, tx, req.Id, req.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder, err := ptypes.Timestamp(td.Reminder)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "reminder field has invalid format-> "+err.Error())
+	}
+	var completedAt interface{}
+	if td.CompletedAt != nil {
+		if completedAt, err = ptypes.Timestamp(td.CompletedAt); err != nil {
+			return nil, status.Error(codes.Unknown, "completed_at field has invalid format-> "+err.Error())
+		}
+	}
+
+	// ToDo is moved back to its list unless the list was deleted since
+	if td.ListId != 0 {
+		tenant, err := requestTenant(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkList(ctx, tx, tenant, td.ListId); status.Code(err) == codes.InvalidArgument {
+			td.ListId = 0
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE ToDo SET `Title`=?, `Description`=?, `Reminder`=?, `Status`=?, `CompletedAt`=?, `Recurrence`=?, `TimeZone`=?, `ListID`=?, `Version`=`Version`+1 WHERE `ID`=?",
+		td.Title, td.Description, reminder, td.Status, completedAt, td.Recurrence, td.TimeZone, listColumnValue(td.ListId), req.Id); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+	}
+
+	if err := setLabels(ctx, tx, req.Id, td.Labels); err != nil {
+		return nil, err
+	}
+
+	restored, err := s.recordChange(ctx, tx, req.Id, v1.TodoEvent_UPDATED, before)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commit(tx); err != nil {
+		return nil, err
+	}
+	setETagHeader(ctx, restored.Etag)
+
+	return &v1.RestoreRevisionResponse{
+		Api:  apiVersion,
+		Todo: restored,
+	}, nil
+}
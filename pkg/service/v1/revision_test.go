@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// todoRows returns result set with the ToDo of the given version, empty
+// result set for zero version
+func todoRows(id, version int64) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"})
+	if version > 0 {
+		rows.AddRow(id, "title", "description", time.Now().In(time.UTC), 0, nil, nil, version, nil, "", nil, "")
+	}
+	return rows
+}
+
+// expectLock expects the ToDo to be locked before the change, zero version
+// means the ToDo does not exist
+func expectLock(mock sqlmock.Sqlmock, id, version int64) {
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\? FOR UPDATE").WithArgs(id, "").WillReturnRows(todoRows(id, version))
+	if version > 0 {
+		mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+	}
+}
+
+// expectRecordChange expects the changed ToDo to be read and its revision
+// and event to be recorded
+func expectRecordChange(mock sqlmock.Sqlmock, id, version int64) {
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\?$").WithArgs(id, "").WillReturnRows(todoRows(id, version))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+	expectRevision(mock, id, version)
+}
+
+// expectRevision expects revision and event of the changed ToDo to be
+// recorded after it is read
+func expectRevision(mock sqlmock.Sqlmock, id, version int64) {
+	mock.ExpectExec("INSERT INTO ToDoRevision").
+		WithArgs(id, version, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO ToDoEvent").WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func Test_toDoServiceServer_recordChange(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db).(*todoServiceServer)
+	ctx := WithActor(context.Background(), "alice")
+
+	before := &v1.Todo{Id: 1, Title: "old title", Etag: `"1"`}
+	beforeData, _ := proto.Marshal(before)
+
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\?$").WithArgs(1, "").WillReturnRows(todoRows(1, 2))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+	mock.ExpectExec("INSERT INTO ToDoRevision").
+		WithArgs(1, 2, v1.TodoEvent_UPDATED, beforeData, sqlmock.AnyArg(), "alice", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO ToDoEvent").WillReturnResult(sqlmock.NewResult(1, 1))
+
+	after, err := s.recordChange(ctx, db, 1, v1.TodoEvent_UPDATED, before)
+	if err != nil {
+		t.Fatalf("toDoServiceServer.recordChange() error = %v", err)
+	}
+	if after.Etag != `"2"` {
+		t.Errorf("toDoServiceServer.recordChange() etag = %v, want %v", after.Etag, `"2"`)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_GetHistory(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	changeTime, _ := ptypes.TimestampProto(tm)
+
+	created := &v1.Todo{Id: 1, Title: "title", Etag: `"1"`}
+	updated := &v1.Todo{Id: 1, Title: "new title", Etag: `"2"`}
+	createdData, _ := proto.Marshal(created)
+	updatedData, _ := proto.Marshal(updated)
+
+	mock.ExpectQuery("SELECT (.+) FROM ToDoRevision WHERE `ToDoID` IN \\(SELECT `ID` FROM ToDo WHERE `ID`=\\? AND `Owner`=\\?\\) ORDER BY `Revision` DESC").
+		WithArgs(1, "").
+		WillReturnRows(sqlmock.NewRows([]string{"Revision", "Type", "Before", "After", "Actor", "CreatedAt"}).
+			AddRow(2, v1.TodoEvent_UPDATED, createdData, updatedData, "alice", tm).
+			AddRow(1, v1.TodoEvent_CREATED, nil, createdData, "", tm))
+
+	got, err := s.GetHistory(ctx, &v1.GetHistoryRequest{Api: "v1", Id: 1})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.GetHistory() error = %v", err)
+	}
+	want := []*v1.TodoRevision{
+		{Revision: 2, Type: v1.TodoEvent_UPDATED, Before: created, After: updated, Actor: "alice", ChangeTime: changeTime},
+		{Revision: 1, Type: v1.TodoEvent_CREATED, After: created, ChangeTime: changeTime},
+	}
+	if len(got.Revisions) != len(want) {
+		t.Fatalf("toDoServiceServer.GetHistory() = %v, want %v", got.Revisions, want)
+	}
+	for i := range want {
+		if !proto.Equal(got.Revisions[i], want[i]) {
+			t.Errorf("toDoServiceServer.GetHistory() revisions[%d] = %v, want %v", i, got.Revisions[i], want[i])
+		}
+	}
+}
+
+func Test_toDoServiceServer_RestoreRevision(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	revisionRows := func(listID int64) *sqlmock.Rows {
+		snapshot, _ := proto.Marshal(&v1.Todo{Id: 1, Title: "old title", Reminder: reminder, Labels: []string{"backend"}, Etag: `"1"`,
+			Recurrence: "FREQ=DAILY", ListId: listID})
+		return sqlmock.NewRows([]string{"Revision", "Type", "Before", "After", "Actor", "CreatedAt"}).
+			AddRow(1, v1.TodoEvent_CREATED, nil, snapshot, "", tm)
+	}
+
+	tests := []struct {
+		name     string
+		req      *v1.RestoreRevisionRequest
+		mock     func()
+		wantCode codes.Code
+	}{
+		{
+			name: "OK",
+			req:  &v1.RestoreRevisionRequest{Api: "v1", Id: 1, Revision: 1, Etag: `"2"`},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 2)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoRevision WHERE `ToDoID`=\\? AND `Revision`=\\?").WithArgs(1, 1).
+					WillReturnRows(revisionRows(0))
+				mock.ExpectExec("UPDATE ToDo SET `Title`=\\?").WithArgs("old title", "", tm, v1.Todo_OPEN, nil, "FREQ=DAILY", "", nil, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM ToDoLabel").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO Label").WithArgs("", "backend").WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectExec("INSERT INTO ToDoLabel").WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordChange(mock, 1, 3)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "List restored",
+			req:  &v1.RestoreRevisionRequest{Api: "v1", Id: 1, Revision: 1},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 2)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoRevision").WithArgs(1, 1).WillReturnRows(revisionRows(4))
+				mock.ExpectQuery("SELECT `ID` FROM ToDoList WHERE `ID`=\\? AND `Owner`=\\? LOCK IN SHARE MODE").WithArgs(4, "").
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(4))
+				mock.ExpectExec("UPDATE ToDo SET `Title`=\\?").WithArgs("old title", "", tm, v1.Todo_OPEN, nil, "FREQ=DAILY", "", 4, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM ToDoLabel").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO Label").WithArgs("", "backend").WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectExec("INSERT INTO ToDoLabel").WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordChange(mock, 1, 3)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "List deleted",
+			req:  &v1.RestoreRevisionRequest{Api: "v1", Id: 1, Revision: 1},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 2)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoRevision").WithArgs(1, 1).WillReturnRows(revisionRows(4))
+				mock.ExpectQuery("SELECT `ID` FROM ToDoList").WithArgs(4, "").WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				mock.ExpectExec("UPDATE ToDo SET `Title`=\\?").WithArgs("old title", "", tm, v1.Todo_OPEN, nil, "FREQ=DAILY", "", nil, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM ToDoLabel").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO Label").WithArgs("", "backend").WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectExec("INSERT INTO ToDoLabel").WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordChange(mock, 1, 3)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Stale etag",
+			req:  &v1.RestoreRevisionRequest{Api: "v1", Id: 1, Revision: 1, Etag: `"1"`},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 2)
+				mock.ExpectRollback()
+			},
+			wantCode: codes.Aborted,
+		},
+		{
+			name: "Revision not found",
+			req:  &v1.RestoreRevisionRequest{Api: "v1", Id: 1, Revision: 7},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 2)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoRevision").WithArgs(1, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"Revision", "Type", "Before", "After", "Actor", "CreatedAt"}))
+				mock.ExpectRollback()
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "ToDo not found",
+			req:  &v1.RestoreRevisionRequest{Api: "v1", Id: 1, Revision: 1},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 0)
+				mock.ExpectRollback()
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := s.RestoreRevision(ctx, tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("toDoServiceServer.RestoreRevision() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err == nil && !reflect.DeepEqual(got.Todo.Etag, `"3"`) {
+				t.Errorf("toDoServiceServer.RestoreRevision() etag = %v, want %v", got.Todo.Etag, `"3"`)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
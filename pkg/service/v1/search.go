@@ -0,0 +1,245 @@
+package v1
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/logger"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/search"
+)
+
+// snippetWidth is about how many characters of the title and description
+// are returned around the matching words
+const snippetWidth = 160
+
+// WithSearchIndex sets index Search looks up todos in, the FULLTEXT index
+// of the ToDo table is used by default. Index which is a search.Writer is
+// kept in sync with the todos by following the event log.
+func WithSearchIndex(idx search.Index) Option {
+	return func(s *todoServiceServer) {
+		if idx != nil {
+			s.index = idx
+		}
+	}
+}
+
+// indexFollower keeps search.Writer in sync with the todos. It follows the
+// event log like Watch does, so that changes committed by other replicas are
+// indexed too and rolled back changes are not.
+type indexFollower struct {
+	mu    sync.Mutex
+	index search.Writer
+
+	// cursor is position in the event log, nil until the todos are loaded
+	cursor *watchCursor
+}
+
+// sync indexes the todos changed since the last call, the first call
+// indexes all todos not in trash. Index is built again when events it has
+// not read were purged.
+func (f *indexFollower) sync(ctx context.Context, s *todoServiceServer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.cursor != nil && f.cursor.after > 0 {
+		// the last read event is purged after the ones following it
+		_, err := s.watchStart(ctx, s.db, strconv.FormatInt(f.cursor.after, 10))
+		if status.Code(err) == codes.OutOfRange {
+			logger.Log.Warn("search index is behind the event log, indexing all todos again")
+			if err := f.index.Clear(ctx); err != nil {
+				return status.Error(codes.Unknown, "failed to clear search index-> "+err.Error())
+			}
+			f.cursor = nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if f.cursor == nil {
+		// events are read first, todos changed while loading are indexed again
+		after, err := s.watchStart(ctx, s.db, "")
+		if err != nil {
+			return err
+		}
+		if err := f.load(ctx, s.db); err != nil {
+			return err
+		}
+		f.cursor = &watchCursor{after: after, sent: map[int64]bool{}}
+	}
+
+	for {
+		events, err := readEvents(ctx, s.db, f.cursor.after)
+		if err != nil {
+			return err
+		}
+
+		var pending []todoEvent
+		var ids []int64
+		for _, e := range events {
+			if !f.cursor.sent[e.id] {
+				f.cursor.sent[e.id] = true
+				pending = append(pending, e)
+				ids = append(ids, e.todoID)
+			}
+		}
+		before := f.cursor.after
+		f.cursor.advance(events, s.now())
+
+		todos, err := readTodos(ctx, s.db, ids)
+		if err != nil {
+			return err
+		}
+
+		var docs []search.Document
+		var removed []int64
+		for _, e := range pending {
+			td, ok := todos[e.todoID]
+			if !ok || td.DeletedAt != nil {
+				removed = append(removed, e.todoID)
+				continue
+			}
+			docs = append(docs, search.Document{Owner: e.owner, ID: td.Id, Title: td.Title, Description: td.Description})
+		}
+		if err := f.index.Remove(ctx, removed...); err != nil {
+			return status.Error(codes.Unknown, "failed to update search index-> "+err.Error())
+		}
+		if err := f.index.Put(ctx, docs...); err != nil {
+			return status.Error(codes.Unknown, "failed to update search index-> "+err.Error())
+		}
+
+		if len(events) < watchBatchSize || f.cursor.after == before {
+			return nil
+		}
+	}
+}
+
+// load indexes all todos not in trash
+func (f *indexFollower) load(ctx context.Context, q querier) error {
+	rows, err := q.QueryContext(ctx, "SELECT `ID`, `Owner`, `Title`, `Description` FROM ToDo WHERE `DeletedAt` IS NULL")
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	var docs []search.Document
+	for rows.Next() {
+		var d search.Document
+		if err := rows.Scan(&d.ID, &d.Owner, &d.Title, &d.Description); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
+		}
+		docs = append(docs, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+
+	if err := f.index.Put(ctx, docs...); err != nil {
+		return status.Error(codes.Unknown, "failed to update search index-> "+err.Error())
+	}
+	return nil
+}
+
+// Search finds todo tasks by words of their title and description
+func (s *todoServiceServer) Search(ctx context.Context, req *v1.SearchRequest) (*v1.SearchResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	query := strings.TrimSpace(req.Query)
+	terms := search.Tokenize(query)
+	if len(terms) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "query field must have at least one word")
+	}
+
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "page_size field is invalid-> "+err.Error())
+	}
+
+	// results are ranked, so pages are taken by offset
+	fingerprint := queryFingerprint("search", query)
+	offset := 0
+	if len(req.PageToken) > 0 {
+		cursor, err := decodePageToken(s.pageTokenKey, req.PageToken)
+		if err == nil && (cursor.Query != fingerprint || len(cursor.Keys) != 1) {
+			err = errPageTokenMismatch
+		}
+		if err == nil {
+			offset, err = strconv.Atoi(cursor.Keys[0])
+		}
+		if err != nil || offset < 0 {
+			return nil, status.Error(codes.InvalidArgument, "page_token field is invalid")
+		}
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.follower != nil {
+		if err := s.follower.sync(ctx, s); err != nil {
+			return nil, err
+		}
+	}
+
+	// one extra hit is fetched to find out whether next page exists
+	hits, err := s.index.Search(ctx, tenant, query, offset, size+1)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to search todos-> "+err.Error())
+	}
+
+	var next string
+	if len(hits) > size {
+		hits = hits[:size]
+		next, err = encodePageToken(s.pageTokenKey, pageToken{Keys: []string{strconv.Itoa(offset + size)}, Query: fingerprint})
+		if err != nil {
+			return nil, status.Error(codes.Unknown, "failed to create next page token-> "+err.Error())
+		}
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	ids := make([]int64, len(hits))
+	for i, h := range hits {
+		ids[i] = h.ID
+	}
+	todos, err := readTodos(ctx, c, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*v1.SearchResult{}
+	for _, h := range hits {
+		td, ok := todos[h.ID]
+		if !ok || td.DeletedAt != nil {
+			// ToDo is deleted after the index was read
+			continue
+		}
+		results = append(results, &v1.SearchResult{
+			Todo:               td,
+			Score:              h.Score,
+			TitleSnippet:       search.Snippet(td.Title, terms, snippetWidth),
+			DescriptionSnippet: search.Snippet(td.Description, terms, snippetWidth),
+		})
+	}
+
+	return &v1.SearchResponse{
+		Api:           apiVersion,
+		Results:       results,
+		NextPageToken: next,
+	}, nil
+}
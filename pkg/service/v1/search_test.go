@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/logger"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/search"
+)
+
+func Test_toDoServiceServer_Search(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db, WithSearchIndex(search.NewMemoryIndex()))
+	ctx := context.Background()
+	tm := time.Now().In(time.UTC)
+	columns := []string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}
+
+	// the first search indexes all todos
+	mock.ExpectQuery("SELECT COALESCE\\(MAX").WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(5))
+	mock.ExpectQuery("SELECT `ID`, `Owner`, `Title`, `Description` FROM ToDo WHERE `DeletedAt` IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Owner", "Title", "Description"}).
+			AddRow(1, "", "Fix printer", "The office printer is jammed").
+			AddRow(2, "", "Buy paper", "Paper for the printer").
+			AddRow(3, "team-b", "Fix printer", ""))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoEvent WHERE `ID`>\\?").WithArgs(5, watchBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "ToDoID", "Type", "CreatedAt", "Owner"}))
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Fix printer", "The office printer is jammed", tm, 0, nil, nil, 1, nil, "", nil, ""))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+
+	got, err := s.Search(ctx, &v1.SearchRequest{Api: "v1", Query: "printer", PageSize: 1})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.Search() error = %v", err)
+	}
+	if len(got.Results) != 1 || got.Results[0].Todo.Id != 1 || got.NextPageToken == "" {
+		t.Fatalf("toDoServiceServer.Search() = %v, want todo 1 and next page", got)
+	}
+	if r := got.Results[0]; r.TitleSnippet != "Fix <em>printer</em>" || r.DescriptionSnippet != "The office <em>printer</em> is jammed" {
+		t.Errorf("toDoServiceServer.Search() snippets = '%s', '%s'", r.TitleSnippet, r.DescriptionSnippet)
+	}
+
+	// page token is bound to the query
+	_, err = s.Search(ctx, &v1.SearchRequest{Api: "v1", Query: "paper", PageToken: got.NextPageToken})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("toDoServiceServer.Search() error = %v, wantCode %v", err, codes.InvalidArgument)
+	}
+
+	// changes are indexed before the next search, todo moved to trash is removed
+	mock.ExpectQuery("SELECT `ID` FROM ToDoEvent WHERE `ID`=\\?").WithArgs(5).WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(5))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoEvent WHERE `ID`>\\?").WithArgs(5, watchBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "ToDoID", "Type", "CreatedAt", "Owner"}).
+			AddRow(6, 2, v1.TodoEvent_UPDATED, tm, "").
+			AddRow(7, 1, v1.TodoEvent_DELETED, tm, ""))
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, "Fix printer", "The office printer is jammed", tm, 0, nil, tm, 2, nil, "", nil, "").
+			AddRow(2, "Buy printer paper", "", tm, 0, nil, nil, 2, nil, "", nil, ""))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "Buy printer paper", "", tm, 0, nil, nil, 2, nil, "", nil, ""))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+
+	got, err = s.Search(ctx, &v1.SearchRequest{Api: "v1", Query: "printer"})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.Search() error = %v", err)
+	}
+	if len(got.Results) != 1 || got.Results[0].Todo.Id != 2 || got.NextPageToken != "" {
+		t.Fatalf("toDoServiceServer.Search() = %v, want todo 2 only", got)
+	}
+	if r := got.Results[0]; r.TitleSnippet != "Buy <em>printer</em> paper" || r.DescriptionSnippet != "" {
+		t.Errorf("toDoServiceServer.Search() snippets = '%s', '%s'", r.TitleSnippet, r.DescriptionSnippet)
+	}
+
+	// query must have a word
+	_, err = s.Search(ctx, &v1.SearchRequest{Api: "v1", Query: " ?! "})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("toDoServiceServer.Search() error = %v, wantCode %v", err, codes.InvalidArgument)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_Search_purgedEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	logger.Log = zap.NewNop()
+	s := NewTodoServiceServer(db, WithSearchIndex(search.NewMemoryIndex()))
+	ctx := context.Background()
+	noEvents := []string{"ID", "ToDoID", "Type", "CreatedAt", "Owner"}
+
+	mock.ExpectQuery("SELECT COALESCE\\(MAX").WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(5))
+	mock.ExpectQuery("SELECT `ID`, `Owner`, `Title`, `Description` FROM ToDo WHERE `DeletedAt` IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Owner", "Title", "Description"}).AddRow(1, "", "Fix printer", ""))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoEvent WHERE `ID`>\\?").WithArgs(5, watchBatchSize).WillReturnRows(sqlmock.NewRows(noEvents))
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1).WillReturnRows(todoRows(1, 1))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+
+	if _, err := s.Search(ctx, &v1.SearchRequest{Api: "v1", Query: "printer"}); err != nil {
+		t.Fatalf("toDoServiceServer.Search() error = %v", err)
+	}
+
+	// events after the cursor were purged before they were read, so the
+	// todos are indexed again and the deleted one is not found
+	mock.ExpectQuery("SELECT `ID` FROM ToDoEvent WHERE `ID`=\\?").WithArgs(5).WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+	mock.ExpectQuery("SELECT COALESCE\\(MAX").WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(9))
+	mock.ExpectQuery("SELECT `ID`, `Owner`, `Title`, `Description` FROM ToDo WHERE `DeletedAt` IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Owner", "Title", "Description"}).AddRow(2, "", "Buy paper", ""))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoEvent WHERE `ID`>\\?").WithArgs(9, watchBatchSize).WillReturnRows(sqlmock.NewRows(noEvents))
+
+	got, err := s.Search(ctx, &v1.SearchRequest{Api: "v1", Query: "printer"})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.Search() error = %v", err)
+	}
+	if len(got.Results) != 0 {
+		t.Errorf("toDoServiceServer.Search() = %v, want no results", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_Search_fullText(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+
+	// FULLTEXT index of the ToDo table is used by default
+	mock.ExpectQuery("SELECT `ID`, MATCH(.+) FROM ToDo WHERE `Owner`=\\?").WithArgs("printer", "", "printer", 51, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Score"}).AddRow(1, 0.8))
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1).WillReturnRows(todoRows(1, 1))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+
+	got, err := s.Search(context.Background(), &v1.SearchRequest{Api: "v1", Query: "printer"})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.Search() error = %v", err)
+	}
+	if len(got.Results) != 1 || got.Results[0].Score != 0.8 {
+		t.Errorf("toDoServiceServer.Search() = %v, want todo 1 with score 0.8", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
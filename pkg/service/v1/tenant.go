@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxTenantLength is the maximum length of the tenant name
+	maxTenantLength = 64
+
+	// defaultTenant owns todos of requests which are not authenticated for a
+	// tenant, including todos created before tenants were introduced
+	defaultTenant = ""
+)
+
+// tenantContextKey is the context key of the authenticated tenant
+type tenantContextKey struct{}
+
+// WithTenant returns context of the request authenticated for the tenant.
+// Authentication middleware uses it, the service never takes the tenant from
+// metadata sent by client.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns tenant the request is authenticated for, false
+// if the request is not authenticated
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenant, ok
+}
+
+// requestTenant returns tenant the client acts for, every ToDo the client
+// reads or changes must be owned by it
+func requestTenant(ctx context.Context) (string, error) {
+	tenant, ok := TenantFromContext(ctx)
+	if !ok {
+		tenant = defaultTenant
+	}
+
+	if utf8.RuneCountInString(tenant) > maxTenantLength {
+		return "", status.Errorf(codes.InvalidArgument, "tenant is longer than %d characters", maxTenantLength)
+	}
+	return tenant, nil
+}
@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+func Test_requestTenant(t *testing.T) {
+	incoming := func(tenant string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("tenant", tenant))
+	}
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		want     string
+		wantCode codes.Code
+	}{
+		{
+			name: "Default",
+			ctx:  context.Background(),
+			want: defaultTenant,
+		},
+		{
+			name: "Metadata is not trusted",
+			ctx:  incoming("team-a"),
+			want: defaultTenant,
+		},
+		{
+			name: "Authenticated",
+			ctx:  WithTenant(incoming("team-a"), "team-b"),
+			want: "team-b",
+		},
+		{
+			name:     "Too long",
+			ctx:      WithTenant(context.Background(), strings.Repeat("t", maxTenantLength+1)),
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := requestTenant(tt.ctx)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("requestTenant() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if got != tt.want {
+				t.Errorf("requestTenant() = '%v', want '%v'", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_otherTenant(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	ctx := WithTenant(context.Background(), "team-b")
+
+	// ToDo 1 exists but is owned by another tenant
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\?").WithArgs(1, "team-b").
+		WillReturnRows(todoRows(1, 0))
+	if _, err := s.Read(ctx, &v1.ReadRequest{Api: "v1", Id: 1}); status.Code(err) != codes.NotFound {
+		t.Errorf("toDoServiceServer.Read() error = %v, wantCode %v", err, codes.NotFound)
+	}
+
+	// ToDo is not changed when it is not found
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `Owner`=\\? FOR UPDATE").WithArgs(1, "team-b").
+		WillReturnRows(todoRows(1, 0))
+	mock.ExpectRollback()
+	_, err = s.Update(ctx, &v1.UpdateRequest{
+		Api:        "v1",
+		Todo:       &v1.Todo{Id: 1, Title: "new title"},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("toDoServiceServer.Update() error = %v, wantCode %v", err, codes.NotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// maxTimeZoneLength is the maximum length of the stored time zone name
+const maxTimeZoneLength = 64
+
+// locations caches time zones by name, loading a zone reads the zoneinfo file
+var locations sync.Map
+
+// loadLocation returns the IANA time zone, empty name is UTC
+func loadLocation(name string) (*time.Location, error) {
+	if len(name) == 0 {
+		return time.UTC, nil
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// parseTimeZone validates time zone name sent by client, empty name means
+// UTC
+func parseTimeZone(name string) (string, error) {
+	if len(name) > maxTimeZoneLength {
+		return "", status.Errorf(codes.InvalidArgument, "time_zone field is longer than %d characters", maxTimeZoneLength)
+	}
+	// Local is the zone of the server, not a zone of the client
+	if name == "Local" {
+		return "", status.Error(codes.InvalidArgument, "time_zone field must be IANA time zone name")
+	}
+	if _, err := loadLocation(name); err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "time_zone field has unknown time zone '%s'", name)
+	}
+	return name, nil
+}
+
+// todoReminder returns reminder of the todo in its time zone, so that
+// recurrences keep the local time of the reminder
+func todoReminder(td *v1.Todo) (time.Time, error) {
+	reminder, err := ptypes.Timestamp(td.Reminder)
+	if err != nil {
+		return time.Time{}, status.Error(codes.Unknown, "reminder field has invalid format-> "+err.Error())
+	}
+	loc, err := loadLocation(td.TimeZone)
+	if err != nil {
+		return time.Time{}, status.Error(codes.Unknown, "time_zone field has invalid format-> "+err.Error())
+	}
+	return reminder.In(loc), nil
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+func Test_parseTimeZone(t *testing.T) {
+	tests := []struct {
+		name     string
+		zone     string
+		wantCode codes.Code
+	}{
+		{
+			name: "UTC",
+		},
+		{
+			name: "IANA name",
+			zone: "Asia/Colombo",
+		},
+		{
+			name:     "Unknown",
+			zone:     "Asia/Atlantis",
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Server zone",
+			zone:     "Local",
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Too long",
+			zone:     strings.Repeat("A", maxTimeZoneLength+1),
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimeZone(tt.zone)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("parseTimeZone() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err == nil && got != tt.zone {
+				t.Errorf("parseTimeZone() = %v, want %v", got, tt.zone)
+			}
+		})
+	}
+}
+
+func Test_todoReminder(t *testing.T) {
+	reminder, _ := ptypes.TimestampProto(time.Date(2026, 10, 20, 3, 30, 0, 0, time.UTC))
+
+	got, err := todoReminder(&v1.Todo{Reminder: reminder, TimeZone: "Asia/Colombo"})
+	if err != nil {
+		t.Fatalf("todoReminder() error = %v", err)
+	}
+	if s := got.Format(time.RFC3339); s != "2026-10-20T09:00:00+05:30" {
+		t.Errorf("todoReminder() = %s, want 2026-10-20T09:00:00+05:30", s)
+	}
+}
@@ -4,9 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/blob"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/search"
+	"github.com/go-sql-driver/mysql"
 	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -14,18 +20,94 @@ import (
 
 const (
 	apiVersion = "v1"
+
+	// maxExternalIDLength is the maximum length of the external ID
+	maxExternalIDLength = 128
 )
 
 type todoServiceServer struct {
 	db *sql.DB
+
+	// pageTokenKey is used to sign and verify ReadAll page tokens
+	pageTokenKey []byte
+
+	// now returns current time, replaced in tests
+	now func() time.Time
+
+	// watchInterval is how often Watch polls for changes of other replicas
+	watchInterval time.Duration
+
+	// changes wakes up watchers when a change is committed
+	changes *changeNotifier
+
+	// idempotencyWindow is how long responses are returned on retries
+	idempotencyWindow time.Duration
+
+	// blobs keeps attached files, nil if attachments are not enabled
+	blobs blob.Store
+
+	// maxAttachmentSize is the maximum size of attached file in bytes
+	maxAttachmentSize int64
+
+	// attachmentTypes is set of content types accepted for attachments
+	attachmentTypes map[string]bool
+
+	// index is searched by Search
+	index search.Index
+
+	// follower keeps index in sync with the todos, nil if the index reads
+	// the todos from the database itself
+	follower *indexFollower
+}
+
+// Option configures optional parameters of the todo service
+type Option func(*todoServiceServer)
+
+// WithPageTokenKey sets the key to sign page tokens, all replicas must share it
+func WithPageTokenKey(key []byte) Option {
+	return func(s *todoServiceServer) {
+		if len(key) > 0 {
+			s.pageTokenKey = key
+		}
+	}
 }
 
 // NewTodoServiceServer creates new todo service
-func NewTodoServiceServer(db *sql.DB) v1.TodoServiceServer {
-	return &todoServiceServer{db: db}
+func NewTodoServiceServer(db *sql.DB, opts ...Option) v1.TodoServiceServer {
+	s := &todoServiceServer{
+		db:                db,
+		pageTokenKey:      newPageTokenKey(),
+		now:               time.Now,
+		watchInterval:     defaultWatchInterval,
+		changes:           newChangeNotifier(),
+		idempotencyWindow: defaultIdempotencyWindow,
+		maxAttachmentSize: defaultMaxAttachmentSize,
+		attachmentTypes:   attachmentTypeSet(defaultAttachmentTypes),
+		index:             search.NewMySQLIndex(db),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if w, ok := s.index.(search.Writer); ok {
+		s.follower = &indexFollower{index: w}
+	}
+
+	return s
 }
 
 func (s *todoServiceServer) checkAPI(api string) error {
+	return checkAPIVersion(api)
+}
+
+func (s *todoServiceServer) connect(ctx context.Context) (*sql.Conn, error) {
+	return connectDB(ctx, s.db)
+}
+
+// checkAPIVersion checks if the API version requested by client is
+// supported by server, empty version means the current one
+func checkAPIVersion(api string) error {
 	if len(api) > 0 {
 		if api != apiVersion {
 			return status.Errorf(codes.Unimplemented, "Unsupported API version, service is implemented '%s' but requested version of '%s'", apiVersion, api)
@@ -35,8 +117,9 @@ func (s *todoServiceServer) checkAPI(api string) error {
 
 }
 
-func (s *todoServiceServer) connect(ctx context.Context) (*sql.Conn, error) {
-	c, err := s.db.Conn(ctx)
+// connectDB gets SQL connection from the pool
+func connectDB(ctx context.Context, db *sql.DB) (*sql.Conn, error) {
+	c, err := db.Conn(ctx)
 
 	if err != nil {
 		return nil, status.Error(codes.Unknown, "failed to connect to database-> "+err.Error())
@@ -45,6 +128,247 @@ func (s *todoServiceServer) connect(ctx context.Context) (*sql.Conn, error) {
 	return c, nil
 }
 
+// querier is implemented by both sql.Conn and sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// begin starts transaction on the connection
+func (s *todoServiceServer) begin(ctx context.Context, c *sql.Conn) (*sql.Tx, error) {
+	tx, err := c.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to start transaction-> "+err.Error())
+	}
+	return tx, nil
+}
+
+// commit commits transaction and wakes up watchers
+func (s *todoServiceServer) commit(tx *sql.Tx) error {
+	if err := tx.Commit(); err != nil {
+		return status.Error(codes.Unknown, "failed to commit transaction-> "+err.Error())
+	}
+	s.changes.notify()
+	return nil
+}
+
+// todoColumns is list of ToDo columns in the order scanTodo reads them
+const todoColumns = "`ID`, `Title`, `Description`, `Reminder`, `Status`, `CompletedAt`, `DeletedAt`, `Version`, `ExternalID`, `Recurrence`, `ListID`, `TimeZone`"
+
+// scanTodo reads ToDo from the current row of the result set
+func scanTodo(rows *sql.Rows) (*v1.Todo, error) {
+	var td v1.Todo
+	var reminder time.Time
+	var completedAt, deletedAt sql.NullTime
+	var version int64
+	var externalID sql.NullString
+	var listID sql.NullInt64
+	if err := rows.Scan(&td.Id, &td.Title, &td.Description, &reminder, &td.Status, &completedAt, &deletedAt, &version, &externalID,
+		&td.Recurrence, &listID, &td.TimeZone); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
+	}
+
+	var err error
+	td.Reminder, err = ptypes.TimestampProto(reminder)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "reminder field has invalid format-> "+err.Error())
+	}
+
+	if completedAt.Valid {
+		td.CompletedAt, err = ptypes.TimestampProto(completedAt.Time)
+		if err != nil {
+			return nil, status.Error(codes.Unknown, "completed_at field has invalid format-> "+err.Error())
+		}
+	}
+
+	if deletedAt.Valid {
+		td.DeletedAt, err = ptypes.TimestampProto(deletedAt.Time)
+		if err != nil {
+			return nil, status.Error(codes.Unknown, "deleted_at field has invalid format-> "+err.Error())
+		}
+	}
+
+	td.Etag = formatETag(version)
+	td.ExternalId = externalID.String
+	td.ListId = listID.Int64
+
+	local, err := todoReminder(&td)
+	if err != nil {
+		return nil, err
+	}
+	td.LocalReminder = local.Format(time.RFC3339)
+
+	return &td, nil
+}
+
+// read queries ToDo of the tenant by ID together with its labels, ToDo moved
+// to trash is returned only if withDeleted is set
+func (s *todoServiceServer) read(ctx context.Context, q querier, id int64, withDeleted bool) (*v1.Todo, error) {
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + todoColumns + " FROM ToDo WHERE `ID`=? AND `Owner`=?"
+	if !withDeleted {
+		query += " AND `DeletedAt` IS NULL"
+	}
+
+	rows, err := q.QueryContext(ctx, query, id, tenant)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+		}
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
+			id))
+	}
+
+	// get ToDo data
+	td, err := scanTodo(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if rows.Next() {
+		return nil, status.Error(codes.Unknown, fmt.Sprintf("found multiple ToDo rows with ID='%d'",
+			id))
+	}
+	rows.Close()
+
+	if err := loadLabels(ctx, q, []*v1.Todo{td}); err != nil {
+		return nil, err
+	}
+
+	return td, nil
+}
+
+// completedAt returns completion time for the ToDo created or updated with given status
+func (s *todoServiceServer) completedAt(st v1.Todo_Status) interface{} {
+	if st == v1.Todo_DONE {
+		return s.now().UTC()
+	}
+	return nil
+}
+
+// checkStatus validates status value sent by client
+func checkStatus(st v1.Todo_Status) error {
+	if _, ok := v1.Todo_Status_name[int32(st)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "status field has unknown value '%d'", st)
+	}
+	return nil
+}
+
+// todoInsert is validated ToDo ready to be inserted
+type todoInsert struct {
+	todo     *v1.Todo
+	reminder time.Time
+	labels   []string
+
+	// recurrence is the recurrence rule in canonical form
+	recurrence string
+
+	// externalID is NULL unless the ToDo has an external ID
+	externalID interface{}
+
+	// listID is ID of the list the ToDo is added to, zero for none
+	listID int64
+
+	// timeZone is the validated time zone of the reminder
+	timeZone string
+}
+
+// prepareCreate validates ToDo sent by client to be created
+func prepareCreate(td *v1.Todo) (*todoInsert, error) {
+	if td == nil {
+		return nil, status.Error(codes.InvalidArgument, "todo field is required")
+	}
+
+	reminder, err := ptypes.Timestamp(td.Reminder)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "reminder field has invalid format-> "+err.Error())
+	}
+
+	if err := checkStatus(td.Status); err != nil {
+		return nil, err
+	}
+
+	labels, err := normalizeLabels(td.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	recurrence, err := parseRecurrence(td.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	if td.ListId < 0 {
+		return nil, status.Error(codes.InvalidArgument, "list_id field must not be negative")
+	}
+
+	timeZone, err := parseTimeZone(td.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	ins := &todoInsert{todo: td, reminder: reminder, labels: labels, recurrence: recurrence, listID: td.ListId, timeZone: timeZone}
+	if len(td.ExternalId) > 0 {
+		if utf8.RuneCountInString(td.ExternalId) > maxExternalIDLength {
+			return nil, status.Errorf(codes.InvalidArgument, "external_id field is longer than %d characters", maxExternalIDLength)
+		}
+		ins.externalID = td.ExternalId
+	}
+
+	return ins, nil
+}
+
+// insert creates ToDo of the tenant with its labels and returns ID of the
+// new ToDo
+func (s *todoServiceServer) insert(ctx context.Context, q querier, ins *todoInsert) (int64, error) {
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if ins.listID != 0 {
+		if err := checkList(ctx, q, tenant, ins.listID); err != nil {
+			return 0, err
+		}
+	}
+
+	// insert ToDo entity data
+	res, err := q.ExecContext(ctx, "INSERT INTO ToDo(`Title`, `Description`, `Reminder`, `Status`, `CompletedAt`, `ExternalID`, `Recurrence`, `Owner`, `ListID`, `TimeZone`) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		ins.todo.Title, ins.todo.Description, ins.reminder, ins.todo.Status, s.completedAt(ins.todo.Status), ins.externalID, ins.recurrence, tenant,
+		listColumnValue(ins.listID), ins.timeZone)
+	if err != nil {
+		if me, ok := err.(*mysql.MySQLError); ok && me.Number == mysqlErrDuplicateEntry {
+			return 0, status.Errorf(codes.AlreadyExists, "ToDo with external_id='%s' already exists", ins.todo.ExternalId)
+		}
+		return 0, status.Error(codes.Unknown, "failed to insert into ToDo-> "+err.Error())
+	}
+
+	// get ID of creates ToDo
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, status.Error(codes.Unknown, "failed to retrieve id for created ToDo-> "+err.Error())
+	}
+
+	if err := addLabels(ctx, q, id, ins.labels); err != nil {
+		return 0, err
+	}
+
+	if _, err := s.recordChange(ctx, q, id, v1.TodoEvent_CREATED, nil); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
 func (s *todoServiceServer) Create(ctx context.Context, req *v1.CreateRequest) (*v1.CreateResponse, error) {
 	// check if the API version requested by client is supported by server
 	if err := s.checkAPI(req.Api); err != nil {
@@ -58,28 +382,54 @@ func (s *todoServiceServer) Create(ctx context.Context, req *v1.CreateRequest) (
 	}
 	defer c.Close()
 
-	reminder, err := ptypes.Timestamp(req.Todo.Reminder)
+	ins, err := prepareCreate(req.Todo)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "reminder field has invalid format-> "+err.Error())
+		return nil, err
 	}
 
-	// insert ToDo entity data
-	res, err := c.ExecContext(ctx, "INSERT INTO ToDo(`Title`, `Description`, `Reminder`) VALUES(?, ?, ?)",
-		req.Todo.Title, req.Todo.Description, reminder)
+	payload := *req
+	payload.Api, payload.IdempotencyKey = "", ""
+	idem, err := newIdempotencyKey(ctx, req.IdempotencyKey, "Create", &payload)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to insert into ToDo-> "+err.Error())
+		return nil, err
 	}
 
-	// get ID of creates ToDo
-	id, err := res.LastInsertId()
+	tx, err := s.begin(ctx, c)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to retrieve id for created ToDo-> "+err.Error())
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	return &v1.CreateResponse{
-		Api: apiVersion,
-		Id:  id,
-	}, nil
+	resp := &v1.CreateResponse{}
+	replayed, err := s.replay(ctx, tx, idem, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !replayed {
+		id, err := s.insert(ctx, tx, ins)
+		if err != nil {
+			return nil, err
+		}
+
+		// new ToDo starts with the first version
+		resp = &v1.CreateResponse{
+			Api:  apiVersion,
+			Id:   id,
+			Etag: formatETag(1),
+		}
+		if err := s.storeResponse(ctx, tx, idem, resp); err != nil {
+			return nil, err
+		}
+
+		if err := s.commit(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	setETagHeader(ctx, resp.Etag)
+
+	return resp, nil
 }
 
 func (s *todoServiceServer) Read(ctx context.Context, req *v1.ReadRequest) (*v1.ReadResponse, error) {
@@ -96,42 +446,181 @@ func (s *todoServiceServer) Read(ctx context.Context, req *v1.ReadRequest) (*v1.
 	defer c.Close()
 
 	// query ToDo by ID
-	rows, err := c.QueryContext(ctx, "SELECT `ID`, `Title`, `Description`, `Reminder` FROM ToDo WHERE `ID`=?",
-		req.Id)
+	td, err := s.read(ctx, c, req.Id, true)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		return nil, err
 	}
-	defer rows.Close()
+	setETagHeader(ctx, td.Etag)
 
-	if !rows.Next() {
-		if err := rows.Err(); err != nil {
-			return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	return &v1.ReadResponse{
+		Api:  apiVersion,
+		Todo: td,
+	}, nil
+
+}
+
+// todoUpdate is validated change of the ToDo
+type todoUpdate struct {
+	id    int64
+	query string
+	args  []interface{}
+
+	// labels replace labels of the ToDo unless nil
+	labels []string
+
+	// listID is ID of the list the ToDo is moved to, zero if it is not moved
+	// to a list
+	listID int64
+
+	// conditional is set when the change is applied to the expected version only
+	conditional bool
+}
+
+// prepareUpdate validates update request, etag is the version of the ToDo
+// client expects, empty etag matches any version
+func (s *todoServiceServer) prepareUpdate(req *v1.UpdateRequest, etag string) (*todoUpdate, error) {
+	if req.Todo == nil {
+		return nil, status.Error(codes.InvalidArgument, "todo field is required")
+	}
+
+	paths, err := updatePaths(req.UpdateMask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "update_mask field is invalid-> "+err.Error())
+	}
+	if len(paths) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask field does not contain any updatable field")
+	}
+
+	version, conditional, err := checkETag(etag)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &todoUpdate{id: req.Todo.Id, conditional: conditional}
+	sets := make([]string, 0, len(paths)+1)
+
+	// collect values of the fields in the update mask only
+	for _, path := range paths {
+		switch path {
+		case "title":
+			sets = append(sets, "`Title`=?")
+			u.args = append(u.args, req.Todo.Title)
+		case "description":
+			sets = append(sets, "`Description`=?")
+			u.args = append(u.args, req.Todo.Description)
+		case "reminder":
+			reminder, err := ptypes.Timestamp(req.Todo.Reminder)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "reminder field has invalid format-> "+err.Error())
+			}
+			sets = append(sets, "`Reminder`=?")
+			u.args = append(u.args, reminder)
+		case "status":
+			if err := checkStatus(req.Todo.Status); err != nil {
+				return nil, err
+			}
+			if req.Todo.Status == v1.Todo_DONE {
+				sets = append(sets, "`Status`=?", "`CompletedAt`=COALESCE(`CompletedAt`, ?)")
+			} else {
+				sets = append(sets, "`Status`=?", "`CompletedAt`=?")
+			}
+			u.args = append(u.args, req.Todo.Status, s.completedAt(req.Todo.Status))
+		case "labels":
+			if u.labels, err = normalizeLabels(req.Todo.Labels); err != nil {
+				return nil, err
+			}
+		case "recurrence":
+			recurrence, err := parseRecurrence(req.Todo.Recurrence)
+			if err != nil {
+				return nil, err
+			}
+			sets = append(sets, "`Recurrence`=?")
+			u.args = append(u.args, recurrence)
+		case "list_id":
+			if req.Todo.ListId < 0 {
+				return nil, status.Error(codes.InvalidArgument, "list_id field must not be negative")
+			}
+			sets = append(sets, "`ListID`=?")
+			u.args = append(u.args, listColumnValue(req.Todo.ListId))
+			u.listID = req.Todo.ListId
+		case "time_zone":
+			timeZone, err := parseTimeZone(req.Todo.TimeZone)
+			if err != nil {
+				return nil, err
+			}
+			sets = append(sets, "`TimeZone`=?")
+			u.args = append(u.args, timeZone)
 		}
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
-			req.Id))
 	}
 
-	// get ToDo data
-	var td v1.Todo
-	var reminder time.Time
-	if err := rows.Scan(&td.Id, &td.Title, &td.Description, &reminder); err != nil {
-		return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
+	// version is changed by every update, LAST_INSERT_ID makes the new value available as insert ID
+	sets = append(sets, "`Version`=LAST_INSERT_ID(`Version`+1)")
+	u.query = "UPDATE ToDo SET " + strings.Join(sets, ", ") + " WHERE `ID`=? AND `DeletedAt` IS NULL"
+	u.args = append(u.args, u.id)
+	if conditional {
+		u.query += " AND `Version`=?"
+		u.args = append(u.args, version)
 	}
-	td.Reminder, err = ptypes.TimestampProto(reminder)
+
+	return u, nil
+}
+
+// update applies change to the ToDo and returns its new version
+func (s *todoServiceServer) update(ctx context.Context, q querier, u *todoUpdate) (int64, error) {
+	before, err := lockTodo(ctx, q, u.id)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "reminder field has invalid format-> "+err.Error())
+		return 0, err
+	}
+	if before == nil {
+		return 0, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
+			u.id))
 	}
 
-	if rows.Next() {
-		return nil, status.Error(codes.Unknown, fmt.Sprintf("found multiple ToDo rows with ID='%d'",
-			req.Id))
+	if u.listID != 0 {
+		tenant, err := requestTenant(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if err := checkList(ctx, q, tenant, u.listID); err != nil {
+			return 0, err
+		}
 	}
 
-	return &v1.ReadResponse{
-		Api:  apiVersion,
-		Todo: &td,
-	}, nil
+	// update ToDo
+	res, err := q.ExecContext(ctx, u.query, u.args...)
+	if err != nil {
+		return 0, status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+
+	if rows == 0 {
+		if u.conditional {
+			return 0, staleETagError(ctx, q, u.id)
+		}
+		return 0, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
+			u.id))
+	}
 
+	version, err := res.LastInsertId()
+	if err != nil {
+		return 0, status.Error(codes.Unknown, "failed to retrieve version of updated ToDo-> "+err.Error())
+	}
+
+	if u.labels != nil {
+		if err := setLabels(ctx, q, u.id, u.labels); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := s.recordChange(ctx, q, u.id, v1.TodoEvent_UPDATED, before); err != nil {
+		return 0, err
+	}
+
+	return version, nil
 }
 
 // Update todo task
@@ -148,34 +637,88 @@ func (s *todoServiceServer) Update(ctx context.Context, req *v1.UpdateRequest) (
 	}
 	defer c.Close()
 
-	reminder, err := ptypes.Timestamp(req.Todo.Reminder)
+	var etag string
+	if req.Todo != nil {
+		etag = requestETag(ctx, req.Todo.Etag)
+	}
+	u, err := s.prepareUpdate(req, etag)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "reminder field has invalid format-> "+err.Error())
+		return nil, err
 	}
 
-	// update ToDo
-	res, err := c.ExecContext(ctx, "UPDATE ToDo SET `Title`=?, `Description`=?, `Reminder`=? WHERE `ID`=?",
-		req.Todo.Title, req.Todo.Description, reminder, req.Todo.Id)
+	tx, err := s.begin(ctx, c)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	rows, err := res.RowsAffected()
+	version, err := s.update(ctx, tx, u)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+		return nil, err
 	}
 
-	if rows == 0 {
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
-			req.Todo.Id))
+	if err := s.commit(tx); err != nil {
+		return nil, err
 	}
 
+	etag = formatETag(version)
+	setETagHeader(ctx, etag)
+
+	// exactly one ToDo is updated, otherwise update fails
 	return &v1.UpdateResponse{
-		Api: apiVersion,
-		Id:  rows,
+		Api:  apiVersion,
+		Id:   1,
+		Etag: etag,
 	}, nil
 }
 
+// delete moves ToDo to trash, etag is the version of the ToDo client
+// expects, empty etag matches any version
+func (s *todoServiceServer) delete(ctx context.Context, q querier, id int64, etag string) error {
+	version, conditional, err := checkETag(etag)
+	if err != nil {
+		return err
+	}
+
+	before, err := lockTodo(ctx, q, id)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
+			id))
+	}
+
+	query := "UPDATE ToDo SET `DeletedAt`=?, `Version`=`Version`+1 WHERE `ID`=? AND `DeletedAt` IS NULL"
+	args := []interface{}{s.now().UTC(), id}
+	if conditional {
+		query += " AND `Version`=?"
+		args = append(args, version)
+	}
+
+	// move ToDo to trash, it is removed permanently by the purger after retention period
+	res, err := q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to delete ToDo-> "+err.Error())
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+
+	if rows == 0 {
+		if conditional {
+			return staleETagError(ctx, q, id)
+		}
+		return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
+			id))
+	}
+
+	_, err = s.recordChange(ctx, q, id, v1.TodoEvent_DELETED, before)
+	return err
+}
+
 // Delete todo task
 func (s *todoServiceServer) Delete(ctx context.Context, req *v1.DeleteRequest) (*v1.DeleteResponse, error) {
 	// check if the API version requested by client is supported by server
@@ -190,25 +733,23 @@ func (s *todoServiceServer) Delete(ctx context.Context, req *v1.DeleteRequest) (
 	}
 	defer c.Close()
 
-	// delete ToDo
-	res, err := c.ExecContext(ctx, "DELETE FROM ToDo WHERE `ID`=?", req.Id)
+	tx, err := s.begin(ctx, c)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to delete ToDo-> "+err.Error())
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	rows, err := res.RowsAffected()
-	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	if err := s.delete(ctx, tx, req.Id, requestETag(ctx, req.Etag)); err != nil {
+		return nil, err
 	}
 
-	if rows == 0 {
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
-			req.Id))
+	if err := s.commit(tx); err != nil {
+		return nil, err
 	}
 
 	return &v1.DeleteResponse{
 		Api:     apiVersion,
-		Deleted: rows,
+		Deleted: 1,
 	}, nil
 }
 
@@ -219,6 +760,55 @@ func (s *todoServiceServer) ReadAll(ctx context.Context, req *v1.ReadAllRequest)
 		return nil, err
 	}
 
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "page_size field is invalid-> "+err.Error())
+	}
+
+	filter, err := parseFilter(req.Filter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "filter field is invalid-> "+err.Error())
+	}
+
+	order, err := parseOrderBy(req.OrderBy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "order_by field is invalid-> "+err.Error())
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := queryFingerprint(req.Filter, orderString(order), strconv.FormatBool(req.ShowDeleted))
+	conds := []string{"`Owner`=?"}
+	args := []interface{}{tenant}
+	if !req.ShowDeleted {
+		conds = append(conds, "`DeletedAt` IS NULL")
+	}
+	if req.ListId != 0 {
+		conds = append(conds, "`ListID`=?")
+		args = append(args, req.ListId)
+		query = queryFingerprint(query, strconv.FormatInt(req.ListId, 10))
+	}
+	if len(req.PageToken) > 0 {
+		cond, cursorArgs, err := cursorSQL(s.pageTokenKey, req.PageToken, query, order)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "page_token field is invalid-> "+err.Error())
+		}
+		conds = append(conds, cond)
+		args = append(args, cursorArgs...)
+	}
+
+	if filter != nil {
+		cond, filterArgs := filterSQL(filter)
+		conds = append(conds, cond)
+		args = append(args, filterArgs...)
+	}
+
+	where := " WHERE " + strings.Join(conds, " AND ")
+	args = append(args, size+1)
+
 	// get SQL connection from pool
 	c, err := s.connect(ctx)
 	if err != nil {
@@ -226,23 +816,19 @@ func (s *todoServiceServer) ReadAll(ctx context.Context, req *v1.ReadAllRequest)
 	}
 	defer c.Close()
 
-	// get ToDo list
-	rows, err := c.QueryContext(ctx, "SELECT `ID`, `Title`, `Description`, `Reminder` FROM ToDo")
+	// get ToDo list, one extra row is fetched to find out whether next page exists
+	rows, err := c.QueryContext(ctx, "SELECT "+todoColumns+" FROM ToDo"+where+" ORDER BY "+orderSQL(order)+" LIMIT ?",
+		args...)
 	if err != nil {
 		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
 	}
 	defer rows.Close()
 
-	var reminder time.Time
 	list := []*v1.Todo{}
 	for rows.Next() {
-		td := new(v1.Todo)
-		if err := rows.Scan(&td.Id, &td.Title, &td.Description, &reminder); err != nil {
-			return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
-		}
-		td.Reminder, err = ptypes.TimestampProto(reminder)
+		td, err := scanTodo(rows)
 		if err != nil {
-			return nil, status.Error(codes.Unknown, "reminder field has invalid format-> "+err.Error())
+			return nil, err
 		}
 		list = append(list, td)
 	}
@@ -250,9 +836,168 @@ func (s *todoServiceServer) ReadAll(ctx context.Context, req *v1.ReadAllRequest)
 	if err := rows.Err(); err != nil {
 		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
 	}
+	rows.Close()
+
+	var next string
+	if len(list) > size {
+		list = list[:size]
+		next, err = encodePageToken(s.pageTokenKey, pageToken{Keys: orderKeys(order, list[size-1]), Query: query})
+		if err != nil {
+			return nil, status.Error(codes.Unknown, "failed to create next page token-> "+err.Error())
+		}
+	}
+
+	if err := loadLabels(ctx, c, list); err != nil {
+		return nil, err
+	}
 
 	return &v1.ReadAllResponse{
-		Api:   apiVersion,
-		Todos: list,
+		Api:           apiVersion,
+		Todos:         list,
+		NextPageToken: next,
+	}, nil
+}
+
+// todoStatement returns statement changing the ToDo, before is the ToDo
+// read prior to the change
+type todoStatement func(before *v1.Todo) (string, []interface{}, error)
+
+// statement returns todoStatement which does not depend on the ToDo
+func statement(query string, args ...interface{}) todoStatement {
+	return func(*v1.Todo) (string, []interface{}, error) {
+		return query, args, nil
+	}
+}
+
+// changeTodo applies single statement change to the ToDo in a transaction
+// and returns the changed ToDo, the statement must not match ToDo in trash
+// unless the change is restoring it
+func (s *todoServiceServer) changeTodo(ctx context.Context, c *sql.Conn, id int64, stmt todoStatement) (*v1.Todo, error) {
+	tx, err := s.begin(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	before, err := lockTodo(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
+			id))
+	}
+
+	query, args, err := stmt(before)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+
+	if rows == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found",
+			id))
+	}
+
+	td, err := s.recordChange(ctx, tx, id, v1.TodoEvent_UPDATED, before)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commit(tx); err != nil {
+		return nil, err
+	}
+
+	return td, nil
+}
+
+// Undelete restores todo task from trash
+func (s *todoServiceServer) Undelete(ctx context.Context, req *v1.UndeleteRequest) (*v1.UndeleteResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	td, err := s.changeTodo(ctx, c, req.Id, statement("UPDATE ToDo SET `DeletedAt`=NULL, `Version`=`Version`+1 WHERE `ID`=? AND `DeletedAt` IS NOT NULL",
+		req.Id))
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("deleted ToDo with ID='%d' is not found",
+				req.Id))
+		}
+		return nil, err
+	}
+
+	return &v1.UndeleteResponse{
+		Api:  apiVersion,
+		Todo: td,
+	}, nil
+}
+
+// Complete marks todo task as done, recurring todo task is moved to its next
+// occurrence instead
+func (s *todoServiceServer) Complete(ctx context.Context, req *v1.CompleteRequest) (*v1.CompleteResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	td, err := s.changeTodo(ctx, c, req.Id, s.completeStatement(req.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.CompleteResponse{
+		Api:  apiVersion,
+		Todo: td,
+	}, nil
+}
+
+// Reopen marks todo task as open again
+func (s *todoServiceServer) Reopen(ctx context.Context, req *v1.ReopenRequest) (*v1.ReopenResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	td, err := s.changeTodo(ctx, c, req.Id, statement("UPDATE ToDo SET `Status`=?, `CompletedAt`=NULL, `Version`=`Version`+1 WHERE `ID`=? AND `DeletedAt` IS NULL",
+		v1.Todo_OPEN, req.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ReopenResponse{
+		Api:  apiVersion,
+		Todo: td,
 	}, nil
 }
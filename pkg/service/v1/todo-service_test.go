@@ -8,8 +8,13 @@ import (
 	"time"
 
 	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
 
@@ -51,13 +56,67 @@ func Test_toDoServiceServer_Create(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("INSERT INTO Todo").WithArgs("title", "description", tm).
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecordChange(mock, 1, 1)
+				mock.ExpectCommit()
 			},
 			want: &v1.CreateResponse{
-				Api: "v1",
-				Id:  1,
+				Api:  "v1",
+				Id:   1,
+				Etag: `"1"`,
+			},
+		},
+		{
+			name: "With labels",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Title:       "title",
+						Description: "description",
+						Reminder:    reminder,
+						Labels:      []string{" urgent", "backend", "urgent "},
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("INSERT INTO Label").WithArgs("", "backend").WillReturnResult(sqlmock.NewResult(10, 1))
+				mock.ExpectExec("INSERT INTO ToDoLabel").WithArgs(2, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO Label").WithArgs("", "urgent").WillReturnResult(sqlmock.NewResult(11, 1))
+				mock.ExpectExec("INSERT INTO ToDoLabel").WithArgs(2, 11).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordChange(mock, 2, 1)
+				mock.ExpectCommit()
+			},
+			want: &v1.CreateResponse{
+				Api:  "v1",
+				Id:   2,
+				Etag: `"1"`,
+			},
+		},
+		{
+			name: "Empty label",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Title:       "title",
+						Description: "description",
+						Reminder:    reminder,
+						Labels:      []string{" "},
+					},
+				},
 			},
+			mock:    func() {},
+			wantErr: true,
 		},
 		{
 			name: "Unsupported API",
@@ -79,6 +138,52 @@ func Test_toDoServiceServer_Create(t *testing.T) {
 			mock:    func() {},
 			wantErr: true,
 		},
+		{
+			name: "With time zone",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Title:       "title",
+						Description: "description",
+						Reminder:    reminder,
+						TimeZone:    "Asia/Colombo",
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "Asia/Colombo").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecordChange(mock, 1, 1)
+				mock.ExpectCommit()
+			},
+			want: &v1.CreateResponse{
+				Api:  "v1",
+				Id:   1,
+				Etag: `"1"`,
+			},
+		},
+		{
+			name: "Unknown time zone",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Title:       "title",
+						Description: "description",
+						Reminder:    reminder,
+						TimeZone:    "Mars/Olympus_Mons",
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
 		{
 			name: "Invalid Reminder field format",
 			s:    s,
@@ -114,8 +219,10 @@ func Test_toDoServiceServer_Create(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("INSERT INTO Todo").WithArgs("title", "description", tm).
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
 					WillReturnError(errors.New("INSERT failed"))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -134,8 +241,10 @@ func Test_toDoServiceServer_Create(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("INSERT INTO Todo").WithArgs("title", "description", tm).
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm, v1.Todo_OPEN, nil, nil, "", "", nil, "").
 					WillReturnResult(sqlmock.NewErrorResult(errors.New("LastInsertId failed")))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -189,17 +298,24 @@ func Test_toDoServiceServer_Read(t *testing.T) {
 				},
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder"}).
-					AddRow(1, "title", "description", tm)
-				mock.ExpectQuery("SELECT (.+) FROM Todo").WithArgs(1).WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+					AddRow(1, "title", "description", tm, 0, nil, nil, 1, nil, "", nil, "")
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1, "").WillReturnRows(rows)
+				labels := sqlmock.NewRows([]string{"ToDoID", "Name"}).
+					AddRow(1, "backend").
+					AddRow(1, "urgent")
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WithArgs(1).WillReturnRows(labels)
 			},
 			want: &v1.ReadResponse{
 				Api: "v1",
 				Todo: &v1.Todo{
-					Id:          1,
-					Title:       "title",
-					Description: "description",
-					Reminder:    reminder,
+					Id:            1,
+					Title:         "title",
+					Description:   "description",
+					Reminder:      reminder,
+					LocalReminder: tm.Format(time.RFC3339),
+					Etag:          `"1"`,
+					Labels:        []string{"backend", "urgent"},
 				},
 			},
 		},
@@ -227,7 +343,7 @@ func Test_toDoServiceServer_Read(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectQuery("SELECT (.+) FROM Todo").WithArgs(1).
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1, "").
 					WillReturnError(errors.New("SELECT failed"))
 			},
 			wantErr: true,
@@ -243,8 +359,8 @@ func Test_toDoServiceServer_Read(t *testing.T) {
 				},
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder"})
-				mock.ExpectQuery("SELECT (.+) FROM Todo").WithArgs(1).WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"})
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1, "").WillReturnRows(rows)
 			},
 			wantErr: true,
 		},
@@ -281,12 +397,13 @@ func Test_toDoServiceServer_Update(t *testing.T) {
 		req *v1.UpdateRequest
 	}
 	tests := []struct {
-		name    string
-		s       v1.TodoServiceServer
-		args    args
-		mock    func()
-		want    *v1.UpdateResponse
-		wantErr bool
+		name     string
+		s        v1.TodoServiceServer
+		args     args
+		mock     func()
+		want     *v1.UpdateResponse
+		wantErr  bool
+		wantCode codes.Code
 	}{
 		{
 			name: "OK",
@@ -304,13 +421,220 @@ func Test_toDoServiceServer_Update(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("UPDATE Todo").WithArgs("new title", "new description", tm, 1).
-					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", "new description", tm, 1).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				expectRecordChange(mock, 1, 1)
+				mock.ExpectCommit()
 			},
 			want: &v1.UpdateResponse{
-				Api: "v1",
-				Id:  1,
+				Api:  "v1",
+				Id:   1,
+				Etag: `"2"`,
+			},
+		},
+		{
+			name: "Matching etag",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Id:    1,
+						Title: "new title",
+						Etag:  `"3"`,
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET (.+) WHERE `ID`=\\? AND `DeletedAt` IS NULL AND `Version`=\\?").WithArgs("new title", 1, 3).
+					WillReturnResult(sqlmock.NewResult(4, 1))
+				expectRecordChange(mock, 1, 1)
+				mock.ExpectCommit()
+			},
+			want: &v1.UpdateResponse{
+				Api:  "v1",
+				Id:   1,
+				Etag: `"4"`,
+			},
+		},
+		{
+			name: "Etag in If-Match metadata",
+			s:    s,
+			args: args{
+				ctx: metadata.NewIncomingContext(ctx, metadata.Pairs("if-match", `W/"3"`)),
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Id:    1,
+						Title: "new title",
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET (.+) AND `Version`=\\?").WithArgs("new title", 1, 3).
+					WillReturnResult(sqlmock.NewResult(4, 1))
+				expectRecordChange(mock, 1, 1)
+				mock.ExpectCommit()
+			},
+			want: &v1.UpdateResponse{
+				Api:  "v1",
+				Id:   1,
+				Etag: `"4"`,
+			},
+		},
+		{
+			name: "Stale etag",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Id:    1,
+						Title: "new title",
+						Etag:  `"3"`,
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", 1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT `Version` FROM ToDo").WithArgs(1, "").
+					WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(5))
+				mock.ExpectRollback()
+			},
+			wantErr:  true,
+			wantCode: codes.Aborted,
+		},
+		{
+			name: "Etag of missing ToDo",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Id:    1,
+						Title: "new title",
+						Etag:  `"3"`,
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 0)
+				mock.ExpectRollback()
+			},
+			wantErr:  true,
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Invalid etag",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Id:    1,
+						Title: "new title",
+						Etag:  "abc",
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+				},
+			},
+			mock:     func() {},
+			wantErr:  true,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Partial update",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Id:    1,
+						Title: "new title",
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"id", "title"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Title`=\\?, `Version`=(.+) WHERE").WithArgs("new title", 1).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				expectRecordChange(mock, 1, 1)
+				mock.ExpectCommit()
+			},
+			want: &v1.UpdateResponse{
+				Api:  "v1",
+				Id:   1,
+				Etag: `"2"`,
+			},
+		},
+		{
+			name: "Labels only",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Id:     1,
+						Labels: []string{"backend"},
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"labels"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Version`=(.+) WHERE").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("DELETE FROM ToDoLabel").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT INTO Label").WithArgs("", "backend").WillReturnResult(sqlmock.NewResult(10, 1))
+				mock.ExpectExec("INSERT INTO ToDoLabel").WithArgs(1, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordChange(mock, 1, 1)
+				mock.ExpectCommit()
+			},
+			want: &v1.UpdateResponse{
+				Api:  "v1",
+				Id:   1,
+				Etag: `"2"`,
+			},
+		},
+		{
+			name: "Unknown field in update mask",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					Todo: &v1.Todo{
+						Id:    1,
+						Title: "new title",
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"owner"}},
+				},
 			},
+			mock:    func() {},
+			wantErr: true,
 		},
 		{
 			name: "Unsupported API",
@@ -367,8 +691,11 @@ func Test_toDoServiceServer_Update(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("UPDATE Todo").WithArgs("new title", "new description", tm, 1).
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", "new description", tm, 1).
 					WillReturnError(errors.New("UPDATE failed"))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -388,8 +715,11 @@ func Test_toDoServiceServer_Update(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("UPDATE Todo").WithArgs("new title", "new description", tm, 1).
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", "new description", tm, 1).
 					WillReturnResult(sqlmock.NewErrorResult(errors.New("RowsAffected failed")))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -409,8 +739,9 @@ func Test_toDoServiceServer_Update(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("UPDATE Todo").WithArgs("new title", "new description", tm, 1).
-					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectBegin()
+				expectLock(mock, 1, 0)
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -423,6 +754,9 @@ func Test_toDoServiceServer_Update(t *testing.T) {
 				t.Errorf("TodoServiceServer.Update() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantCode != codes.OK && status.Code(err) != tt.wantCode {
+				t.Errorf("TodoServiceServer.Update() error = %v, wantCode %v", err, tt.wantCode)
+			}
 			if err == nil && !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("TodoServiceServer.Update() = %v, want %v", got, tt.want)
 			}
@@ -444,12 +778,13 @@ func Test_toDoServiceServer_Delete(t *testing.T) {
 		req *v1.DeleteRequest
 	}
 	tests := []struct {
-		name    string
-		s       v1.TodoServiceServer
-		args    args
-		mock    func()
-		want    *v1.DeleteResponse
-		wantErr bool
+		name     string
+		s        v1.TodoServiceServer
+		args     args
+		mock     func()
+		want     *v1.DeleteResponse
+		wantErr  bool
+		wantCode codes.Code
 	}{
 		{
 			name: "OK",
@@ -462,8 +797,12 @@ func Test_toDoServiceServer_Delete(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("DELETE FROM Todo").WithArgs(1).
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecordChange(mock, 1, 1)
+				mock.ExpectCommit()
 			},
 			want: &v1.DeleteResponse{
 				Api:     "v1",
@@ -494,8 +833,11 @@ func Test_toDoServiceServer_Delete(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("DELETE FROM Todo").WithArgs(1).
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
 					WillReturnError(errors.New("DELETE failed"))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -510,8 +852,11 @@ func Test_toDoServiceServer_Delete(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("DELETE FROM Todo").WithArgs(1).
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
 					WillReturnResult(sqlmock.NewErrorResult(errors.New("RowsAffected failed")))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -526,11 +871,59 @@ func Test_toDoServiceServer_Delete(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("DELETE FROM Todo").WithArgs(1).
-					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectBegin()
+				expectLock(mock, 1, 0)
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
+		{
+			name: "Matching etag",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteRequest{
+					Api:  "v1",
+					Id:   1,
+					Etag: `"3"`,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`(.+) AND `Version`=\\?").WithArgs(sqlmock.AnyArg(), 1, 3).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecordChange(mock, 1, 1)
+				mock.ExpectCommit()
+			},
+			want: &v1.DeleteResponse{
+				Api:     "v1",
+				Deleted: 1,
+			},
+		},
+		{
+			name: "Stale etag",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteRequest{
+					Api:  "v1",
+					Id:   1,
+					Etag: `"3"`,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1, 3).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectQuery("SELECT `Version` FROM ToDo").WithArgs(1, "").
+					WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(4))
+				mock.ExpectRollback()
+			},
+			wantErr:  true,
+			wantCode: codes.Aborted,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -540,6 +933,9 @@ func Test_toDoServiceServer_Delete(t *testing.T) {
 				t.Errorf("toDoServiceServer.Delete() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantCode != codes.OK && status.Code(err) != tt.wantCode {
+				t.Errorf("toDoServiceServer.Delete() error = %v, wantCode %v", err, tt.wantCode)
+			}
 			if err == nil && !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("toDoServiceServer.Delete() = %v, want %v", got, tt.want)
 			}
@@ -554,11 +950,17 @@ func Test_toDoServiceServer_ReadAll(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 	defer db.Close()
-	s := NewTodoServiceServer(db)
+	key := []byte("secret")
+	s := NewTodoServiceServer(db, WithPageTokenKey(key))
 	tm1 := time.Now().In(time.UTC)
 	reminder1, _ := ptypes.TimestampProto(tm1)
 	tm2 := time.Now().In(time.UTC)
 	reminder2, _ := ptypes.TimestampProto(tm2)
+	token, _ := encodePageToken(key, pageToken{Keys: []string{"1"}, Query: queryFingerprint("", "id", "false")})
+	orderToken, _ := encodePageToken(key, pageToken{
+		Keys:  []string{tm1.Format(time.RFC3339Nano), "1"},
+		Query: queryFingerprint("", "reminder desc, id", "false"),
+	})
 
 	type args struct {
 		ctx context.Context
@@ -582,29 +984,259 @@ func Test_toDoServiceServer_ReadAll(t *testing.T) {
 				},
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder"}).
-					AddRow(1, "title 1", "description 1", tm1).
-					AddRow(2, "title 2", "description 2", tm2)
-				mock.ExpectQuery("SELECT (.+) FROM Todo").WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+					AddRow(1, "title 1", "description 1", tm1, 0, nil, nil, 1, nil, "", nil, "").
+					AddRow(2, "title 2", "description 2", tm2, 0, nil, nil, 1, nil, "", nil, "")
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs("", defaultPageSize+1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
 			},
 			want: &v1.ReadAllResponse{
 				Api: "v1",
 				Todos: []*v1.Todo{
 					{
-						Id:          1,
-						Title:       "title 1",
-						Description: "description 1",
-						Reminder:    reminder1,
+						Id:            1,
+						Title:         "title 1",
+						Description:   "description 1",
+						Reminder:      reminder1,
+						LocalReminder: tm1.Format(time.RFC3339),
+						Etag:          `"1"`,
 					},
 					{
-						Id:          2,
-						Title:       "title 2",
-						Description: "description 2",
-						Reminder:    reminder2,
+						Id:            2,
+						Title:         "title 2",
+						Description:   "description 2",
+						Reminder:      reminder2,
+						LocalReminder: tm2.Format(time.RFC3339),
+						Etag:          `"1"`,
 					},
 				},
 			},
 		},
+		{
+			name: "First page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:      "v1",
+					PageSize: 1,
+				},
+			},
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+					AddRow(1, "title 1", "description 1", tm1, 0, nil, nil, 1, nil, "", nil, "").
+					AddRow(2, "title 2", "description 2", tm2, 0, nil, nil, 1, nil, "", nil, "")
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs("", 2).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				Todos: []*v1.Todo{
+					{
+						Id:            1,
+						Title:         "title 1",
+						Description:   "description 1",
+						Reminder:      reminder1,
+						LocalReminder: tm1.Format(time.RFC3339),
+						Etag:          `"1"`,
+					},
+				},
+				NextPageToken: token,
+			},
+		},
+		{
+			name: "Next page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:       "v1",
+					PageSize:  1,
+					PageToken: token,
+				},
+			},
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+					AddRow(2, "title 2", "description 2", tm2, 0, nil, nil, 1, nil, "", nil, "")
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Owner`=\\? AND `DeletedAt` IS NULL AND \\(\\(`ID` > \\?\\)\\) ORDER BY `ID` LIMIT").
+					WithArgs("", 1, 2).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				Todos: []*v1.Todo{
+					{
+						Id:            2,
+						Title:         "title 2",
+						Description:   "description 2",
+						Reminder:      reminder2,
+						LocalReminder: tm2.Format(time.RFC3339),
+						Etag:          `"1"`,
+					},
+				},
+			},
+		},
+		{
+			name: "Filter",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:    "v1",
+					Filter: `title:"title" AND id > 1`,
+				},
+			},
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+					AddRow(2, "title 2", "description 2", tm2, 0, nil, nil, 1, nil, "", nil, "")
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Owner`=\\? AND `DeletedAt` IS NULL AND \\(`Title` LIKE \\? AND `ID` > \\?\\)").
+					WithArgs("", "%title%", 1, defaultPageSize+1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				Todos: []*v1.Todo{
+					{
+						Id:            2,
+						Title:         "title 2",
+						Description:   "description 2",
+						Reminder:      reminder2,
+						LocalReminder: tm2.Format(time.RFC3339),
+						Etag:          `"1"`,
+					},
+				},
+			},
+		},
+		{
+			name: "Order by",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:       "v1",
+					OrderBy:   "reminder desc",
+					PageSize:  1,
+					PageToken: orderToken,
+				},
+			},
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+					AddRow(2, "title 2", "description 2", tm2, 0, nil, nil, 1, nil, "", nil, "")
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Owner`=\\? AND `DeletedAt` IS NULL AND \\(\\(`Reminder` < \\?\\) OR \\(`Reminder` = \\? AND `ID` > \\?\\)\\) ORDER BY `Reminder` DESC, `ID` LIMIT").
+					WithArgs("", tm1, tm1, 1, 2).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				Todos: []*v1.Todo{
+					{
+						Id:            2,
+						Title:         "title 2",
+						Description:   "description 2",
+						Reminder:      reminder2,
+						LocalReminder: tm2.Format(time.RFC3339),
+						Etag:          `"1"`,
+					},
+				},
+			},
+		},
+		{
+			name: "Show deleted",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:         "v1",
+					ShowDeleted: true,
+				},
+			},
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+					AddRow(1, "title 1", "description 1", tm1, 0, nil, tm2, 1, nil, "", nil, "")
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Owner`=\\? ORDER BY `ID` LIMIT").WithArgs("", defaultPageSize+1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				Todos: []*v1.Todo{
+					{
+						Id:            1,
+						Title:         "title 1",
+						Description:   "description 1",
+						Reminder:      reminder1,
+						LocalReminder: tm1.Format(time.RFC3339),
+						Etag:          `"1"`,
+						DeletedAt:     reminder2,
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid order by",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:     "v1",
+					OrderBy: "password",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Invalid filter",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:    "v1",
+					Filter: "title:",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Page token for another filter",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:       "v1",
+					Filter:    "id > 1",
+					PageToken: token,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Tampered page token",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:       "v1",
+					PageToken: token + "x",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Negative page size",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:      "v1",
+					PageSize: -1,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
 		{
 			name: "Empty",
 			s:    s,
@@ -615,8 +1247,8 @@ func Test_toDoServiceServer_ReadAll(t *testing.T) {
 				},
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder"})
-				mock.ExpectQuery("SELECT (.+) FROM Todo").WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"})
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs("", defaultPageSize+1).WillReturnRows(rows)
 			},
 			want: &v1.ReadAllResponse{
 				Api:   "v1",
@@ -650,3 +1282,250 @@ func Test_toDoServiceServer_ReadAll(t *testing.T) {
 		})
 	}
 }
+
+func Test_toDoServiceServer_Complete(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.CompleteRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.TodoServiceServer
+		args    args
+		mock    func()
+		want    *v1.CompleteResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CompleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Status`=\\?, `CompletedAt`=COALESCE").
+					WithArgs(v1.Todo_DONE, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+					AddRow(1, "title", "description", tm, v1.Todo_DONE, tm, nil, 1, nil, "", nil, "")
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1, "").WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+				expectRevision(mock, 1, 1)
+				mock.ExpectCommit()
+			},
+			want: &v1.CompleteResponse{
+				Api: "v1",
+				Todo: &v1.Todo{
+					Id:            1,
+					Title:         "title",
+					Description:   "description",
+					Reminder:      reminder,
+					LocalReminder: tm.Format(time.RFC3339),
+					Etag:          `"1"`,
+					Status:        v1.Todo_DONE,
+					CompletedAt:   reminder,
+				},
+			},
+		},
+		{
+			name: "UPDATE failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CompleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo").WithArgs(v1.Todo_DONE, sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("UPDATE failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CompleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 0)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.Complete(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.Complete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !proto.Equal(got, tt.want) {
+				t.Errorf("toDoServiceServer.Complete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_Reopen(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	mock.ExpectBegin()
+	expectLock(mock, 1, 1)
+	mock.ExpectExec("UPDATE ToDo SET `Status`=\\?, `CompletedAt`=NULL").WithArgs(v1.Todo_OPEN, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+		AddRow(1, "title", "description", tm, v1.Todo_OPEN, nil, nil, 1, nil, "", nil, "")
+	mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1, "").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+	expectRevision(mock, 1, 1)
+	mock.ExpectCommit()
+
+	got, err := s.Reopen(ctx, &v1.ReopenRequest{Api: "v1", Id: 1})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.Reopen() error = %v", err)
+	}
+	want := &v1.ReopenResponse{
+		Api: "v1",
+		Todo: &v1.Todo{
+			Id:            1,
+			Title:         "title",
+			Description:   "description",
+			Reminder:      reminder,
+			LocalReminder: tm.Format(time.RFC3339),
+			Etag:          `"1"`,
+			Status:        v1.Todo_OPEN,
+		},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("toDoServiceServer.Reopen() = %v, want %v", got, want)
+	}
+}
+
+func Test_toDoServiceServer_Undelete(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.UndeleteRequest
+	}
+	tests := []struct {
+		name     string
+		s        v1.TodoServiceServer
+		args     args
+		mock     func()
+		want     *v1.UndeleteResponse
+		wantCode codes.Code
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UndeleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`=NULL").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+					AddRow(1, "title", "description", tm, 0, nil, nil, 1, nil, "", nil, "")
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1, "").WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+				expectRevision(mock, 1, 1)
+				mock.ExpectCommit()
+			},
+			want: &v1.UndeleteResponse{
+				Api: "v1",
+				Todo: &v1.Todo{
+					Id:            1,
+					Title:         "title",
+					Description:   "description",
+					Reminder:      reminder,
+					LocalReminder: tm.Format(time.RFC3339),
+					Etag:          `"1"`,
+				},
+			},
+		},
+		{
+			name: "Not in trash",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UndeleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLock(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`=NULL").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectRollback()
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.Undelete(tt.args.ctx, tt.args.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("toDoServiceServer.Undelete() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if err == nil && !proto.Equal(got, tt.want) {
+				t.Errorf("toDoServiceServer.Undelete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
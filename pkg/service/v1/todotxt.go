@@ -0,0 +1,261 @@
+package v1
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// todoTxtContentType is media type of exported todo.txt files
+	todoTxtContentType = "text/plain; charset=utf-8"
+
+	// todoTxtDateFormat is format of the dates of todo.txt lines
+	todoTxtDateFormat = "2006-01-02"
+
+	// todoTxtInProgress is value of status tag of todo in progress, todo.txt
+	// has no such status
+	todoTxtInProgress = "in-progress"
+)
+
+// formatTodoTxtTime formats the time as date if it is midnight in its time
+// zone, as RFC 3339 time otherwise
+func formatTodoTxtTime(t time.Time) string {
+	if h, m, s := t.Clock(); h == 0 && m == 0 && s == 0 && t.Nanosecond() == 0 {
+		return t.Format(todoTxtDateFormat)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// parseTodoTxtTime parses date or RFC 3339 time, date is midnight in the
+// time zone
+func parseTodoTxtTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation(todoTxtDateFormat, value, loc); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// todoTxtLine renders the todo as todo.txt line
+func todoTxtLine(td *v1.Todo) (string, error) {
+	reminder, err := todoReminder(td)
+	if err != nil {
+		return "", err
+	}
+
+	var words []string
+	if td.Status == v1.Todo_DONE {
+		words = append(words, "x")
+		if td.CompletedAt != nil {
+			completedAt, err := ptypes.Timestamp(td.CompletedAt)
+			if err != nil {
+				return "", status.Error(codes.Unknown, "completed_at field has invalid format-> "+err.Error())
+			}
+			words = append(words, completedAt.UTC().Format(todoTxtDateFormat))
+		}
+	}
+
+	// the line must not break
+	for i, w := range strings.Fields(td.Title) {
+		words = append(words, escapeTodoTxtWord(w, i == 0))
+	}
+	for _, l := range td.Labels {
+		words = append(words, "+"+strings.Join(strings.Fields(l), "_"))
+	}
+	if td.Status == v1.Todo_IN_PROGRESS {
+		words = append(words, "status:"+todoTxtInProgress)
+	}
+	words = append(words, "due:"+formatTodoTxtTime(reminder))
+	if len(td.TimeZone) > 0 {
+		words = append(words, "tz:"+td.TimeZone)
+	}
+	if len(td.Recurrence) > 0 {
+		words = append(words, "rrule:"+td.Recurrence)
+	}
+	words = append(words, "uid:"+strings.Join(strings.Fields(exportUID(td)), "_"))
+
+	return strings.Join(words, " "), nil
+}
+
+// escapeTodoTxtWord prefixes word of the title with backslash if it would
+// be read as something else, first is set for the first word of the title,
+// which must not be read as completion mark, priority or date
+func escapeTodoTxtWord(word string, first bool) string {
+	_, _, tag := todoTxtTag(word)
+	if tag || word[0] == '\\' || word[0] == '+' || word[0] == '@' ||
+		first && (word == "x" || isTodoTxtPriority(word) || isTodoTxtDate(word)) {
+		return "\\" + word
+	}
+	return word
+}
+
+// todoTxtTag splits key:value tag the service reads, tags of other tools
+// and URLs are not tags
+func todoTxtTag(word string) (key, value string, ok bool) {
+	i := strings.IndexByte(word, ':')
+	if i <= 0 || i == len(word)-1 || strings.HasPrefix(word[i+1:], "//") {
+		return "", "", false
+	}
+	switch key = word[:i]; key {
+	case "due", "rrule", "uid", "status", "tz":
+		return key, word[i+1:], true
+	}
+	return "", "", false
+}
+
+// isTodoTxtPriority reports whether the word is priority such as (A)
+func isTodoTxtPriority(word string) bool {
+	return len(word) == 3 && word[0] == '(' && 'A' <= word[1] && word[1] <= 'Z' && word[2] == ')'
+}
+
+// isTodoTxtDate reports whether the word is date of completion or creation
+func isTodoTxtDate(word string) bool {
+	_, err := time.Parse(todoTxtDateFormat, word)
+	return err == nil
+}
+
+// parseTodoTxt converts the todo.txt line to todo, the todo is returned with
+// the external ID even if the line is invalid
+func parseTodoTxt(line string) (*v1.Todo, error) {
+	td := &v1.Todo{}
+	words := strings.Fields(line)
+
+	// completion mark with completion and creation dates, or priority with
+	// creation date, creation date is not kept
+	if len(words) > 0 && words[0] == "x" {
+		td.Status = v1.Todo_DONE
+		words = words[1:]
+	} else if len(words) > 0 && isTodoTxtPriority(words[0]) {
+		words = words[1:]
+	}
+	for i := 0; i < 2 && len(words) > 0 && isTodoTxtDate(words[0]); i++ {
+		words = words[1:]
+	}
+
+	var title []string
+	var due string
+	var statusName string
+	for _, w := range words {
+		// escaped word of the title
+		if len(w) > 1 && w[0] == '\\' {
+			title = append(title, w[1:])
+			continue
+		}
+		if len(w) > 1 && (w[0] == '+' || w[0] == '@') {
+			td.Labels = append(td.Labels, w[1:])
+			continue
+		}
+
+		// key:value tags, other words are part of the title
+		key, value, ok := todoTxtTag(w)
+		if !ok {
+			title = append(title, w)
+			continue
+		}
+		switch key {
+		case "due":
+			due = value
+		case "rrule":
+			td.Recurrence = value
+		case "uid":
+			td.ExternalId = value
+		case "status":
+			statusName = value
+		case "tz":
+			td.TimeZone = value
+		}
+	}
+	td.Title = strings.Join(title, " ")
+
+	switch statusName {
+	case "":
+	case todoTxtInProgress:
+		if td.Status != v1.Todo_DONE {
+			td.Status = v1.Todo_IN_PROGRESS
+		}
+	default:
+		return td, status.Errorf(codes.InvalidArgument, "status tag has unknown value '%s'", statusName)
+	}
+
+	if len(due) == 0 {
+		return td, status.Error(codes.InvalidArgument, "due tag is required for the reminder")
+	}
+	loc, err := loadLocation(td.TimeZone)
+	if err != nil {
+		return td, status.Errorf(codes.InvalidArgument, "tz tag has unknown time zone '%s'", td.TimeZone)
+	}
+	reminder, err := parseTodoTxtTime(due, loc)
+	if err != nil {
+		return td, status.Errorf(codes.InvalidArgument, "due tag '%s' is not date or RFC 3339 time", due)
+	}
+	td.Reminder, err = ptypes.TimestampProto(reminder)
+	if err != nil {
+		return td, status.Error(codes.InvalidArgument, "due tag is invalid-> "+err.Error())
+	}
+
+	return td, nil
+}
+
+// ExportTodoTxt renders todo tasks matching the filter as todo.txt file
+func (s *todoServiceServer) ExportTodoTxt(ctx context.Context, req *v1.ExportTodoTxtRequest) (*httpbody.HttpBody, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	list, err := s.exportList(ctx, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, td := range list {
+		line, err := todoTxtLine(td)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: todoTxtContentType,
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// ImportTodoTxt creates todo tasks from lines of the todo.txt file
+func (s *todoServiceServer) ImportTodoTxt(ctx context.Context, req *v1.ImportTodoTxtRequest) (*v1.ImportSummary, error) {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(req.GetFile().GetData()))
+
+	// error index is the line, so blank lines are skipped rows
+	var rows []importRow
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), utf8BOM))
+		if len(line) == 0 {
+			rows = append(rows, importRow{})
+			continue
+		}
+		td, err := parseTodoTxt(line)
+		rows = append(rows, importRow{todo: td, err: err})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "file field is invalid-> "+err.Error())
+	}
+
+	return s.importRows(ctx, rows)
+}
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+func Test_toDoServiceServer_ExportTodoTxt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Owner`=\\? AND `DeletedAt` IS NULL ORDER BY `ID`$").WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+			AddRow(1, "Water\nplants", "", time.Date(2026, 10, 20, 9, 30, 0, 0, time.UTC), v1.Todo_IN_PROGRESS, nil, nil, 1, nil, "FREQ=WEEKLY", nil, "").
+			AddRow(2, "Pay rent", "", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), v1.Todo_DONE, time.Date(2026, 10, 30, 18, 0, 0, 0, time.UTC), nil, 3, "bank-7", "", nil, ""))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").
+		WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}).AddRow(1, "front garden"))
+
+	got, err := s.ExportTodoTxt(context.Background(), &v1.ExportTodoTxtRequest{Api: "v1"})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.ExportTodoTxt() error = %v", err)
+	}
+	if got.ContentType != todoTxtContentType {
+		t.Errorf("toDoServiceServer.ExportTodoTxt() content type = %s, want %s", got.ContentType, todoTxtContentType)
+	}
+	want := "Water plants +front_garden status:in-progress due:2026-10-20T09:30:00Z rrule:FREQ=WEEKLY uid:todo-1\n" +
+		"x 2026-10-30 Pay rent due:2026-11-01 uid:bank-7\n"
+	if string(got.Data) != want {
+		t.Errorf("toDoServiceServer.ExportTodoTxt() =\n%s\nwant\n%s", got.Data, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_ImportTodoTxt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+
+	file := "(A) 2026-10-01 Call the plumber https://example.com @phone due:2026-10-20 uid:txt-1\n" +
+		"\n" +
+		"Someday maybe uid:txt-2\n" +
+		"x 2026-10-30 2026-10-01 Pay rent due:2026-11-01T08:00:00Z status:in-progress\n"
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO ToDo").
+		WithArgs("Call the plumber https://example.com", "", time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), v1.Todo_OPEN, nil, "txt-1", "", "", nil, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO Label").WithArgs("", "phone").WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("INSERT INTO ToDoLabel").WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectRecordChange(mock, 1, 1)
+	mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO ToDo").
+		WithArgs("Pay rent", "", time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC), v1.Todo_DONE, sqlmock.AnyArg(), nil, "", "", nil, "").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	expectRecordChange(mock, 2, 1)
+	mock.ExpectCommit()
+
+	got, err := s.ImportTodoTxt(context.Background(), &v1.ImportTodoTxtRequest{
+		Api:  "v1",
+		File: &httpbody.HttpBody{ContentType: "text/plain", Data: []byte(file)},
+	})
+	if err != nil {
+		t.Fatalf("toDoServiceServer.ImportTodoTxt() error = %v", err)
+	}
+	if got.Created != 2 || got.Failed != 1 {
+		t.Errorf("toDoServiceServer.ImportTodoTxt() created = %d, failed = %d, want 2, 1", got.Created, got.Failed)
+	}
+	// index of the error is the line, the blank line is counted
+	if len(got.Errors) != 1 || got.Errors[0].Index != 2 || got.Errors[0].ExternalId != "txt-2" {
+		t.Errorf("toDoServiceServer.ImportTodoTxt() errors = %v", got.Errors)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_parseTodoTxt_roundTrip(t *testing.T) {
+	reminder, _ := ptypes.TimestampProto(time.Date(2026, 10, 20, 9, 30, 0, 0, time.UTC))
+	td := &v1.Todo{
+		Id:         1,
+		Title:      "Water plants at 10:30",
+		Reminder:   reminder,
+		Status:     v1.Todo_IN_PROGRESS,
+		Labels:     []string{"garden", "home"},
+		Recurrence: "FREQ=WEEKLY;BYDAY=TU",
+		TimeZone:   "Asia/Colombo",
+	}
+
+	line, err := todoTxtLine(td)
+	if err != nil {
+		t.Fatalf("todoTxtLine() error = %v", err)
+	}
+	if !strings.Contains(line, " due:2026-10-20T15:00:00+05:30 ") {
+		t.Errorf("todoTxtLine() = %s, want due in local time", line)
+	}
+	got, err := parseTodoTxt(line)
+	if err != nil {
+		t.Fatalf("parseTodoTxt() error = %v", err)
+	}
+
+	td.ExternalId = "todo-1"
+	td.Id = 0
+	if got.String() != td.String() {
+		t.Errorf("parseTodoTxt() = %v, want %v", got, td)
+	}
+
+	// words of the title which look like markers or tags are escaped
+	for _, title := range []string{
+		"x marks the spot",
+		"Buy +milk due:tomorrow",
+		"(A) grade",
+		"2026-10-20 2026-10-21 report",
+		"Email @bob about tz:UTC",
+		`Open C:\temp \x`,
+		"See https://example.com wiki:page",
+	} {
+		td := &v1.Todo{Id: 1, Title: title, Reminder: reminder}
+		line, err := todoTxtLine(td)
+		if err != nil {
+			t.Fatalf("todoTxtLine() error = %v", err)
+		}
+		got, err := parseTodoTxt(line)
+		if err != nil {
+			t.Fatalf("parseTodoTxt(%s) error = %v", line, err)
+		}
+		if got.Title != title || got.Status != v1.Todo_OPEN || len(got.Labels) != 0 || got.ExternalId != "todo-1" {
+			t.Errorf("parseTodoTxt(%s) = %v, want title '%s'", line, got, title)
+		}
+	}
+}
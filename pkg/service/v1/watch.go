@@ -0,0 +1,327 @@
+package v1
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// defaultWatchInterval is how often watchers poll for changes made by
+	// other replicas, changes of this replica wake them up immediately
+	defaultWatchInterval = time.Second
+
+	// watchBatchSize is maximum number of events read by a single query
+	watchBatchSize = 1000
+
+	// watchGapTimeout is how long a missing event ID is waited for before it
+	// is treated as rolled back. Event IDs are allocated on insert, so an
+	// event of a transaction which is not committed yet can appear after
+	// events with greater IDs.
+	watchGapTimeout = 10 * time.Second
+)
+
+// WithWatchInterval sets how often Watch polls the database for changes
+// made by other replicas
+func WithWatchInterval(d time.Duration) Option {
+	return func(s *todoServiceServer) {
+		if d > 0 {
+			s.watchInterval = d
+		}
+	}
+}
+
+// changeNotifier wakes up watchers when the service commits a change
+type changeNotifier struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+func newChangeNotifier() *changeNotifier {
+	return &changeNotifier{ch: make(chan struct{})}
+}
+
+// wait returns channel which is closed on the next change
+func (n *changeNotifier) wait() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.ch
+}
+
+// notify wakes up all waiting watchers
+func (n *changeNotifier) notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	close(n.ch)
+	n.ch = make(chan struct{})
+}
+
+// recordEvent appends change of the ToDo to the event log read by Watch,
+// changes are recorded by recordChange which calls it
+func (s *todoServiceServer) recordEvent(ctx context.Context, q querier, id int64, t v1.TodoEvent_Type) error {
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.ExecContext(ctx, "INSERT INTO ToDoEvent(`ToDoID`, `Type`, `CreatedAt`, `Owner`) VALUES(?, ?, ?, ?)",
+		id, t, s.now().UTC(), tenant); err != nil {
+		return status.Error(codes.Unknown, "failed to insert into ToDoEvent-> "+err.Error())
+	}
+	return nil
+}
+
+// todoEvent is row of the event log
+type todoEvent struct {
+	id        int64
+	todoID    int64
+	typ       v1.TodoEvent_Type
+	createdAt time.Time
+
+	// owner is the tenant owning the ToDo
+	owner string
+}
+
+// watchCursor tracks position of the watcher in the event log
+type watchCursor struct {
+	// after is ID of the event all events up to which were sent
+	after int64
+
+	// sent is set of events after the cursor which were already sent
+	sent map[int64]bool
+}
+
+// advance moves the cursor over sent events which are not preceded by
+// missing ones, events must be sorted by ID
+func (c *watchCursor) advance(events []todoEvent, now time.Time) {
+	for _, e := range events {
+		if !c.sent[e.id] {
+			return
+		}
+		if e.id != c.after+1 && now.Sub(e.createdAt) < watchGapTimeout {
+			// wait for the events of the transactions which are not committed yet
+			return
+		}
+		for id := range c.sent {
+			if id <= e.id {
+				delete(c.sent, id)
+			}
+		}
+		c.after = e.id
+	}
+}
+
+// resumeToken returns token to resume watching after the event
+func (c *watchCursor) resumeToken(id int64) string {
+	if id > c.after {
+		// events before it may still be missing, they are sent again on resume
+		id = c.after
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+// watchStart returns ID of the event the watching starts after
+func (s *todoServiceServer) watchStart(ctx context.Context, q querier, token string) (int64, error) {
+	query := "SELECT COALESCE(MAX(`ID`), 0) FROM ToDoEvent"
+	var args []interface{}
+	if len(token) > 0 {
+		after, err := strconv.ParseInt(token, 10, 64)
+		if err != nil || after < 0 {
+			return 0, status.Error(codes.InvalidArgument, "resume_token field is invalid")
+		}
+		if after == 0 {
+			return 0, nil
+		}
+
+		// token is ID of existing event unless the event was purged
+		query = "SELECT `ID` FROM ToDoEvent WHERE `ID`=?"
+		args = append(args, after)
+	}
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, status.Error(codes.Unknown, "failed to select from ToDoEvent-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, status.Error(codes.Unknown, "failed to retrieve data from ToDoEvent-> "+err.Error())
+		}
+		return 0, status.Error(codes.OutOfRange, "resume_token field is expired, start watching without it")
+	}
+
+	var after int64
+	if err := rows.Scan(&after); err != nil {
+		return 0, status.Error(codes.Unknown, "failed to retrieve field values from ToDoEvent row-> "+err.Error())
+	}
+	return after, nil
+}
+
+// readEvents reads events after the cursor
+func readEvents(ctx context.Context, q querier, after int64) ([]todoEvent, error) {
+	rows, err := q.QueryContext(ctx, "SELECT `ID`, `ToDoID`, `Type`, `CreatedAt`, `Owner` FROM ToDoEvent WHERE `ID`>? ORDER BY `ID` LIMIT ?",
+		after, watchBatchSize)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoEvent-> "+err.Error())
+	}
+	defer rows.Close()
+
+	var list []todoEvent
+	for rows.Next() {
+		var e todoEvent
+		if err := rows.Scan(&e.id, &e.todoID, &e.typ, &e.createdAt, &e.owner); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDoEvent row-> "+err.Error())
+		}
+		list = append(list, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoEvent-> "+err.Error())
+	}
+	return list, nil
+}
+
+// readTodos queries ToDo list by IDs together with labels, ToDo moved to
+// trash is included. It does not check the tenant, IDs are taken from events
+// already filtered by their owner.
+func readTodos(ctx context.Context, q querier, ids []int64) (map[int64]*v1.Todo, error) {
+	byID := make(map[int64]*v1.Todo, len(ids))
+	if len(ids) == 0 {
+		return byID, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT "+todoColumns+" FROM ToDo WHERE `ID` IN (?"+strings.Repeat(", ?", len(ids)-1)+")", args...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := make([]*v1.Todo, 0, len(ids))
+	for rows.Next() {
+		td, err := scanTodo(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, td)
+		byID[td.Id] = td
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+	rows.Close()
+
+	if err := loadLabels(ctx, q, list); err != nil {
+		return nil, err
+	}
+	return byID, nil
+}
+
+// Watch streams changes of todo tasks of the tenant
+func (s *todoServiceServer) Watch(req *v1.WatchRequest, stream v1.TodoService_WatchServer) error {
+	// check if the API version requested by client is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return err
+	}
+
+	filter, err := parseFilter(req.Filter)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "filter field is invalid-> "+err.Error())
+	}
+
+	// connection is taken from the pool for each query only, as the stream
+	// stays idle most of the time
+	ctx := stream.Context()
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	after, err := s.watchStart(ctx, s.db, req.ResumeToken)
+	if err != nil {
+		return err
+	}
+	cursor := &watchCursor{after: after, sent: map[int64]bool{}}
+
+	for {
+		// taken before reading, so that change committed meanwhile is not missed
+		changed := s.changes.wait()
+
+		events, err := readEvents(ctx, s.db, cursor.after)
+		if err != nil {
+			return err
+		}
+
+		var pending []todoEvent
+		var ids []int64
+		for _, e := range events {
+			if !cursor.sent[e.id] {
+				// events of other tenants are skipped as if they were sent
+				cursor.sent[e.id] = true
+				if e.owner == tenant {
+					pending = append(pending, e)
+					ids = append(ids, e.todoID)
+				}
+			}
+		}
+		before := cursor.after
+		cursor.advance(events, s.now())
+
+		todos, err := readTodos(ctx, s.db, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range pending {
+			td, ok := todos[e.todoID]
+			if !ok {
+				// ToDo is purged already
+				td = &v1.Todo{Id: e.todoID}
+			} else if !filterMatch(filter, td) {
+				continue
+			}
+
+			eventTime, err := ptypes.TimestampProto(e.createdAt)
+			if err != nil {
+				return status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+			}
+
+			if err := stream.Send(&v1.TodoEvent{
+				Api:         apiVersion,
+				Type:        e.typ,
+				Todo:        td,
+				EventTime:   eventTime,
+				ResumeToken: cursor.resumeToken(e.id),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(events) == watchBatchSize && cursor.after != before {
+			// more events are waiting
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		case <-time.After(s.watchInterval):
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// watchStream collects events sent by Watch
+type watchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*v1.TodoEvent
+	onSend func()
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(e *v1.TodoEvent) error {
+	s.events = append(s.events, e)
+	s.onSend()
+	return nil
+}
+
+func Test_toDoServiceServer_Watch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+
+	ctx, cancel := context.WithCancel(WithTenant(context.Background(), "team-a"))
+	defer cancel()
+	stream := &watchStream{ctx: ctx}
+	stream.onSend = func() {
+		if len(stream.events) == 2 {
+			cancel()
+		}
+	}
+
+	mock.ExpectQuery("SELECT COALESCE\\(MAX").WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(5))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoEvent WHERE `ID`>\\?").WithArgs(5, watchBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "ToDoID", "Type", "CreatedAt", "Owner"}).
+			AddRow(6, 1, v1.TodoEvent_CREATED, tm, "team-a").
+			AddRow(7, 2, v1.TodoEvent_UPDATED, tm, "team-a").
+			AddRow(8, 4, v1.TodoEvent_CREATED, tm, "team-b").
+			AddRow(9, 3, v1.TodoEvent_DELETED, tm, "team-a"))
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Status", "CompletedAt", "DeletedAt", "Version", "ExternalID", "Recurrence", "ListID", "TimeZone"}).
+			AddRow(1, "first", "", tm, 0, nil, nil, 1, nil, "", nil, "").
+			AddRow(2, "second", "", tm, 0, nil, nil, 2, nil, "", nil, ""))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+
+	if err := s.Watch(&v1.WatchRequest{Api: "v1", Filter: `title != "second"`}, stream); err != nil {
+		t.Fatalf("toDoServiceServer.Watch() error = %v", err)
+	}
+	if len(stream.events) != 2 {
+		t.Fatalf("toDoServiceServer.Watch() sent %d events, want 2", len(stream.events))
+	}
+
+	created, deleted := stream.events[0], stream.events[1]
+	if created.Type != v1.TodoEvent_CREATED || created.Todo.Title != "first" || created.ResumeToken != "6" {
+		t.Errorf("toDoServiceServer.Watch() first event = %v", created)
+	}
+	// todo 3 is purged already, so only its ID is known, event of another
+	// tenant is skipped
+	if deleted.Type != v1.TodoEvent_DELETED || deleted.Todo.Id != 3 || deleted.ResumeToken != "9" {
+		t.Errorf("toDoServiceServer.Watch() second event = %v", deleted)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_toDoServiceServer_Watch_expiredToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewTodoServiceServer(db)
+
+	mock.ExpectQuery("SELECT `ID` FROM ToDoEvent WHERE `ID`=\\?").WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+
+	err = s.Watch(&v1.WatchRequest{Api: "v1", ResumeToken: "3"}, &watchStream{ctx: context.Background()})
+	if code := status.Code(err); code != codes.OutOfRange {
+		t.Errorf("toDoServiceServer.Watch() error = %v, wantCode %v", err, codes.OutOfRange)
+	}
+}
+
+func Test_watchCursor_advance(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	c := &watchCursor{after: 5, sent: map[int64]bool{6: true, 8: true, 9: true}}
+
+	// event 7 is missing, transaction writing it may be still running
+	events := []todoEvent{
+		{id: 6, createdAt: now},
+		{id: 8, createdAt: now.Add(-time.Second)},
+		{id: 9, createdAt: now},
+	}
+	c.advance(events, now)
+	if c.after != 6 {
+		t.Errorf("watchCursor.advance() after = %d, want 6", c.after)
+	}
+	if token := c.resumeToken(9); token != "6" {
+		t.Errorf("watchCursor.resumeToken() = %s, want 6", token)
+	}
+
+	// event 7 is treated as rolled back after the timeout
+	c.advance(events[1:], now.Add(watchGapTimeout))
+	if c.after != 9 || len(c.sent) != 0 {
+		t.Errorf("watchCursor.advance() after = %d, sent = %v, want 9 and none", c.after, c.sent)
+	}
+}
@@ -0,0 +1,435 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"go.uber.org/zap"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/Lilanga/go-grpc-http-rest-microservice/pkg/logger"
+)
+
+const (
+	// webhookSignatureHeader holds "sha256=" followed by hex encoded
+	// HMAC-SHA256 of the timestamp header value, "." and the body
+	webhookSignatureHeader = "X-Webhook-Signature"
+
+	// webhookTimestampHeader holds Unix time the delivery was sent at
+	webhookTimestampHeader = "X-Webhook-Timestamp"
+
+	// webhookEventHeader holds ID of the event, receivers use it to drop
+	// repeated deliveries
+	webhookEventHeader = "X-Webhook-Event-Id"
+
+	// defaultWebhookMaxAttempts is number of attempts after which delivery
+	// is moved to dead letters
+	defaultWebhookMaxAttempts = 10
+
+	// defaultWebhookBackoff is delay before the first retry, it doubles on
+	// every next retry
+	defaultWebhookBackoff = 30 * time.Second
+
+	// defaultWebhookMaxBackoff is the maximum delay between retries
+	defaultWebhookMaxBackoff = time.Hour
+
+	// webhookBatchSize is maximum number of deliveries claimed at once
+	webhookBatchSize = 50
+
+	// webhookLease is how long claimed deliveries are reserved for the
+	// replica sending them
+	webhookLease = 10 * time.Minute
+
+	// webhookTimeout is how long a receiver has to answer
+	webhookTimeout = 10 * time.Second
+
+	// maxWebhookErrorLength is the maximum length of the stored error
+	maxWebhookErrorLength = 1024
+)
+
+// WebhookDeliverer queues todo events for webhook subscriptions and posts
+// them. Failed deliveries are retried with exponential backoff and moved to
+// dead letters after the last attempt. Every event is delivered at least
+// once.
+type WebhookDeliverer struct {
+	db       *sql.DB
+	interval time.Duration
+	client   *http.Client
+
+	// maxAttempts is number of attempts before delivery becomes dead letter
+	maxAttempts int
+
+	// backoff is delay before the first retry, maxBackoff limits the
+	// doubled delays
+	backoff    time.Duration
+	maxBackoff time.Duration
+
+	// now returns current time, replaced in tests
+	now func() time.Time
+}
+
+// NewWebhookDeliverer creates deliverer posting todo events to subscribed
+// URLs every interval
+func NewWebhookDeliverer(db *sql.DB, interval time.Duration) *WebhookDeliverer {
+	return &WebhookDeliverer{
+		db:          db,
+		interval:    interval,
+		client:      &http.Client{Timeout: webhookTimeout},
+		maxAttempts: defaultWebhookMaxAttempts,
+		backoff:     defaultWebhookBackoff,
+		maxBackoff:  defaultWebhookMaxBackoff,
+		now:         time.Now,
+	}
+}
+
+// Run queues and posts events periodically until the context is done
+func (d *WebhookDeliverer) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := d.Enqueue(ctx); err != nil {
+			logger.Log.Error("failed to queue webhook deliveries", zap.String("reason", err.Error()))
+		} else if n > 0 {
+			logger.Log.Debug("queued webhook deliveries", zap.Int("count", n))
+		}
+
+		if n, err := d.Deliver(ctx); err != nil {
+			logger.Log.Error("failed to post webhook deliveries", zap.String("reason", err.Error()))
+		} else if n > 0 {
+			logger.Log.Info("posted webhook deliveries", zap.Int("count", n))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// webhookSubscription is subscription as seen by the deliverer
+type webhookSubscription struct {
+	id     int64
+	owner  string
+	types  map[v1.TodoEvent_Type]bool
+	cursor int64
+}
+
+// Enqueue queues events recorded since the last call for the matching
+// subscriptions and returns number of queued deliveries
+func (d *WebhookDeliverer) Enqueue(ctx context.Context) (int, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// lock makes replicas queue every event once
+	subs, err := lockWebhookSubscriptions(ctx, tx)
+	if err != nil || len(subs) == 0 {
+		return 0, err
+	}
+
+	after := subs[0].cursor
+	for _, sub := range subs {
+		if sub.cursor < after {
+			after = sub.cursor
+		}
+	}
+	events, err := readEvents(ctx, tx, after)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	ids := make([]int64, len(events))
+	for i, e := range events {
+		ids[i] = e.todoID
+	}
+	todos, err := readTodos(ctx, tx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	now := d.now().UTC()
+	payloads := map[int64]string{}
+	queued := 0
+	for _, sub := range subs {
+		cursor := sub.cursor
+		for _, e := range events {
+			if e.id <= sub.cursor {
+				continue
+			}
+			if e.id != cursor+1 && now.Sub(e.createdAt) < watchGapTimeout {
+				// wait for the events of the transactions which are not committed yet
+				break
+			}
+			cursor = e.id
+
+			// subscription receives events of todos of its tenant only
+			if e.owner != sub.owner || len(sub.types) > 0 && !sub.types[e.typ] {
+				continue
+			}
+
+			payload, ok := payloads[e.id]
+			if !ok {
+				if payload, err = webhookPayload(e, todos[e.todoID]); err != nil {
+					return 0, err
+				}
+				payloads[e.id] = payload
+			}
+
+			if _, err := tx.ExecContext(ctx, "INSERT INTO WebhookDelivery(`SubscriptionID`, `EventID`, `EventType`, `Payload`, `NextAttemptAt`, `CreatedAt`) VALUES(?, ?, ?, ?, ?, ?)",
+				sub.id, e.id, e.typ, payload, now, now); err != nil {
+				return 0, err
+			}
+			queued++
+		}
+
+		if cursor != sub.cursor {
+			if _, err := tx.ExecContext(ctx, "UPDATE WebhookSubscription SET `EventCursor`=? WHERE `ID`=?", cursor, sub.id); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return queued, nil
+}
+
+// lockWebhookSubscriptions reads all subscriptions and locks them until the
+// end of the transaction
+func lockWebhookSubscriptions(ctx context.Context, q querier) ([]*webhookSubscription, error) {
+	rows, err := q.QueryContext(ctx, "SELECT `ID`, `Owner`, `EventTypes`, `EventCursor` FROM WebhookSubscription ORDER BY `ID` FOR UPDATE")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*webhookSubscription
+	for rows.Next() {
+		sub := &webhookSubscription{types: map[v1.TodoEvent_Type]bool{}}
+		var types string
+		if err := rows.Scan(&sub.id, &sub.owner, &types, &sub.cursor); err != nil {
+			return nil, err
+		}
+		parsed, err := parseEventTypes(types)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range parsed {
+			sub.types[t] = true
+		}
+		list = append(list, sub)
+	}
+	return list, rows.Err()
+}
+
+// webhookPayload returns JSON body posted for the event, td is nil if the
+// ToDo was purged
+func webhookPayload(e todoEvent, td *v1.Todo) (string, error) {
+	if td == nil {
+		td = &v1.Todo{Id: e.todoID}
+	}
+
+	eventTime, err := ptypes.TimestampProto(e.createdAt)
+	if err != nil {
+		return "", err
+	}
+
+	m := jsonpb.Marshaler{OrigName: true}
+	return m.MarshalToString(&v1.TodoEvent{
+		Api:         apiVersion,
+		Type:        e.typ,
+		Todo:        td,
+		EventTime:   eventTime,
+		ResumeToken: strconv.FormatInt(e.id, 10),
+	})
+}
+
+// webhookDelivery is queued delivery claimed for sending
+type webhookDelivery struct {
+	id       int64
+	eventID  int64
+	payload  string
+	attempts int
+	url      string
+	secret   string
+}
+
+// Deliver posts a batch of due deliveries and returns number of successful
+// ones
+func (d *WebhookDeliverer) Deliver(ctx context.Context) (int, error) {
+	list, err := d.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, del := range list {
+		postErr := d.post(ctx, del)
+		if postErr == nil {
+			if _, err := d.db.ExecContext(ctx, "DELETE FROM WebhookDelivery WHERE `ID`=?", del.id); err != nil {
+				return sent, err
+			}
+			sent++
+			continue
+		}
+
+		if err := d.fail(ctx, del, postErr); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// claim reserves due deliveries for the lease period and returns them
+func (d *WebhookDeliverer) claim(ctx context.Context) ([]*webhookDelivery, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := d.now().UTC()
+	rows, err := tx.QueryContext(ctx, "SELECT d.`ID`, d.`EventID`, d.`Payload`, d.`Attempts`, s.`URL`, s.`Secret`"+
+		" FROM WebhookDelivery d JOIN WebhookSubscription s ON s.`ID`=d.`SubscriptionID`"+
+		" WHERE d.`NextAttemptAt`<=? AND (d.`LeaseUntil` IS NULL OR d.`LeaseUntil`<=?)"+
+		" ORDER BY d.`ID` LIMIT ? FOR UPDATE",
+		now, now, webhookBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*webhookDelivery
+	for rows.Next() {
+		var del webhookDelivery
+		if err := rows.Scan(&del.id, &del.eventID, &del.payload, &del.attempts, &del.url, &del.secret); err != nil {
+			return nil, err
+		}
+		list = append(list, &del)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, 0, len(list)+1)
+	args = append(args, now.Add(webhookLease))
+	for _, del := range list {
+		args = append(args, del.id)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE WebhookDelivery SET `LeaseUntil`=? WHERE `ID` IN (?"+strings.Repeat(", ?", len(list)-1)+")",
+		args...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// webhookSignature returns value of the signature header
+func webhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// post sends the delivery, any response status other than 2xx is failure
+func (d *WebhookDeliverer) post(ctx context.Context, del *webhookDelivery) error {
+	body := []byte(del.payload)
+	req, err := http.NewRequest(http.MethodPost, del.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	timestamp := strconv.FormatInt(d.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookTimestampHeader, timestamp)
+	req.Header.Set(webhookEventHeader, strconv.FormatInt(del.eventID, 10))
+	req.Header.Set(webhookSignatureHeader, webhookSignature(del.secret, timestamp, body))
+
+	resp, err := d.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// drain the body to reuse the connection
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("receiver returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// retryDelay returns delay before the next attempt after the given number
+// of failed ones
+func (d *WebhookDeliverer) retryDelay(attempts int) time.Duration {
+	delay := d.backoff
+	for i := 1; i < attempts && delay < d.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.maxBackoff {
+		delay = d.maxBackoff
+	}
+	return delay
+}
+
+// fail schedules retry of the failed delivery or moves it to dead letters
+// after the last attempt
+func (d *WebhookDeliverer) fail(ctx context.Context, del *webhookDelivery, postErr error) error {
+	attempts := del.attempts + 1
+	msg := []rune(postErr.Error())
+	if len(msg) > maxWebhookErrorLength {
+		msg = msg[:maxWebhookErrorLength]
+	}
+	now := d.now().UTC()
+
+	if attempts < d.maxAttempts {
+		_, err := d.db.ExecContext(ctx, "UPDATE WebhookDelivery SET `Attempts`=?, `LastError`=?, `NextAttemptAt`=?, `LeaseUntil`=NULL WHERE `ID`=?",
+			attempts, string(msg), now.Add(d.retryDelay(attempts)), del.id)
+		return err
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "INSERT INTO WebhookDeadLetter(`SubscriptionID`, `EventID`, `EventType`, `Payload`, `Attempts`, `LastError`, `CreatedAt`, `FailedAt`)"+
+		" SELECT `SubscriptionID`, `EventID`, `EventType`, `Payload`, ?, ?, `CreatedAt`, ? FROM WebhookDelivery WHERE `ID`=?",
+		attempts, string(msg), now, del.id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM WebhookDelivery WHERE `ID`=?", del.id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestWebhookDeliverer_Enqueue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	d := NewWebhookDeliverer(db, time.Second)
+	d.now = func() time.Time { return tm }
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT `ID`, `Owner`, `EventTypes`, `EventCursor` FROM WebhookSubscription ORDER BY `ID` FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Owner", "EventTypes", "EventCursor"}).
+			AddRow(1, "", "", 5).
+			AddRow(2, "", "2", 6).
+			AddRow(3, "team-a", "", 5))
+	// event 8 is not committed yet, so event 9 waits for it
+	mock.ExpectQuery("SELECT (.+) FROM ToDoEvent WHERE `ID`>\\?").WithArgs(5, watchBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "ToDoID", "Type", "CreatedAt", "Owner"}).
+			AddRow(6, 1, v1.TodoEvent_CREATED, tm, "").
+			AddRow(7, 2, v1.TodoEvent_DELETED, tm, "").
+			AddRow(9, 1, v1.TodoEvent_UPDATED, tm, ""))
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1, 2, 1).WillReturnRows(todoRows(1, 2))
+	mock.ExpectQuery("SELECT (.+) FROM ToDoLabel").WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Name"}))
+	mock.ExpectExec("INSERT INTO WebhookDelivery").WithArgs(1, 6, v1.TodoEvent_CREATED, sqlmock.AnyArg(), tm, tm).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO WebhookDelivery").WithArgs(1, 7, v1.TodoEvent_DELETED, sqlmock.AnyArg(), tm, tm).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec("UPDATE WebhookSubscription SET `EventCursor`=\\? WHERE `ID`=\\?").WithArgs(7, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO WebhookDelivery").WithArgs(2, 7, v1.TodoEvent_DELETED, sqlmock.AnyArg(), tm, tm).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec("UPDATE WebhookSubscription SET `EventCursor`=\\? WHERE `ID`=\\?").WithArgs(7, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// subscription of another tenant only moves past the events
+	mock.ExpectExec("UPDATE WebhookSubscription SET `EventCursor`=\\? WHERE `ID`=\\?").WithArgs(7, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	got, err := d.Enqueue(context.Background())
+	if err != nil {
+		t.Fatalf("WebhookDeliverer.Enqueue() error = %v", err)
+	}
+	if got != 3 {
+		t.Errorf("WebhookDeliverer.Enqueue() = %v, want 3", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_webhookPayload(t *testing.T) {
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	got, err := webhookPayload(todoEvent{id: 7, todoID: 2, typ: v1.TodoEvent_DELETED, createdAt: tm}, nil)
+	if err != nil {
+		t.Fatalf("webhookPayload() error = %v", err)
+	}
+	want := `{"api":"v1","type":"DELETED","todo":{"id":"2"},"event_time":"2026-10-18T12:00:00Z","resume_token":"7"}`
+	if got != want {
+		t.Errorf("webhookPayload() = %v, want %v", got, want)
+	}
+}
+
+func TestWebhookDeliverer_Deliver(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	// receiver accepts event 1 only and checks the signature of all
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		timestamp := r.Header.Get(webhookTimestampHeader)
+		if timestamp != "1792324800" {
+			t.Errorf("%s header = '%s', want '1792324800'", webhookTimestampHeader, timestamp)
+		}
+		if sig := r.Header.Get(webhookSignatureHeader); sig != webhookSignature("secret", timestamp, body) {
+			t.Errorf("%s header = '%s' does not match the body", webhookSignatureHeader, sig)
+		}
+		if r.Header.Get(webhookEventHeader) != "1" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	d := NewWebhookDeliverer(db, time.Second)
+	d.now = func() time.Time { return tm }
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM WebhookDelivery d JOIN WebhookSubscription s (.+) FOR UPDATE").
+		WithArgs(tm, tm, webhookBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "EventID", "Payload", "Attempts", "URL", "Secret"}).
+			AddRow(11, 1, `{"type":"CREATED"}`, 0, srv.URL, "secret").
+			AddRow(12, 2, `{"type":"UPDATED"}`, 2, srv.URL, "secret").
+			AddRow(13, 3, `{"type":"DELETED"}`, defaultWebhookMaxAttempts-1, srv.URL, "secret"))
+	mock.ExpectExec("UPDATE WebhookDelivery SET `LeaseUntil`=\\? WHERE `ID` IN \\(\\?, \\?, \\?\\)").
+		WithArgs(tm.Add(webhookLease), 11, 12, 13).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+	mock.ExpectExec("DELETE FROM WebhookDelivery WHERE `ID`=\\?").WithArgs(11).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE WebhookDelivery SET `Attempts`=\\?, `LastError`=\\?, `NextAttemptAt`=\\?").
+		WithArgs(3, "receiver returned status 500", tm.Add(4*defaultWebhookBackoff), 12).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO WebhookDeadLetter(.+) SELECT (.+) FROM WebhookDelivery WHERE `ID`=\\?").
+		WithArgs(defaultWebhookMaxAttempts, "receiver returned status 500", tm, 13).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DELETE FROM WebhookDelivery WHERE `ID`=\\?").WithArgs(13).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	got, err := d.Deliver(context.Background())
+	if err != nil {
+		t.Fatalf("WebhookDeliverer.Deliver() error = %v", err)
+	}
+	if got != 1 {
+		t.Errorf("WebhookDeliverer.Deliver() = %v, want 1", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWebhookDeliverer_retryDelay(t *testing.T) {
+	d := &WebhookDeliverer{backoff: 30 * time.Second, maxBackoff: time.Hour}
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: 8, want: time.Hour},
+		{attempts: 100, want: time.Hour},
+	}
+	for _, tt := range tests {
+		if got := d.retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("WebhookDeliverer.retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,418 @@
+package v1
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// maxWebhookURLLength is the maximum length of the subscription URL
+	maxWebhookURLLength = 2048
+
+	// maxWebhookSecretLength is the maximum length of the signing secret
+	maxWebhookSecretLength = 128
+
+	// defaultDeadLetterPageSize is number of dead letters listed when client
+	// does not set page size
+	defaultDeadLetterPageSize = 50
+
+	// maxDeadLetterPageSize is the maximum number of dead letters listed at once
+	maxDeadLetterPageSize = 1000
+)
+
+type webhookServiceServer struct {
+	db *sql.DB
+
+	// now returns current time, replaced in tests
+	now func() time.Time
+}
+
+// NewWebhookServiceServer creates new webhook subscription service
+func NewWebhookServiceServer(db *sql.DB) v1.WebhookServiceServer {
+	return &webhookServiceServer{db: db, now: time.Now}
+}
+
+// newWebhookSecret returns random signing secret
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// checkWebhookURL validates URL events are posted to
+func checkWebhookURL(s string) error {
+	if len(s) == 0 {
+		return status.Error(codes.InvalidArgument, "url field is required")
+	}
+	if len(s) > maxWebhookURLLength {
+		return status.Errorf(codes.InvalidArgument, "url field is longer than %d characters", maxWebhookURLLength)
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "url field is invalid-> "+err.Error())
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return status.Error(codes.InvalidArgument, "url field must be absolute http or https URL")
+	}
+	return nil
+}
+
+// formatEventTypes returns event types in the stored form
+func formatEventTypes(types []v1.TodoEvent_Type) (string, error) {
+	seen := map[v1.TodoEvent_Type]bool{}
+	var list []string
+	for _, t := range types {
+		if _, ok := v1.TodoEvent_Type_name[int32(t)]; !ok {
+			return "", status.Errorf(codes.InvalidArgument, "event_types field has unknown value '%d'", t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			list = append(list, strconv.Itoa(int(t)))
+		}
+	}
+	return strings.Join(list, ","), nil
+}
+
+// parseEventTypes parses event types in the stored form, nil means all types
+func parseEventTypes(s string) ([]v1.TodoEvent_Type, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+
+	var types []v1.TodoEvent_Type
+	for _, v := range strings.Split(s, ",") {
+		t, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, status.Error(codes.Unknown, "event_types field has invalid format-> "+err.Error())
+		}
+		types = append(types, v1.TodoEvent_Type(t))
+	}
+	return types, nil
+}
+
+// CreateSubscription registers URL todo events are pushed to
+func (s *webhookServiceServer) CreateSubscription(ctx context.Context, req *v1.CreateSubscriptionRequest) (*v1.CreateSubscriptionResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
+
+	sub := req.Subscription
+	if sub == nil {
+		return nil, status.Error(codes.InvalidArgument, "subscription field is required")
+	}
+	if err := checkWebhookURL(sub.Url); err != nil {
+		return nil, err
+	}
+	types, err := formatEventTypes(sub.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := sub.Secret
+	if len(secret) == 0 {
+		if secret, err = newWebhookSecret(); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to generate secret-> "+err.Error())
+		}
+	} else if utf8.RuneCountInString(secret) > maxWebhookSecretLength {
+		return nil, status.Errorf(codes.InvalidArgument, "secret field is longer than %d characters", maxWebhookSecretLength)
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := connectDB(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	// only events recorded after the subscription is created are pushed
+	createdAt := s.now().UTC()
+	res, err := c.ExecContext(ctx, "INSERT INTO WebhookSubscription(`URL`, `Secret`, `EventTypes`, `EventCursor`, `CreatedAt`, `Owner`) SELECT ?, ?, ?, COALESCE(MAX(`ID`), 0), ?, ? FROM ToDoEvent",
+		sub.Url, secret, types, createdAt, tenant)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to insert into WebhookSubscription-> "+err.Error())
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve id for created WebhookSubscription-> "+err.Error())
+	}
+
+	createTime, err := ptypes.TimestampProto(createdAt)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+	}
+
+	return &v1.CreateSubscriptionResponse{
+		Api: apiVersion,
+		Subscription: &v1.WebhookSubscription{
+			Id:         id,
+			Url:        sub.Url,
+			Secret:     secret,
+			EventTypes: sub.EventTypes,
+			CreateTime: createTime,
+		},
+	}, nil
+}
+
+// ListSubscriptions returns all subscriptions of the tenant without their
+// secrets
+func (s *webhookServiceServer) ListSubscriptions(ctx context.Context, req *v1.ListSubscriptionsRequest) (*v1.ListSubscriptionsResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := connectDB(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.QueryContext(ctx, "SELECT `ID`, `URL`, `EventTypes`, `CreatedAt` FROM WebhookSubscription WHERE `Owner`=? ORDER BY `ID`", tenant)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from WebhookSubscription-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.WebhookSubscription{}
+	for rows.Next() {
+		var sub v1.WebhookSubscription
+		var types string
+		var createdAt time.Time
+		if err := rows.Scan(&sub.Id, &sub.Url, &types, &createdAt); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from WebhookSubscription row-> "+err.Error())
+		}
+		if sub.EventTypes, err = parseEventTypes(types); err != nil {
+			return nil, err
+		}
+		if sub.CreateTime, err = ptypes.TimestampProto(createdAt); err != nil {
+			return nil, status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+		}
+		list = append(list, &sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from WebhookSubscription-> "+err.Error())
+	}
+
+	return &v1.ListSubscriptionsResponse{
+		Api:           apiVersion,
+		Subscriptions: list,
+	}, nil
+}
+
+// DeleteSubscription removes subscription with its pending deliveries and
+// dead letters
+func (s *webhookServiceServer) DeleteSubscription(ctx context.Context, req *v1.DeleteSubscriptionRequest) (*v1.DeleteSubscriptionResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := connectDB(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.ExecContext(ctx, "DELETE FROM WebhookSubscription WHERE `ID`=? AND `Owner`=?", req.Id, tenant)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to delete WebhookSubscription-> "+err.Error())
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+
+	if rows == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("WebhookSubscription with ID='%d' is not found",
+			req.Id))
+	}
+
+	return &v1.DeleteSubscriptionResponse{
+		Api:     apiVersion,
+		Deleted: rows,
+	}, nil
+}
+
+// ListDeadLetters returns deliveries to subscriptions of the tenant which
+// failed after all retries
+func (s *webhookServiceServer) ListDeadLetters(ctx context.Context, req *v1.ListDeadLettersRequest) (*v1.ListDeadLettersResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
+
+	size := int(req.PageSize)
+	if size < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size field must not be negative")
+	}
+	if size == 0 {
+		size = defaultDeadLetterPageSize
+	}
+	if size > maxDeadLetterPageSize {
+		size = maxDeadLetterPageSize
+	}
+
+	// page token is ID of the last dead letter of the previous page
+	var after int64
+	if len(req.PageToken) > 0 {
+		var err error
+		if after, err = strconv.ParseInt(req.PageToken, 10, 64); err != nil || after < 0 {
+			return nil, status.Error(codes.InvalidArgument, "page_token field is invalid")
+		}
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where := " WHERE `ID`>? AND `SubscriptionID` IN (SELECT `ID` FROM WebhookSubscription WHERE `Owner`=?)"
+	args := []interface{}{after, tenant}
+	if req.SubscriptionId != 0 {
+		where += " AND `SubscriptionID`=?"
+		args = append(args, req.SubscriptionId)
+	}
+	args = append(args, size+1)
+
+	// get SQL connection from pool
+	c, err := connectDB(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	rows, err := c.QueryContext(ctx, "SELECT `ID`, `SubscriptionID`, `EventID`, `EventType`, `Payload`, `Attempts`, `LastError`, `CreatedAt`, `FailedAt` FROM WebhookDeadLetter"+
+		where+" ORDER BY `ID` LIMIT ?", args...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from WebhookDeadLetter-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.WebhookDeadLetter{}
+	for rows.Next() {
+		var d v1.WebhookDeadLetter
+		var createdAt, failedAt time.Time
+		if err := rows.Scan(&d.Id, &d.SubscriptionId, &d.EventId, &d.EventType, &d.Payload, &d.Attempts, &d.LastError, &createdAt, &failedAt); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from WebhookDeadLetter row-> "+err.Error())
+		}
+		if d.CreateTime, err = ptypes.TimestampProto(createdAt); err != nil {
+			return nil, status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+		}
+		if d.FailTime, err = ptypes.TimestampProto(failedAt); err != nil {
+			return nil, status.Error(codes.Unknown, "failed_at field has invalid format-> "+err.Error())
+		}
+		list = append(list, &d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from WebhookDeadLetter-> "+err.Error())
+	}
+
+	// one extra row tells if there is a next page
+	var next string
+	if len(list) > size {
+		list = list[:size]
+		next = strconv.FormatInt(list[size-1].Id, 10)
+	}
+
+	return &v1.ListDeadLettersResponse{
+		Api:           apiVersion,
+		DeadLetters:   list,
+		NextPageToken: next,
+	}, nil
+}
+
+// ReplayDeadLetter queues the dead letter for delivery again
+func (s *webhookServiceServer) ReplayDeadLetter(ctx context.Context, req *v1.ReplayDeadLetterRequest) (*v1.ReplayDeadLetterResponse, error) {
+	// check if the API version requested by client is supported by server
+	if err := checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
+
+	tenant, err := requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get SQL connection from pool
+	c, err := connectDB(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	tx, err := c.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to start transaction-> "+err.Error())
+	}
+	defer tx.Rollback()
+
+	now := s.now().UTC()
+	res, err := tx.ExecContext(ctx, "INSERT INTO WebhookDelivery(`SubscriptionID`, `EventID`, `EventType`, `Payload`, `NextAttemptAt`, `CreatedAt`)"+
+		" SELECT `SubscriptionID`, `EventID`, `EventType`, `Payload`, ?, ? FROM WebhookDeadLetter"+
+		" WHERE `ID`=? AND `SubscriptionID` IN (SELECT `ID` FROM WebhookSubscription WHERE `Owner`=?)",
+		now, now, req.Id, tenant)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to insert into WebhookDelivery-> "+err.Error())
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+
+	if rows == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("WebhookDeadLetter with ID='%d' is not found",
+			req.Id))
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM WebhookDeadLetter WHERE `ID`=?", req.Id); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to delete WebhookDeadLetter-> "+err.Error())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to commit transaction-> "+err.Error())
+	}
+
+	return &v1.ReplayDeadLetterResponse{
+		Api: apiVersion,
+	}, nil
+}
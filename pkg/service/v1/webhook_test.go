@@ -0,0 +1,328 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "github.com/Lilanga/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func Test_webhookServiceServer_CreateSubscription(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	createTime, _ := ptypes.TimestampProto(tm)
+	s := &webhookServiceServer{db: db, now: func() time.Time { return tm }}
+
+	tests := []struct {
+		name     string
+		req      *v1.CreateSubscriptionRequest
+		mock     func()
+		want     *v1.WebhookSubscription
+		wantCode codes.Code
+	}{
+		{
+			name: "OK",
+			req: &v1.CreateSubscriptionRequest{Api: "v1", Subscription: &v1.WebhookSubscription{
+				Url:        "https://example.com/hook",
+				Secret:     "secret",
+				EventTypes: []v1.TodoEvent_Type{v1.TodoEvent_CREATED, v1.TodoEvent_DELETED, v1.TodoEvent_CREATED},
+			}},
+			mock: func() {
+				mock.ExpectExec("INSERT INTO WebhookSubscription(.+) SELECT (.+) FROM ToDoEvent").
+					WithArgs("https://example.com/hook", "secret", "0,2", tm, "").
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			want: &v1.WebhookSubscription{
+				Id:         3,
+				Url:        "https://example.com/hook",
+				Secret:     "secret",
+				EventTypes: []v1.TodoEvent_Type{v1.TodoEvent_CREATED, v1.TodoEvent_DELETED, v1.TodoEvent_CREATED},
+				CreateTime: createTime,
+			},
+		},
+		{
+			name:     "Missing subscription",
+			req:      &v1.CreateSubscriptionRequest{Api: "v1"},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Relative URL",
+			req:      &v1.CreateSubscriptionRequest{Api: "v1", Subscription: &v1.WebhookSubscription{Url: "/hook"}},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Unsupported scheme",
+			req:      &v1.CreateSubscriptionRequest{Api: "v1", Subscription: &v1.WebhookSubscription{Url: "ftp://example.com/hook"}},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Unknown event type",
+			req: &v1.CreateSubscriptionRequest{Api: "v1", Subscription: &v1.WebhookSubscription{
+				Url:        "https://example.com/hook",
+				EventTypes: []v1.TodoEvent_Type{7},
+			}},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "INSERT failed",
+			req: &v1.CreateSubscriptionRequest{Api: "v1", Subscription: &v1.WebhookSubscription{
+				Url:    "https://example.com/hook",
+				Secret: "secret",
+			}},
+			mock: func() {
+				mock.ExpectExec("INSERT INTO WebhookSubscription").WillReturnError(errors.New("INSERT failed"))
+			},
+			wantCode: codes.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := s.CreateSubscription(ctx, tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("webhookServiceServer.CreateSubscription() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err == nil && !proto.Equal(got.Subscription, tt.want) {
+				t.Errorf("webhookServiceServer.CreateSubscription() = %v, want %v", got.Subscription, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_webhookServiceServer_CreateSubscription_generatedSecret(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewWebhookServiceServer(db)
+
+	mock.ExpectExec("INSERT INTO WebhookSubscription").
+		WithArgs("http://example.com/hook", sqlmock.AnyArg(), "", sqlmock.AnyArg(), "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	got, err := s.CreateSubscription(context.Background(), &v1.CreateSubscriptionRequest{
+		Api:          "v1",
+		Subscription: &v1.WebhookSubscription{Url: "http://example.com/hook"},
+	})
+	if err != nil {
+		t.Fatalf("webhookServiceServer.CreateSubscription() error = %v", err)
+	}
+	if len(got.Subscription.Secret) != 64 {
+		t.Errorf("webhookServiceServer.CreateSubscription() secret = '%s', want 64 hex digits", got.Subscription.Secret)
+	}
+}
+
+func Test_webhookServiceServer_ListSubscriptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewWebhookServiceServer(db)
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	createTime, _ := ptypes.TimestampProto(tm)
+
+	mock.ExpectQuery("SELECT `ID`, `URL`, `EventTypes`, `CreatedAt` FROM WebhookSubscription WHERE `Owner`=\\?").WithArgs("team-a").
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "URL", "EventTypes", "CreatedAt"}).
+			AddRow(1, "https://example.com/all", "", tm).
+			AddRow(2, "https://example.com/deleted", "2", tm))
+
+	got, err := s.ListSubscriptions(WithTenant(context.Background(), "team-a"), &v1.ListSubscriptionsRequest{Api: "v1"})
+	if err != nil {
+		t.Fatalf("webhookServiceServer.ListSubscriptions() error = %v", err)
+	}
+	want := &v1.ListSubscriptionsResponse{
+		Api: "v1",
+		Subscriptions: []*v1.WebhookSubscription{
+			{Id: 1, Url: "https://example.com/all", CreateTime: createTime},
+			{Id: 2, Url: "https://example.com/deleted", EventTypes: []v1.TodoEvent_Type{v1.TodoEvent_DELETED}, CreateTime: createTime},
+		},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("webhookServiceServer.ListSubscriptions() = %v, want %v", got, want)
+	}
+}
+
+func Test_webhookServiceServer_DeleteSubscription(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewWebhookServiceServer(db)
+
+	tests := []struct {
+		name     string
+		rows     int64
+		wantCode codes.Code
+	}{
+		{
+			name: "OK",
+			rows: 1,
+		},
+		{
+			name:     "Not found",
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectExec("DELETE FROM WebhookSubscription WHERE `ID`=\\? AND `Owner`=\\?").WithArgs(1, "").
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			_, err := s.DeleteSubscription(context.Background(), &v1.DeleteSubscriptionRequest{Api: "v1", Id: 1})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("webhookServiceServer.DeleteSubscription() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_webhookServiceServer_ListDeadLetters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewWebhookServiceServer(db)
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+	deadLetterRows := func(ids ...int64) *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"ID", "SubscriptionID", "EventID", "EventType", "Payload", "Attempts", "LastError", "CreatedAt", "FailedAt"})
+		for _, id := range ids {
+			rows.AddRow(id, 2, 10+id, v1.TodoEvent_UPDATED, "{}", 10, "receiver returned status 500", tm, tm)
+		}
+		return rows
+	}
+	deadLetter := func(id int64) *v1.WebhookDeadLetter {
+		return &v1.WebhookDeadLetter{Id: id, SubscriptionId: 2, EventId: 10 + id, EventType: v1.TodoEvent_UPDATED, Payload: "{}",
+			Attempts: 10, LastError: "receiver returned status 500", CreateTime: ts, FailTime: ts}
+	}
+
+	tests := []struct {
+		name     string
+		req      *v1.ListDeadLettersRequest
+		mock     func()
+		want     *v1.ListDeadLettersResponse
+		wantCode codes.Code
+	}{
+		{
+			name: "First page",
+			req:  &v1.ListDeadLettersRequest{Api: "v1", PageSize: 2},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM WebhookDeadLetter WHERE `ID`>\\? AND `SubscriptionID` IN \\(SELECT `ID` FROM WebhookSubscription WHERE `Owner`=\\?\\) ORDER BY `ID` LIMIT \\?").
+					WithArgs(0, "", 3).
+					WillReturnRows(deadLetterRows(1, 2, 3))
+			},
+			want: &v1.ListDeadLettersResponse{Api: "v1", DeadLetters: []*v1.WebhookDeadLetter{deadLetter(1), deadLetter(2)}, NextPageToken: "2"},
+		},
+		{
+			name: "Last page of subscription",
+			req:  &v1.ListDeadLettersRequest{Api: "v1", SubscriptionId: 2, PageToken: "2"},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM WebhookDeadLetter WHERE (.+) AND `SubscriptionID`=\\? ORDER BY").WithArgs(2, "", 2, defaultDeadLetterPageSize+1).
+					WillReturnRows(deadLetterRows(3))
+			},
+			want: &v1.ListDeadLettersResponse{Api: "v1", DeadLetters: []*v1.WebhookDeadLetter{deadLetter(3)}},
+		},
+		{
+			name:     "Invalid page token",
+			req:      &v1.ListDeadLettersRequest{Api: "v1", PageToken: "abc"},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Negative page size",
+			req:      &v1.ListDeadLettersRequest{Api: "v1", PageSize: -1},
+			mock:     func() {},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := s.ListDeadLetters(context.Background(), tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("webhookServiceServer.ListDeadLetters() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err == nil && !proto.Equal(got, tt.want) {
+				t.Errorf("webhookServiceServer.ListDeadLetters() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_webhookServiceServer_ReplayDeadLetter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	tm := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	s := &webhookServiceServer{db: db, now: func() time.Time { return tm }}
+
+	tests := []struct {
+		name     string
+		mock     func()
+		wantCode codes.Code
+	}{
+		{
+			name: "OK",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO WebhookDelivery(.+) SELECT (.+) FROM WebhookDeadLetter WHERE `ID`=\\? AND `SubscriptionID` IN").WithArgs(tm, tm, 1, "").
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectExec("DELETE FROM WebhookDeadLetter WHERE `ID`=\\?").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Not found",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO WebhookDelivery").WithArgs(tm, tm, 1, "").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			_, err := s.ReplayDeadLetter(context.Background(), &v1.ReplayDeadLetterRequest{Api: "v1", Id: 1})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("webhookServiceServer.ReplayDeadLetter() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
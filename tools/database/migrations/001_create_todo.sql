@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `ToDo` (
+    `ID` BIGINT NOT NULL AUTO_INCREMENT,
+    `Title` VARCHAR(200) NOT NULL DEFAULT '',
+    `Description` VARCHAR(1024) NOT NULL DEFAULT '',
+    `Reminder` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`ID`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
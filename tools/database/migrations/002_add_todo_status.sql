@@ -0,0 +1,5 @@
+-- Status holds numeric value of v1.Todo_Status enum
+ALTER TABLE `ToDo`
+    ADD COLUMN `Status` TINYINT NOT NULL DEFAULT 0,
+    ADD COLUMN `CompletedAt` DATETIME(6) NULL,
+    ADD INDEX `IX_ToDo_Status` (`Status`);
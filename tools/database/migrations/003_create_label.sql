@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS `Label` (
+    `ID` BIGINT NOT NULL AUTO_INCREMENT,
+    `Name` VARCHAR(64) NOT NULL,
+    PRIMARY KEY (`ID`),
+    UNIQUE INDEX `UX_Label_Name` (`Name`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `ToDoLabel` (
+    `ToDoID` BIGINT NOT NULL,
+    `LabelID` BIGINT NOT NULL,
+    PRIMARY KEY (`ToDoID`, `LabelID`),
+    INDEX `IX_ToDoLabel_LabelID` (`LabelID`),
+    CONSTRAINT `FK_ToDoLabel_ToDo` FOREIGN KEY (`ToDoID`) REFERENCES `ToDo` (`ID`) ON DELETE CASCADE,
+    CONSTRAINT `FK_ToDoLabel_Label` FOREIGN KEY (`LabelID`) REFERENCES `Label` (`ID`) ON DELETE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,3 @@
+ALTER TABLE `ToDo`
+    ADD COLUMN `DeletedAt` DATETIME(6) NULL,
+    ADD INDEX `IX_ToDo_DeletedAt` (`DeletedAt`);
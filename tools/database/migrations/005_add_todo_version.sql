@@ -0,0 +1,3 @@
+-- Version is incremented on every change and exposed as etag
+ALTER TABLE `ToDo`
+    ADD COLUMN `Version` BIGINT NOT NULL DEFAULT 1;
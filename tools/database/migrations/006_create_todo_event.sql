@@ -0,0 +1,11 @@
+-- ToDoEvent is append-only log of ToDo changes read by Watch, Type holds
+-- numeric value of v1.TodoEvent_Type enum. Events are kept after the ToDo
+-- is purged, so there is no foreign key.
+CREATE TABLE IF NOT EXISTS `ToDoEvent` (
+    `ID` BIGINT NOT NULL AUTO_INCREMENT,
+    `ToDoID` BIGINT NOT NULL,
+    `Type` TINYINT NOT NULL,
+    `CreatedAt` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`ID`),
+    INDEX `IX_ToDoEvent_CreatedAt` (`CreatedAt`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,5 @@
+-- ExternalID is id of the todo in the system it was imported from, NULL for
+-- todos created in this service
+ALTER TABLE `ToDo`
+    ADD COLUMN `ExternalID` VARCHAR(128) NULL,
+    ADD UNIQUE INDEX `UX_ToDo_ExternalID` (`ExternalID`);
@@ -0,0 +1,11 @@
+-- IdempotencyKey stores responses of requests sent with an idempotency key to
+-- return them on retries, RequestHash is SHA-256 of the request payload
+CREATE TABLE IF NOT EXISTS `IdempotencyKey` (
+    `Key` VARCHAR(128) NOT NULL,
+    `Method` VARCHAR(64) NOT NULL,
+    `RequestHash` BINARY(32) NOT NULL,
+    `Response` MEDIUMBLOB NOT NULL,
+    `CreatedAt` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`Key`, `Method`),
+    INDEX `IX_IdempotencyKey_CreatedAt` (`CreatedAt`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
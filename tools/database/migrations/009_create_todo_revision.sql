@@ -0,0 +1,15 @@
+-- ToDoRevision is history of ToDo changes, Revision is the ToDo version after
+-- the change and Type holds numeric value of v1.TodoEvent_Type enum. Before
+-- and After are protobuf encoded v1.Todo snapshots, Before is NULL for the
+-- created ToDo. Revisions are kept after the ToDo is purged, so there is no
+-- foreign key.
+CREATE TABLE IF NOT EXISTS `ToDoRevision` (
+    `ToDoID` BIGINT NOT NULL,
+    `Revision` BIGINT NOT NULL,
+    `Type` TINYINT NOT NULL,
+    `Before` BLOB NULL,
+    `After` BLOB NOT NULL,
+    `Actor` VARCHAR(128) NOT NULL DEFAULT '',
+    `CreatedAt` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`ToDoID`, `Revision`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
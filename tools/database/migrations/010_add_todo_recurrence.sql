@@ -0,0 +1,4 @@
+-- Recurrence is RFC 5545 recurrence rule of the todo, empty for todos which
+-- do not repeat
+ALTER TABLE `ToDo`
+    ADD COLUMN `Recurrence` VARCHAR(255) NOT NULL DEFAULT '';
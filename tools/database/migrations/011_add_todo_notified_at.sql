@@ -0,0 +1,10 @@
+-- NotifiedAt is the last reminder of the todo which was sent, reminder is due
+-- again when it is moved after it. ReminderLeaseUntil reserves due reminder
+-- for the replica sending it.
+ALTER TABLE `ToDo`
+    ADD COLUMN `NotifiedAt` DATETIME(6) NULL,
+    ADD COLUMN `ReminderLeaseUntil` DATETIME(6) NULL,
+    ADD INDEX `IX_ToDo_Reminder` (`Reminder`);
+
+-- reminders which were due before the dispatcher existed are not sent
+UPDATE `ToDo` SET `NotifiedAt`=`Reminder` WHERE `Reminder`<NOW(6);
@@ -0,0 +1,46 @@
+-- WebhookSubscription is URL todo events are pushed to. EventTypes is comma
+-- separated list of numeric v1.TodoEvent_Type values, empty for all types.
+-- EventCursor is ID of the ToDoEvent all events up to which are queued.
+CREATE TABLE IF NOT EXISTS `WebhookSubscription` (
+    `ID` BIGINT NOT NULL AUTO_INCREMENT,
+    `URL` VARCHAR(2048) NOT NULL,
+    `Secret` VARCHAR(128) NOT NULL,
+    `EventTypes` VARCHAR(64) NOT NULL DEFAULT '',
+    `EventCursor` BIGINT NOT NULL,
+    `CreatedAt` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`ID`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- WebhookDelivery is queue of events to be posted, Payload is the JSON body.
+-- LeaseUntil reserves the delivery for the replica sending it.
+CREATE TABLE IF NOT EXISTS `WebhookDelivery` (
+    `ID` BIGINT NOT NULL AUTO_INCREMENT,
+    `SubscriptionID` BIGINT NOT NULL,
+    `EventID` BIGINT NOT NULL,
+    `EventType` TINYINT NOT NULL,
+    `Payload` MEDIUMTEXT NOT NULL,
+    `Attempts` INT NOT NULL DEFAULT 0,
+    `LastError` VARCHAR(1024) NOT NULL DEFAULT '',
+    `NextAttemptAt` DATETIME(6) NOT NULL,
+    `LeaseUntil` DATETIME(6) NULL,
+    `CreatedAt` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`ID`),
+    INDEX `IX_WebhookDelivery_NextAttemptAt` (`NextAttemptAt`),
+    CONSTRAINT `FK_WebhookDelivery_WebhookSubscription` FOREIGN KEY (`SubscriptionID`) REFERENCES `WebhookSubscription` (`ID`) ON DELETE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- WebhookDeadLetter is delivery which failed after all retries, kept until
+-- it is replayed or its subscription is deleted
+CREATE TABLE IF NOT EXISTS `WebhookDeadLetter` (
+    `ID` BIGINT NOT NULL AUTO_INCREMENT,
+    `SubscriptionID` BIGINT NOT NULL,
+    `EventID` BIGINT NOT NULL,
+    `EventType` TINYINT NOT NULL,
+    `Payload` MEDIUMTEXT NOT NULL,
+    `Attempts` INT NOT NULL,
+    `LastError` VARCHAR(1024) NOT NULL DEFAULT '',
+    `CreatedAt` DATETIME(6) NOT NULL,
+    `FailedAt` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`ID`),
+    CONSTRAINT `FK_WebhookDeadLetter_WebhookSubscription` FOREIGN KEY (`SubscriptionID`) REFERENCES `WebhookSubscription` (`ID`) ON DELETE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
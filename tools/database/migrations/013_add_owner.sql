@@ -0,0 +1,25 @@
+-- Owner is the tenant the row belongs to, rows created before tenants were
+-- introduced belong to the default tenant with empty name. Labels, external
+-- IDs and idempotency keys are unique within the tenant only.
+ALTER TABLE `ToDo`
+    ADD COLUMN `Owner` VARCHAR(64) NOT NULL DEFAULT '',
+    DROP INDEX `UX_ToDo_ExternalID`,
+    ADD UNIQUE INDEX `UX_ToDo_Owner_ExternalID` (`Owner`, `ExternalID`),
+    ADD INDEX `IX_ToDo_Owner_ID` (`Owner`, `ID`);
+
+ALTER TABLE `ToDoEvent`
+    ADD COLUMN `Owner` VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE `Label`
+    ADD COLUMN `Owner` VARCHAR(64) NOT NULL DEFAULT '',
+    DROP INDEX `UX_Label_Name`,
+    ADD UNIQUE INDEX `UX_Label_Owner_Name` (`Owner`, `Name`);
+
+ALTER TABLE `IdempotencyKey`
+    ADD COLUMN `Owner` VARCHAR(64) NOT NULL DEFAULT '',
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (`Owner`, `Key`, `Method`);
+
+ALTER TABLE `WebhookSubscription`
+    ADD COLUMN `Owner` VARCHAR(64) NOT NULL DEFAULT '',
+    ADD INDEX `IX_WebhookSubscription_Owner` (`Owner`);
@@ -0,0 +1,16 @@
+-- ToDoList groups todos of a project, ListID of the todo is NULL when it is
+-- not in any list. Todos in trash are detached when their list is deleted.
+CREATE TABLE IF NOT EXISTS `ToDoList` (
+    `ID` BIGINT NOT NULL AUTO_INCREMENT,
+    `Owner` VARCHAR(64) NOT NULL DEFAULT '',
+    `Name` VARCHAR(200) NOT NULL,
+    `Description` VARCHAR(1024) NOT NULL DEFAULT '',
+    `CreatedAt` DATETIME(6) NOT NULL,
+    `UpdatedAt` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`ID`),
+    UNIQUE INDEX `UX_ToDoList_Owner_Name` (`Owner`, `Name`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+ALTER TABLE `ToDo`
+    ADD COLUMN `ListID` BIGINT NULL,
+    ADD CONSTRAINT `FK_ToDo_ToDoList` FOREIGN KEY (`ListID`) REFERENCES `ToDoList` (`ID`) ON DELETE SET NULL;
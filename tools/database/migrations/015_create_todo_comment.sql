@@ -0,0 +1,13 @@
+-- ToDoComment is discussion of the ToDo, EditedAt is NULL until the comment
+-- is edited. Comments are removed together with the ToDo when it is purged.
+CREATE TABLE IF NOT EXISTS `ToDoComment` (
+    `ID` BIGINT NOT NULL AUTO_INCREMENT,
+    `ToDoID` BIGINT NOT NULL,
+    `Author` VARCHAR(128) NOT NULL DEFAULT '',
+    `Body` TEXT NOT NULL,
+    `CreatedAt` DATETIME(6) NOT NULL,
+    `EditedAt` DATETIME(6) NULL,
+    PRIMARY KEY (`ID`),
+    INDEX `IX_ToDoComment_ToDoID_ID` (`ToDoID`, `ID`),
+    CONSTRAINT `FK_ToDoComment_ToDo` FOREIGN KEY (`ToDoID`) REFERENCES `ToDo` (`ID`) ON DELETE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,15 @@
+-- ToDoAttachment is file attached to the ToDo, its content is kept in the
+-- blob store under BlobKey. Rows are removed together with the ToDo when it
+-- is purged, the purger removes the contents before.
+CREATE TABLE IF NOT EXISTS `ToDoAttachment` (
+    `ID` BIGINT NOT NULL AUTO_INCREMENT,
+    `ToDoID` BIGINT NOT NULL,
+    `FileName` VARCHAR(255) NOT NULL,
+    `ContentType` VARCHAR(255) NOT NULL,
+    `Size` BIGINT NOT NULL,
+    `BlobKey` VARCHAR(64) NOT NULL,
+    `CreatedAt` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`ID`),
+    INDEX `IX_ToDoAttachment_ToDoID_ID` (`ToDoID`, `ID`),
+    CONSTRAINT `FK_ToDoAttachment_ToDo` FOREIGN KEY (`ToDoID`) REFERENCES `ToDo` (`ID`) ON DELETE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,4 @@
+-- FT_ToDo_Title_Description is used by Search when todos are searched by
+-- MySQL, which keeps it in sync with the todos.
+ALTER TABLE `ToDo`
+    ADD FULLTEXT INDEX `FT_ToDo_Title_Description` (`Title`, `Description`);
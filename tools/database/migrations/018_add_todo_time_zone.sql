@@ -0,0 +1,4 @@
+-- TimeZone is IANA time zone of the reminder, empty for UTC. Reminder stays
+-- UTC, the zone keeps local time of recurring reminders across DST changes.
+ALTER TABLE `ToDo`
+    ADD COLUMN `TimeZone` VARCHAR(64) NOT NULL DEFAULT '';